@@ -1,14 +1,21 @@
 package ami
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
 	"github.com/spf13/cobra"
 
 	"github.com/giantswarm/microerror"
+
+	"github.com/giantswarm/devctl/v8/pkg/gen/input/ami"
 )
 
 const (
 	flagArch                    = "arch"
-	flagChannel                 = "stable"
+	flagChannel                 = "channel"
 	flagChinaBucketName         = "china.bucket"
 	flagChinaBucketRegion       = "china.region"
 	flagChinaAWSAccessKeyID     = "aws.accesskeyid"
@@ -17,11 +24,22 @@ const (
 	flagMinimumVersion          = "minimum.version"
 	flagPrimaryDomain           = "primary.domain"
 	flagKeepExisting            = "keep.existing"
+	flagIncremental             = "incremental"
+	flagHTTPTimeout             = "http.timeout"
+	flagRetries                 = "retries"
+	flagWorkers                 = "workers"
+	flagFormat                  = "format"
+	flagVerify                  = "verify"
+	flagVerifyRegions           = "verify.regions"
+
+	// flagChannelDeprecated is the name --channel was registered with by
+	// mistake. It is kept for existing invocations.
+	flagChannelDeprecated = "stable"
 )
 
 type flag struct {
-	Arch                    string
-	Channel                 string
+	Archs                   []string
+	Channels                []string
 	ChinaBucketName         string
 	ChinaBucketRegion       string
 	ChinaAWSAccessKeyID     string
@@ -30,11 +48,20 @@ type flag struct {
 	MinimumVersion          string
 	PrimaryDomain           string
 	KeepExisting            string
+	Incremental             bool
+	HTTPTimeout             time.Duration
+	Retries                 int
+	Workers                 int
+	Formats                 []string
+	Verify                  bool
+	VerifyRegions           []string
 }
 
 func (f *flag) Init(cmd *cobra.Command) {
-	cmd.Flags().StringVar(&f.Arch, flagArch, "amd64-usr", `Architecture of the image, e.g. "amd64-usr".`)
-	cmd.Flags().StringVar(&f.Channel, flagChannel, "stable", `Channel of the OS.`)
+	cmd.Flags().StringSliceVar(&f.Archs, flagArch, []string{"amd64-usr"}, `Architectures of the image, e.g. "amd64-usr,arm64-usr". Repeat or comma-separate for multiple.`)
+	cmd.Flags().StringSliceVar(&f.Channels, flagChannel, []string{"stable"}, `Channels of the OS, e.g. "stable,beta". Repeat or comma-separate for multiple.`)
+	cmd.Flags().StringSliceVar(&f.Channels, flagChannelDeprecated, []string{"stable"}, `Channels of the OS.`)
+	_ = cmd.Flags().MarkDeprecated(flagChannelDeprecated, "use --"+flagChannel+" instead")
 	cmd.Flags().StringVar(&f.ChinaBucketName, flagChinaBucketName, "flatcar-prod-ami-import-cn-north-1", `S3 bucket name to get version info in china.`)
 	cmd.Flags().StringVar(&f.ChinaBucketRegion, flagChinaBucketRegion, "cn-north-1", `Region containing S3 bucket to get version info in china.`)
 	cmd.Flags().StringVar(&f.ChinaAWSAccessKeyID, flagChinaAWSAccessKeyID, "", `AWS Access Key ID for china.`)
@@ -43,13 +70,42 @@ func (f *flag) Init(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&f.MinimumVersion, flagMinimumVersion, "2191.5.0", `Minimum version of flatcar to use for generation, e.g. "2134.3.0".`)
 	cmd.Flags().StringVar(&f.PrimaryDomain, flagPrimaryDomain, "flatcar-linux.net", `Domain to use as a source for AMIs.`)
 	cmd.Flags().StringVar(&f.KeepExisting, flagKeepExisting, "", `Keep versions already defined in file specified.`)
-
+	cmd.Flags().BoolVar(&f.Incremental, flagIncremental, false, `Only scrape versions newer than the newest version in --`+flagKeepExisting+`.`)
+	cmd.Flags().DurationVar(&f.HTTPTimeout, flagHTTPTimeout, 30*time.Second, `Timeout of a single request to the release mirror.`)
+	cmd.Flags().IntVar(&f.Retries, flagRetries, 3, `Number of times a request failing with a network error, 429 or 5xx is retried.`)
+	cmd.Flags().IntVar(&f.Workers, flagWorkers, 8, `Number of versions scraped concurrently.`)
+	cmd.Flags().StringSliceVar(&f.Formats, flagFormat, []string{ami.FormatLegacy}, fmt.Sprintf(`Output formats, one or more of <%s>. %q writes aws-ami.yaml.template for a single channel and architecture, %q writes amis.json and %q writes zz_generated.amis.go for all of them.`, strings.Join(ami.AllFormats(), "|"), ami.FormatLegacy, ami.FormatJSON, ami.FormatGo))
+	cmd.Flags().BoolVar(&f.Verify, flagVerify, false, `Check that every AMI ID is well-formed and every release has an AMI in each expected region before writing anything.`)
+	cmd.Flags().StringSliceVar(&f.VerifyRegions, flagVerifyRegions, nil, `Regions every release must have an AMI in with --`+flagVerify+`. Defaults to the regions of the newest release of each channel and architecture.`)
 }
 
 func (f *flag) Validate() error {
 	if f.Dir == "" {
 		return microerror.Maskf(invalidFlagError, "--%s must not be empty", flagDir)
 	}
+	for _, format := range f.Formats {
+		if !slices.Contains(ami.AllFormats(), format) {
+			return microerror.Maskf(invalidFlagError, "--%s must be one of <%s>, got %#q", flagFormat, strings.Join(ami.AllFormats(), "|"), format)
+		}
+	}
+	if slices.Contains(f.Formats, ami.FormatLegacy) && (len(f.Archs) != 1 || len(f.Channels) != 1) {
+		return microerror.Maskf(invalidFlagError, "--%s=%s supports a single --%s and --%s, use --%s=%s or %s instead", flagFormat, ami.FormatLegacy, flagArch, flagChannel, flagFormat, ami.FormatJSON, ami.FormatGo)
+	}
+	if len(f.VerifyRegions) > 0 && !f.Verify {
+		return microerror.Maskf(invalidFlagError, "--%s requires --%s", flagVerifyRegions, flagVerify)
+	}
+	if f.Incremental && f.KeepExisting == "" {
+		return microerror.Maskf(invalidFlagError, "--%s requires --%s", flagIncremental, flagKeepExisting)
+	}
+	if f.HTTPTimeout <= 0 {
+		return microerror.Maskf(invalidFlagError, "--%s must be positive", flagHTTPTimeout)
+	}
+	if f.Retries < 0 {
+		return microerror.Maskf(invalidFlagError, "--%s must not be negative", flagRetries)
+	}
+	if f.Workers < 1 {
+		return microerror.Maskf(invalidFlagError, "--%s must be at least 1", flagWorkers)
+	}
 
 	return nil
 }
@@ -36,7 +36,25 @@ func (r *runner) Run(cmd *cobra.Command, args []string) error {
 }
 
 func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) error {
-	c := ami.Config(*r.flag)
+	c := ami.Config{
+		Archs:                   r.flag.Archs,
+		Channels:                r.flag.Channels,
+		ChinaBucketName:         r.flag.ChinaBucketName,
+		ChinaBucketRegion:       r.flag.ChinaBucketRegion,
+		ChinaAWSAccessKeyID:     r.flag.ChinaAWSAccessKeyID,
+		ChinaAWSSecretAccessKey: r.flag.ChinaAWSSecretAccessKey,
+		Dir:                     r.flag.Dir,
+		MinimumVersion:          r.flag.MinimumVersion,
+		PrimaryDomain:           r.flag.PrimaryDomain,
+		KeepExisting:            r.flag.KeepExisting,
+		Incremental:             r.flag.Incremental,
+		HTTPTimeout:             r.flag.HTTPTimeout,
+		Retries:                 r.flag.Retries,
+		Workers:                 r.flag.Workers,
+		Formats:                 r.flag.Formats,
+		Verify:                  r.flag.Verify,
+		VerifyRegions:           r.flag.VerifyRegions,
+	}
 
 	amiInput, err := ami.New(c)
 	if err != nil {
@@ -50,7 +68,7 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 
 	err = gen.Execute(
 		ctx,
-		amiInput.AMIFile(),
+		amiInput.Files()...,
 	)
 	if err != nil {
 		return microerror.Mask(err)
@@ -1,21 +1,53 @@
 package apptest
 
 import (
+	"slices"
+	"strings"
+
+	"github.com/giantswarm/microerror"
 	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/devctl/v8/pkg/gen/input/apptest"
+)
+
+const (
+	flagChart            = "chart"
+	flagInstallNamespace = "install-namespace"
+	flagProviders        = "providers"
+	flagUpgrade          = "upgrade"
 )
 
 type flag struct {
 	AppName  string
 	RepoName string
 	Catalog  string
+
+	Chart            string
+	InstallNamespace string
+	Providers        []string
+	Upgrade          bool
 }
 
 func (f *flag) Init(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&f.AppName, "app-name", "", "The name of the app in the catalog")
 	cmd.Flags().StringVar(&f.RepoName, "repo-name", "", "The name of the repo")
 	cmd.Flags().StringVar(&f.Catalog, "catalog", "", "The name of the catalog the app belongs to")
+
+	cmd.Flags().StringVar(&f.Chart, flagChart, "", "Directory of the app's Helm chart. Defaults to helm/<app-name>, or the only chart in helm/.")
+	cmd.Flags().StringVar(&f.InstallNamespace, flagInstallNamespace, apptest.DefaultInstallNamespace, "The namespace to install the app into within the workload cluster")
+	cmd.Flags().StringSliceVar(&f.Providers, flagProviders, nil, "CAPI providers to test against, one or more of <"+strings.Join(apptest.AllProviders(), "|")+">. Defaults to the chart's compatibleProviders restriction, or "+apptest.DefaultProvider+".")
+	cmd.Flags().BoolVar(&f.Upgrade, flagUpgrade, false, "Also generate an upgrade suite, which upgrades the latest released version of the app to the tested one")
 }
 
 func (f *flag) Validate() error {
+	for _, p := range f.Providers {
+		if !slices.Contains(apptest.AllProviders(), p) {
+			return microerror.Maskf(invalidFlagError, "--%s must contain only <%s>, got %#q", flagProviders, strings.Join(apptest.AllProviders(), "|"), p)
+		}
+	}
+	if f.InstallNamespace == "" {
+		return microerror.Maskf(invalidFlagError, "--%s must not be empty", flagInstallNamespace)
+	}
+
 	return nil
 }
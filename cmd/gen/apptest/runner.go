@@ -50,6 +50,15 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 			AppName:  r.flag.AppName,
 			RepoName: r.flag.RepoName,
 			Catalog:  r.flag.Catalog,
+
+			Providers:        r.flag.Providers,
+			InstallNamespace: r.flag.InstallNamespace,
+			Upgrade:          r.flag.Upgrade,
+		}
+
+		err = r.inspectChart(ctx, &c)
+		if err != nil {
+			return microerror.Mask(err)
 		}
 
 		apptestInput, err = apptest.New(c)
@@ -105,3 +114,83 @@ func goModTidy(workingDir string) error {
 	_, err := cmd.Output()
 	return err
 }
+
+// inspectChart derives the providers, the values and the workloads to wait
+// for from the app's chart. Without chart the suites are generated without
+// them.
+func (r *runner) inspectChart(ctx context.Context, c *apptest.Config) error {
+	dir, err := findChart(r.flag.Chart, r.flag.AppName)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	if dir == "" {
+		r.logger.Debugf(ctx, "no chart found, generating suites without chart assertions")
+		return nil
+	}
+
+	if len(c.Providers) == 0 {
+		data, err := os.ReadFile(filepath.Join(dir, "Chart.yaml"))
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		c.Providers, err = apptest.ChartProviders(data)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "values.schema.json"))
+	if err == nil {
+		c.Values, err = apptest.SchemaDefaults(data)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return microerror.Mask(err)
+	}
+
+	// The release is named after the app, like the App CR installed by
+	// apptest-framework, so the names of the rendered workloads match.
+	cmd := exec.Command("helm", "template", r.flag.AppName, dir, "--namespace", r.flag.InstallNamespace) // #nosec G204
+	cmd.Stderr = r.stderr
+	manifests, err := cmd.Output()
+	if err != nil {
+		r.logger.LogCtx(ctx, "level", "warning", "message", fmt.Sprintf("helm template %s failed, generating suites without workload assertions: %s", dir, err))
+		return nil
+	}
+	c.Workloads, err = apptest.ParseWorkloads(manifests, r.flag.InstallNamespace)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// findChart returns the chart directory given by flag, or the chart of the
+// app in helm/, or "" when there is none.
+func findChart(flag, appName string) (string, error) {
+	if flag != "" {
+		_, err := os.Stat(filepath.Join(flag, "Chart.yaml"))
+		if err != nil {
+			return "", microerror.Maskf(invalidFlagError, "--%s %#q is not a chart directory: %s", flagChart, flag, err)
+		}
+		return flag, nil
+	}
+
+	if appName != "" {
+		dir := filepath.Join("helm", appName)
+		if _, err := os.Stat(filepath.Join(dir, "Chart.yaml")); err == nil {
+			return dir, nil
+		}
+	}
+
+	charts, err := filepath.Glob(filepath.Join("helm", "*", "Chart.yaml"))
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+	if len(charts) == 1 {
+		return filepath.Dir(charts[0]), nil
+	}
+
+	return "", nil
+}
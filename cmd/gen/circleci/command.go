@@ -18,19 +18,45 @@ The pipeline is fully derived from existing signals -- there is no CI parameter
 block. Jobs are selected by:
 
   - language go        -> architect/go-build
+  - language node      -> node-build or node-test on cimg/node (package manager
+                          from the lockfile, version from .nvmrc)
+  - language python    -> python-test on cimg/python (uv, poetry or pip from
+                          the lockfile, version from .python-version)
   - Dockerfile present -> architect/push-to-registries (buildx + split-china-push)
                           and architect/sync-china-registry
   - app flavour        -> architect/push-to-app-catalog (app-build-suite executor)
                           and architect/run-tests-with-ats (--skip-ats opts out
                           of the ATS chart tests)
 
-The giantswarm/architect orb is pinned to a version baked into devctl (not a
-flag): a major orb bump changes the template's required job/param shape, so it
-must ship as a new devctl release rather than being passed in at generation
-time. Tag/branch filters follow the giantswarm convention (branch builds
-validate the image, tags push multi-arch and publish the chart).`
+--additional-language adds the build job of a second language, e.g. node for
+the web UI of a Go backend. The image and chart jobs wait on all build jobs.
+
+The giantswarm/architect and circleci/continuation orbs default to versions
+baked into devctl, since a major orb bump can change the template's required
+job/param shape. --orb-version and --continuation-orb-version, or the orb
+manifest .circleci/orbs.yaml, pin other versions, and "latest" resolves the
+latest release at generation time. --check-orb reports generated configs whose
+orbs lag behind, with release note excerpts. Tag/branch filters follow the giantswarm convention (branch builds
+validate the image, tags push multi-arch and publish the chart).
+
+--provider=github-actions generates the equivalent GitHub Actions workflow
+.github/workflows/zz_generated.ci.yaml instead and deletes the generated
+.circleci/config.yml and .circleci/workflows.yml. The default
+--provider=circleci deletes the workflow, so switching either way leaves one
+pipeline. The repo-owned .circleci/custom.yml is not migrated.
+
+--explain prints the job graph of the build workflow instead of writing files:
+the requires between jobs, which jobs run on branches and which on tags, and the
+flag or repo file each job comes from. --explain=dot and --explain=mermaid print
+it as a Graphviz or Mermaid graph. The equals sign is required: in
+"--explain mermaid" the format is read as a positional argument and rejected.`
 	example = `  devctl gen circleci --repo-name mcp-kubernetes --language go --flavour app
-  devctl gen circleci --repo-name crd-docs-generator --language go`
+  devctl gen circleci --repo-name crd-docs-generator --language go
+  devctl gen circleci --repo-name happa --language go --additional-language node --flavour app
+  devctl gen circleci --repo-name mcp-kubernetes --language go --flavour app --provider github-actions
+  devctl gen circleci --repo-name mcp-kubernetes --language go --flavour app --explain=mermaid
+  devctl gen circleci --repo-name mcp-kubernetes --language go --flavour app --orb-version latest
+  devctl gen circleci --check-orb --check-orb-repo giantswarm/mcp-kubernetes,giantswarm/happa`
 )
 
 type Config struct {
@@ -19,3 +19,21 @@ var invalidFlagError = &microerror.Error{
 func IsInvalidFlag(err error) bool {
 	return microerror.Cause(err) == invalidFlagError
 }
+
+var envVarNotFoundError = &microerror.Error{
+	Kind: "envVarNotFoundError",
+}
+
+// IsEnvVarNotFound asserts envVarNotFoundError.
+func IsEnvVarNotFound(err error) bool {
+	return microerror.Cause(err) == envVarNotFoundError
+}
+
+var executionFailedError = &microerror.Error{
+	Kind: "executionFailedError",
+}
+
+// IsExecutionFailed asserts executionFailedError.
+func IsExecutionFailed(err error) bool {
+	return microerror.Cause(err) == executionFailedError
+}
@@ -2,87 +2,142 @@ package circleci
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/giantswarm/microerror"
 	"github.com/spf13/cobra"
 
 	"github.com/giantswarm/devctl/v8/pkg/gen"
+	"github.com/giantswarm/devctl/v8/pkg/gen/input/circleci"
 )
 
 const (
-	flagAppCatalog       = "app-catalog"
-	flagAppCatalogTest   = "app-catalog-test"
-	flagBranchPublish    = "branch-publish"
-	flagBuildConcurrency = "build-concurrency"
-	flagChartName        = "chart-name"
-	flagForcePublic      = "force-public"
-	flagImagePreBuildJob = "image-pre-build-job"
-	flagImagePrivateOnly = "image-private-only"
-	flagImageName        = "image-name"
-	flagImagePlatforms   = "image-platforms"
-	flagImageDockerfile  = "image-dockerfile"
-	flagResourceClass    = "resource-class"
-	flagSkipATS          = "skip-ats"
-	flagFlavour          = "flavour"
-	flagLanguage         = "language"
-	flagRepoName         = "repo-name"
-	flagPackageManager   = "package-manager"
-	flagNodeImageVersion = "node-image-version"
-	flagNodeTestTarget   = "node-test-target"
-	flagNodeBuildTarget  = "node-build-target"
-	flagNodeBuildOutput  = "node-build-output"
+	flagAdditionalLanguage     = "additional-language"
+	flagAppCatalog             = "app-catalog"
+	flagAppCatalogTest         = "app-catalog-test"
+	flagBranchPublish          = "branch-publish"
+	flagBuildConcurrency       = "build-concurrency"
+	flagChartName              = "chart-name"
+	flagCheckOrb               = "check-orb"
+	flagCheckOrbRepo           = "check-orb-repo"
+	flagContinuationOrbVersion = "continuation-orb-version"
+	flagExplain                = "explain"
+	flagForcePublic            = "force-public"
+	flagImagePreBuildJob       = "image-pre-build-job"
+	flagImagePrivateOnly       = "image-private-only"
+	flagImageName              = "image-name"
+	flagImagePlatforms         = "image-platforms"
+	flagImageDockerfile        = "image-dockerfile"
+	flagResourceClass          = "resource-class"
+	flagSkipATS                = "skip-ats"
+	flagFlavour                = "flavour"
+	flagLanguage               = "language"
+	flagRepoName               = "repo-name"
+	flagPackageManager         = "package-manager"
+	flagNodeImageVersion       = "node-image-version"
+	flagNodeTestTarget         = "node-test-target"
+	flagNodeBuildTarget        = "node-build-target"
+	flagNodeBuildOutput        = "node-build-output"
+	flagOrbManifest            = "orb-manifest"
+	flagOrbVersion             = "orb-version"
+	flagProvider               = "provider"
+	flagPythonPackageManager   = "python-package-manager"
+	flagPythonImageVersion     = "python-image-version"
+)
+
+const (
+	providerCircleCI      = "circleci"
+	providerGitHubActions = "github-actions"
 )
 
 type flag struct {
-	AppCatalog       string
-	AppCatalogTest   string
-	BranchPublish    bool
-	BuildConcurrency string
-	ChartName        string
-	ForcePublic      bool
-	ImagePreBuildJob string
-	ImagePrivateOnly bool
-	ImageName        string
-	ImagePlatforms   string
-	ImageDockerfile  string
-	ResourceClass    string
-	SkipATS          bool
-	Flavours         gen.FlavourSlice
-	Language         gen.Language
-	RepoName         string
-	PackageManager   string
-	NodeImageVersion string
-	NodeTestTarget   string
-	NodeBuildTarget  string
-	NodeBuildOutput  string
+	AdditionalLanguages    []string
+	AppCatalog             string
+	AppCatalogTest         string
+	BranchPublish          bool
+	BuildConcurrency       string
+	ChartName              string
+	CheckOrb               bool
+	CheckOrbRepos          []string
+	ContinuationOrbVersion string
+	Explain                string
+	ForcePublic            bool
+	ImagePreBuildJob       string
+	ImagePrivateOnly       bool
+	ImageName              string
+	ImagePlatforms         string
+	ImageDockerfile        string
+	ResourceClass          string
+	SkipATS                bool
+	Flavours               gen.FlavourSlice
+	Language               gen.Language
+	RepoName               string
+	PackageManager         string
+	NodeImageVersion       string
+	NodeTestTarget         string
+	NodeBuildTarget        string
+	NodeBuildOutput        string
+	OrbManifest            string
+	OrbVersion             string
+	Provider               string
+	PythonPackageManager   string
+	PythonImageVersion     string
 }
 
 func (f *flag) Init(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&f.AdditionalLanguages, flagAdditionalLanguage, nil, `Languages built next to --language, each with its own build job, e.g. "node" for the web UI of a Go backend. The image and chart jobs wait on all build jobs, and the image build gets the outputs of go-build and node-build. Possible values: <go|node|python>`)
 	cmd.Flags().StringVar(&f.AppCatalog, flagAppCatalog, "", `Catalog the chart pipeline publishes to (push-to-app-catalog app_catalog). Empty defaults to "giantswarm-catalog"; set it for repos that ship to a different catalog (e.g. the internal "giantswarm-operations-platform") so generation does not migrate the chart to the public catalog.`)
 	cmd.Flags().StringVar(&f.AppCatalogTest, flagAppCatalogTest, "", `Test catalog the chart pipeline publishes to (push-to-app-catalog app_catalog_test). Empty defaults to "giantswarm-test-catalog". Kept paired with --app-catalog.`)
 	cmd.Flags().BoolVar(&f.BranchPublish, flagBranchPublish, false, "Publish a dev image and chart on branch builds. By default branches build + test only (no push); when set, the branch path additionally pushes an amd64 dev image and the dev chart (coupled).")
 	cmd.Flags().StringVar(&f.BuildConcurrency, flagBuildConcurrency, "", `Override how many architectures the cli-flavour go-build job compiles concurrently (architect go-build "build_concurrency" param). Empty defaults to "auto" (nproc). Lower it (e.g. "2") for repos whose binary is large enough that a cold full-matrix cross-compile OOMs the runner at "auto" -- memory, not CPU, is the binding constraint, and a killed build never stores the build cache. Only applies to the cli flavour.`)
-	cmd.Flags().StringVar(&f.ChartName, flagChartName, "", "Override the chart name (the push-to-app-catalog `chart` param and the helm/<chart> directory). Empty defaults to the repo name. Set it for repos whose chart directory does not match the repo name (e.g. docs-proxy -> docs-proxy-app). The append-only custom.yml merge cannot rename a generated job's chart.")
-	cmd.Flags().BoolVar(&f.ForcePublic, flagForcePublic, false, "Push the image and chart as public artifacts even though the repo is private (architect `force-public: true`). Set it for private repos that publish public artifacts (e.g. web-assets). Mutually exclusive with --image-private-only. The append-only custom.yml merge cannot add this to a generated job.")
-	cmd.Flags().StringVar(&f.ImagePreBuildJob, flagImagePreBuildJob, "", "Name of a repo-owned job (defined in .circleci/custom.yml) the release image build must wait on. Adds a `requires` entry to push-to-registries-release, which the append-only custom.yml merge cannot inject into a generated job. Used for workspace-handoff pre-steps. Empty for the common case.")
+	cmd.Flags().StringVar(&f.ChartName, flagChartName, "", "Override the chart name (the push-to-app-catalog `chart` param and the helm/<chart> directory). Empty defaults to the repo name. Set it for repos whose chart directory does not match the repo name (e.g. docs-proxy -> docs-proxy-app). Unlike a custom.yml override, it also covers the helm/<chart> lookup and every chart job.")
+	cmd.Flags().BoolVar(&f.CheckOrb, flagCheckOrb, false, fmt.Sprintf("Report, instead of writing files, whether the orbs the generated CircleCI config pins lag behind their latest release, with excerpts of the release notes in between. Checks the config in the working directory, or the repos named by --%s. Requires GITHUB_TOKEN.", flagCheckOrbRepo))
+	cmd.Flags().StringSliceVar(&f.CheckOrbRepos, flagCheckOrbRepo, nil, fmt.Sprintf("Repositories (owner/name) whose generated CircleCI config --%s checks on GitHub, e.g. giantswarm/mcp-kubernetes. Empty checks the working directory.", flagCheckOrb))
+	cmd.Flags().StringVar(&f.ContinuationOrbVersion, flagContinuationOrbVersion, "", fmt.Sprintf(`circleci/continuation orb version the setup config pins: an exact version, or %q to resolve the latest release (requires GITHUB_TOKEN). Empty takes it from the orb manifest, and falls back to devctl's baked-in %s.`, circleci.OrbVersionLatest, circleci.ContinuationOrbVersion))
+	cmd.Flags().StringVar(&f.Explain, flagExplain, "", fmt.Sprintf(`Print the job dependency graph of the generated build workflow instead of writing files: which jobs run on branches and which on tags, what each job requires, and the flag or repo file that caused it. --%s alone prints text; pass a format with an equals sign, e.g. --%s=mermaid. Possible values: <%s>`, flagExplain, flagExplain, strings.Join(circleci.AllExplainFormats(), "|")))
+	cmd.Flags().Lookup(flagExplain).NoOptDefVal = circleci.ExplainFormatText
+	cmd.Flags().BoolVar(&f.ForcePublic, flagForcePublic, false, "Push the image and chart as public artifacts even though the repo is private (architect `force-public: true`). Set it for private repos that publish public artifacts (e.g. web-assets). Mutually exclusive with --image-private-only. Unlike a custom.yml override, it covers every image and chart job at once.")
+	cmd.Flags().StringVar(&f.ImagePreBuildJob, flagImagePreBuildJob, "", "Name of a repo-owned job (defined in .circleci/custom.yml) the release image build must wait on. Adds a `requires` entry to push-to-registries-release and the branch image job; a custom.yml override can add it to a single job instead. Used for workspace-handoff pre-steps. Empty for the common case.")
 	cmd.Flags().BoolVar(&f.ImagePrivateOnly, flagImagePrivateOnly, false, "Ship the image to the private registry only (gsociprivate), replacing split-china-push and omitting the sync-china-registry job. Set it for private repos whose image must not land in the public catalog.")
-	cmd.Flags().StringVar(&f.ImageName, flagImageName, "", "Override the `giantswarm/<repo>` default image name on the image jobs (push-to-registries / sync-china-registry `image` param). Set it for repos whose published image differs from the repo name (e.g. kserve -> giantswarm/kserve-controller). The append-only custom.yml merge cannot rename a generated job's image. Empty keeps the orb default.")
+	cmd.Flags().StringVar(&f.ImageName, flagImageName, "", "Override the `giantswarm/<repo>` default image name on the image jobs (push-to-registries / sync-china-registry `image` param). Set it for repos whose published image differs from the repo name (e.g. kserve -> giantswarm/kserve-controller). Unlike a custom.yml override, it covers every image job at once. Empty keeps the orb default.")
 	cmd.Flags().StringVar(&f.ImagePlatforms, flagImagePlatforms, "", "Override the buildx platform list on the image jobs (push-to-registries `platforms` param). Empty lets the orb default apply (linux/amd64,linux/arm64 when no go-build .platforms file). Set it for single-architecture images (e.g. vllm -> linux/arm64, whose amd64 build has no prebuilt wheels).")
-	cmd.Flags().StringVar(&f.ImageDockerfile, flagImageDockerfile, "", "Override the Dockerfile path on the image jobs (push-to-registries `dockerfile` param). Set it for repos whose Dockerfile is not at the repo root (e.g. backstage -> packages/backend/Dockerfile); a non-empty value also turns the image pipeline on, since the root-Dockerfile derivation misses a nested Dockerfile. The append-only custom.yml merge cannot set this on a generated job. Empty keeps the orb default.")
+	cmd.Flags().StringVar(&f.ImageDockerfile, flagImageDockerfile, "", "Override the Dockerfile path on the image jobs (push-to-registries `dockerfile` param). Set it for repos whose Dockerfile is not at the repo root (e.g. backstage -> packages/backend/Dockerfile); a non-empty value also turns the image pipeline on, since the root-Dockerfile derivation misses a nested Dockerfile. Unlike a custom.yml override, it also drives the image pipeline detection. Empty keeps the orb default.")
 	cmd.Flags().StringVar(&f.ResourceClass, flagResourceClass, "", `Override the CircleCI resource_class on the cli-flavour go-build job. Empty defaults to "large". Raise it (e.g. "xlarge") for repos that need more RAM/CPU headroom for the cold cross-compile. Only applies to the cli flavour.`)
 	cmd.Flags().BoolVar(&f.SkipATS, flagSkipATS, false, `Opt the chart pipeline out of app-test-suite (ATS) chart tests. By default an "app" flavour repo runs architect/run-tests-with-ats between build-chart and the chart push, and generation emits the canonical tests/ats/Pipfile. When set, those test jobs and the Pipfile are not generated and the chart push gates directly on build-chart. Only applies to the app flavour.`)
 	cmd.Flags().VarP(gen.NewFlavourSliceFlagValue(&f.Flavours, gen.FlavourSlice{}), flagFlavour, "f", fmt.Sprintf(`List of project flavours. The "app" flavour selects the chart pipeline. Possible values: <%s>`, strings.Join(gen.AllFlavours(), "|")))
-	cmd.Flags().VarP(gen.NewLanguageFlagValue(&f.Language, gen.Language("")), flagLanguage, "l", fmt.Sprintf(`The programming language. "go" selects the go-build job. Possible values: <%s>`, strings.Join(gen.AllLanguages(), "|")))
+	cmd.Flags().VarP(gen.NewLanguageFlagValue(&f.Language, gen.Language("")), flagLanguage, "l", fmt.Sprintf(`The programming language. "go" selects the go-build job, "node" the Node build/test job, and "python" the Python test job. Possible values: <%s>`, strings.Join(gen.AllLanguages(), "|")))
 	cmd.Flags().StringVarP(&f.RepoName, flagRepoName, "r", "", "Repository name under the giantswarm organization (used for the binary, chart, and job names).")
 	cmd.Flags().StringVar(&f.PackageManager, flagPackageManager, "", `Node package manager for the build/test job (one of "npm", "yarn", "yarn-classic", "pnpm"). Empty detects it from the lockfile (package-lock.json -> npm, pnpm-lock.yaml -> pnpm, yarn.lock -> yarn Berry or yarn-classic by its header). Only applies with --language=node.`)
 	cmd.Flags().StringVar(&f.NodeImageVersion, flagNodeImageVersion, "", `cimg/node tag the build/test job runs on, which also salts the node-build cache key. Empty detects it from the repo's .nvmrc, and falls back to devctl's baked-in default when there is none. Committing a .nvmrc is how a repo keeps CI in step with a Node version it also bakes into artifacts devctl does not generate (a Dockerfile FROM, a setup-node step) from one place. Only an exact major.minor.patch is read from .nvmrc -- aliases ("lts/*") and less specific versions are ignored with a warning, because a floating tag would drift from the exact patch the repo's Dockerfile pins and would coarsen the cache-key salt. Only applies with --language=node.`)
 	cmd.Flags().StringVar(&f.NodeTestTarget, flagNodeTestTarget, "", `package.json script the Node job runs for the verify phase, ci:verify (the make-target interface; the repo composes its whole correctness gate -- tsc --noEmit + lint + prettier --check + tests, in one process -- into it). Empty defaults to "test", which is only a floor: the convention is an explicit composed ci:verify (lint/format live here CI-wide). Only applies with --language=node.`)
 	cmd.Flags().StringVar(&f.NodeBuildTarget, flagNodeBuildTarget, "", "package.json script the Node job runs to build, ci:build. Empty omits the build step (a library that only verifies). Must be bundle/emit-only -- redo nothing the verify script did (no second typecheck/lint/test) and no re-install. Only applies with --language=node.")
 	cmd.Flags().StringVar(&f.NodeBuildOutput, flagNodeBuildOutput, "", `Workspace path the Node job persists for an image handoff (e.g. "packages/*/dist/*"). Non-empty names the job "node-build" and emits persist_to_workspace so the image jobs can attach it; empty names it "node-test". Only applies with --language=node.`)
+	cmd.Flags().StringVar(&f.OrbManifest, flagOrbManifest, "", fmt.Sprintf(`YAML file pinning orb versions, e.g. "orbs: {giantswarm/architect: 10.1.0}". Versions are exact or %q. Flags win over the manifest. Empty reads %s when the repo has one.`, circleci.OrbVersionLatest, circleci.DefaultOrbManifest))
+	cmd.Flags().StringVar(&f.OrbVersion, flagOrbVersion, "", fmt.Sprintf(`giantswarm/architect orb version the workflows pin: an exact version, or %q to resolve the latest release (requires GITHUB_TOKEN). Empty takes it from the orb manifest, and falls back to devctl's baked-in %s. A major bump can change the jobs and params the template relies on.`, circleci.OrbVersionLatest, circleci.OrbVersion))
+	cmd.Flags().StringVar(&f.PythonPackageManager, flagPythonPackageManager, "", `Python package manager for the test job (one of "uv", "poetry", "pip"). Empty detects it from the lockfile (uv.lock -> uv, poetry.lock -> poetry, pip otherwise). Only applies with --language=python.`)
+	cmd.Flags().StringVar(&f.PythonImageVersion, flagPythonImageVersion, "", `cimg/python tag the test job runs on, which also salts the cache key. Empty detects it from the repo's .python-version (major.minor or major.minor.patch), and falls back to devctl's baked-in default when there is none. Only applies with --language=python.`)
+	cmd.Flags().StringVar(&f.Provider, flagProvider, providerCircleCI, fmt.Sprintf("CI provider to generate the pipeline for. Possible values: %s (default), %s. %s generates .circleci/config.yml and .circleci/workflows.yml; %s generates the equivalent .github/workflows/zz_generated.ci.yaml. Each deletes the files of the other, so switching migrates the repo. --%s, --%s and --%s only apply to %s.", providerCircleCI, providerGitHubActions, providerCircleCI, providerGitHubActions, flagImagePreBuildJob, flagBuildConcurrency, flagResourceClass, providerCircleCI))
 }
 
 func (f *flag) Validate() error {
+	if f.CheckOrb {
+		if f.Explain != "" {
+			return microerror.Maskf(invalidFlagError, "--%s and --%s are mutually exclusive", flagCheckOrb, flagExplain)
+		}
+		for _, repo := range f.CheckOrbRepos {
+			owner, name, ok := strings.Cut(repo, "/")
+			if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+				return microerror.Maskf(invalidFlagError, "--%s must be owner/name, got %#q", flagCheckOrbRepo, repo)
+			}
+		}
+		// The check reads the generated files, it does not generate.
+		return nil
+	}
+	if len(f.CheckOrbRepos) > 0 {
+		return microerror.Maskf(invalidFlagError, "--%s requires --%s", flagCheckOrbRepo, flagCheckOrb)
+	}
+
 	if f.RepoName == "" {
 		return microerror.Maskf(invalidFlagError, "--%s must not be empty", flagRepoName)
 	}
@@ -90,5 +145,55 @@ func (f *flag) Validate() error {
 		return microerror.Maskf(invalidFlagError, "--%s and --%s are mutually exclusive", flagForcePublic, flagImagePrivateOnly)
 	}
 
+	for _, l := range f.AdditionalLanguages {
+		switch gen.Language(l) {
+		case gen.LanguageGo, gen.LanguageNode, gen.LanguagePython:
+		default:
+			return microerror.Maskf(invalidFlagError, "--%s must be one of: go, node, python", flagAdditionalLanguage)
+		}
+		if gen.Language(l) == f.Language {
+			return microerror.Maskf(invalidFlagError, "--%s %#q is already the --%s", flagAdditionalLanguage, l, flagLanguage)
+		}
+	}
+
+	if f.OrbVersion != "" && !circleci.ValidOrbVersion(f.OrbVersion) {
+		return microerror.Maskf(invalidFlagError, "--%s must be %q or an exact version like 10.1.0, got %#q", flagOrbVersion, circleci.OrbVersionLatest, f.OrbVersion)
+	}
+	if f.ContinuationOrbVersion != "" && !circleci.ValidOrbVersion(f.ContinuationOrbVersion) {
+		return microerror.Maskf(invalidFlagError, "--%s must be %q or an exact version like 2.0.1, got %#q", flagContinuationOrbVersion, circleci.OrbVersionLatest, f.ContinuationOrbVersion)
+	}
+
+	if f.Explain != "" && !slices.Contains(circleci.AllExplainFormats(), f.Explain) {
+		return microerror.Maskf(invalidFlagError, "--%s must be one of <%s>, got %#q", flagExplain, strings.Join(circleci.AllExplainFormats(), "|"), f.Explain)
+	}
+
+	switch f.PythonPackageManager {
+	case "", circleci.PackageManagerUV, circleci.PackageManagerPoetry, circleci.PackageManagerPip:
+	default:
+		return microerror.Maskf(invalidFlagError, "--%s must be one of: %s, %s, %s", flagPythonPackageManager, circleci.PackageManagerUV, circleci.PackageManagerPoetry, circleci.PackageManagerPip)
+	}
+
+	switch f.Provider {
+	case providerCircleCI:
+		// valid
+	case providerGitHubActions:
+		// The pre-build job lives in .circleci/custom.yml, which the GitHub
+		// Actions pipeline does not merge.
+		if f.ImagePreBuildJob != "" {
+			return microerror.Maskf(invalidFlagError, "--%s is not supported with --%s=%s", flagImagePreBuildJob, flagProvider, providerGitHubActions)
+		}
+	default:
+		return microerror.Maskf(invalidFlagError, "--%s must be one of: %s, %s", flagProvider, providerCircleCI, providerGitHubActions)
+	}
+
 	return nil
 }
+
+// languages returns --language and the --additional-language values.
+func (f *flag) languages() []gen.Language {
+	languages := []gen.Language{f.Language}
+	for _, l := range f.AdditionalLanguages {
+		languages = append(languages, gen.Language(l))
+	}
+	return languages
+}
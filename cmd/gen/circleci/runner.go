@@ -4,17 +4,22 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"maps"
 	"os"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
+	"github.com/giantswarm/devctl/v8/internal/env"
 	"github.com/giantswarm/devctl/v8/pkg/gen"
 	"github.com/giantswarm/devctl/v8/pkg/gen/input"
 	"github.com/giantswarm/devctl/v8/pkg/gen/input/circleci"
+	"github.com/giantswarm/devctl/v8/pkg/githubclient"
 )
 
 type runner struct {
@@ -27,6 +32,15 @@ type runner struct {
 func (r *runner) Run(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
+	// --explain has an optional value, so "--explain mermaid" parses as
+	// --explain=text plus a positional argument the command never reads.
+	if len(args) > 0 {
+		if r.flag.Explain != "" {
+			return microerror.Maskf(invalidFlagError, "--%s takes its format after an equals sign, e.g. --%s=%s, got positional argument %#q", flagExplain, flagExplain, args[0], args[0])
+		}
+		return microerror.Maskf(invalidFlagError, "unexpected positional arguments %#q", args)
+	}
+
 	err := r.flag.Validate()
 	if err != nil {
 		return microerror.Mask(err)
@@ -43,6 +57,15 @@ func (r *runner) Run(cmd *cobra.Command, args []string) error {
 func (r *runner) run(ctx context.Context, _ *cobra.Command, _ []string) error {
 	var err error
 
+	if r.flag.CheckOrb {
+		return r.checkOrbs(ctx)
+	}
+
+	orbVersions, err := r.resolveOrbVersions(ctx)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
 	// The image pipeline is derived from repo content: architect already
 	// requires a Dockerfile to build an image, so its presence is the signal.
 	_, statErr := os.Stat("Dockerfile")
@@ -50,16 +73,17 @@ func (r *runner) run(ctx context.Context, _ *cobra.Command, _ []string) error {
 
 	// Node package manager is derived from the lockfile, the same content-signal
 	// style as the Dockerfile probe. An explicit --package-manager wins.
+	languages := r.flag.languages()
 	packageManager := r.flag.PackageManager
-	if packageManager == "" && r.flag.Language == gen.LanguageNode {
-		packageManager = detectPackageManager()
+	if packageManager == "" && slices.Contains(languages, gen.LanguageNode) {
+		packageManager = gen.DetectNodePackageManager(".")
 	}
 
 	// Node version is derived from .nvmrc, the same content-signal style. An
 	// explicit --node-image-version wins; neither set falls back to devctl's
 	// baked-in default.
 	nodeImageVersion := r.flag.NodeImageVersion
-	if nodeImageVersion == "" && r.flag.Language == gen.LanguageNode {
+	if nodeImageVersion == "" && slices.Contains(languages, gen.LanguageNode) {
 		var rejected string
 		nodeImageVersion, rejected = detectNodeVersion()
 		// A .nvmrc that names no cimg/node tag is the one case worth saying out
@@ -70,31 +94,65 @@ func (r *runner) run(ctx context.Context, _ *cobra.Command, _ []string) error {
 		}
 	}
 
+	// The Python package manager and version are probed the same way, from
+	// the lockfile and .python-version.
+	pythonPackageManager := r.flag.PythonPackageManager
+	pythonImageVersion := r.flag.PythonImageVersion
+	var pythonPyproject bool
+	if slices.Contains(languages, gen.LanguagePython) {
+		if pythonPackageManager == "" {
+			pythonPackageManager = gen.DetectPythonPackageManager(".")
+		}
+		// pip repos without a requirements.txt install from pyproject.toml.
+		if _, err := os.Stat("requirements.txt"); err != nil {
+			pythonPyproject = true
+		}
+		if pythonImageVersion == "" {
+			var rejected string
+			pythonImageVersion, rejected = detectPythonVersion()
+			if rejected != "" {
+				_, _ = fmt.Fprintf(r.stderr, "warning: ignoring .python-version value %q -- the Python job needs a major.minor or major.minor.patch version (e.g. 3.13); falling back to %s\n", rejected, circleci.DefaultPythonImageVersion)
+			}
+		}
+	}
+
+	var additionalLanguages []gen.Language
+	for _, l := range r.flag.AdditionalLanguages {
+		additionalLanguages = append(additionalLanguages, gen.Language(l))
+	}
+
 	var circleciInput *circleci.CircleCI
 	{
 		c := circleci.Config{
-			RepoName:         r.flag.RepoName,
-			Language:         r.flag.Language,
-			Flavours:         r.flag.Flavours,
-			SkipATS:          r.flag.SkipATS,
-			HasDockerfile:    hasDockerfile,
-			AppCatalog:       r.flag.AppCatalog,
-			AppCatalogTest:   r.flag.AppCatalogTest,
-			ChartName:        r.flag.ChartName,
-			ForcePublic:      r.flag.ForcePublic,
-			BranchPublish:    r.flag.BranchPublish,
-			BuildConcurrency: r.flag.BuildConcurrency,
-			ImagePreBuildJob: r.flag.ImagePreBuildJob,
-			ImagePrivateOnly: r.flag.ImagePrivateOnly,
-			ImageName:        r.flag.ImageName,
-			ImagePlatforms:   r.flag.ImagePlatforms,
-			ImageDockerfile:  r.flag.ImageDockerfile,
-			ResourceClass:    r.flag.ResourceClass,
-			PackageManager:   packageManager,
-			NodeImageVersion: nodeImageVersion,
-			NodeTestTarget:   r.flag.NodeTestTarget,
-			NodeBuildTarget:  r.flag.NodeBuildTarget,
-			NodeBuildOutput:  r.flag.NodeBuildOutput,
+			RepoName:             r.flag.RepoName,
+			Language:             r.flag.Language,
+			AdditionalLanguages:  additionalLanguages,
+			Flavours:             r.flag.Flavours,
+			SkipATS:              r.flag.SkipATS,
+			HasDockerfile:        hasDockerfile,
+			AppCatalog:           r.flag.AppCatalog,
+			AppCatalogTest:       r.flag.AppCatalogTest,
+			ChartName:            r.flag.ChartName,
+			ForcePublic:          r.flag.ForcePublic,
+			BranchPublish:        r.flag.BranchPublish,
+			BuildConcurrency:     r.flag.BuildConcurrency,
+			ImagePreBuildJob:     r.flag.ImagePreBuildJob,
+			ImagePrivateOnly:     r.flag.ImagePrivateOnly,
+			ImageName:            r.flag.ImageName,
+			ImagePlatforms:       r.flag.ImagePlatforms,
+			ImageDockerfile:      r.flag.ImageDockerfile,
+			ResourceClass:        r.flag.ResourceClass,
+			PackageManager:       packageManager,
+			NodeImageVersion:     nodeImageVersion,
+			NodeTestTarget:       r.flag.NodeTestTarget,
+			NodeBuildTarget:      r.flag.NodeBuildTarget,
+			NodeBuildOutput:      r.flag.NodeBuildOutput,
+			PythonPackageManager: pythonPackageManager,
+			PythonImageVersion:   pythonImageVersion,
+			PythonPyproject:      pythonPyproject,
+
+			OrbVersion:             orbVersions[circleci.OrbArchitect],
+			ContinuationOrbVersion: orbVersions[circleci.OrbContinuation],
 		}
 
 		circleciInput, err = circleci.New(c)
@@ -103,9 +161,43 @@ func (r *runner) run(ctx context.Context, _ *cobra.Command, _ []string) error {
 		}
 	}
 
-	inputs := []input.Input{
-		circleciInput.SetupConfig(),
-		circleciInput.Workflows(),
+	// --explain is a dry run: it describes the build workflow the
+	// generator would write and leaves the repo untouched. The job names and
+	// filters are the same for both providers.
+	if r.flag.Explain != "" {
+		jobs, err := circleciInput.WorkflowJobs(ctx)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		err = circleci.Explain(r.stdout, jobs, r.flag.Explain)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		return nil
+	}
+
+	// Two mutually-exclusive providers. Each branch emits the pipeline files
+	// it owns AND deletion inputs for the OTHER provider's files, so flipping
+	// `--provider` in either direction leaves the repo with exactly one
+	// pipeline.
+	var inputs []input.Input
+	if r.flag.Provider == providerGitHubActions {
+		inputs = append(inputs, circleciInput.GitHubActions())
+		inputs = append(inputs, circleciInput.CircleCIDeletions()...)
+
+		// The repo-owned custom.yml is merged by the CircleCI setup workflow
+		// only. It is not deleted, but its jobs stop running.
+		if _, err := os.Stat(".circleci/custom.yml"); err == nil {
+			_, _ = fmt.Fprintf(r.stderr, "warning: .circleci/custom.yml is not migrated to GitHub Actions -- move its jobs to a repo-owned workflow and delete it\n")
+		}
+	} else {
+		inputs = append(inputs,
+			circleciInput.SetupConfig(),
+			circleciInput.Workflows(),
+			circleciInput.GitHubActionsDeletion(),
+		)
 	}
 	// The canonical ATS Pipfile rides on the same chart/app (.HasApp) signal
 	// that emits the run-tests-with-ats jobs, so it is folded into this
@@ -121,25 +213,167 @@ func (r *runner) run(ctx context.Context, _ *cobra.Command, _ []string) error {
 	return nil
 }
 
-// detectPackageManager picks the Node package manager from the lockfile present
-// in the working directory, mirroring the Dockerfile content-probe. npm and
-// pnpm are unambiguous by lockfile name; a yarn.lock is Classic only if it
-// carries the v1 header comment, otherwise it is Berry (the empty default).
-func detectPackageManager() string {
-	if _, err := os.Stat("package-lock.json"); err == nil {
-		return circleci.PackageManagerNPM
+// resolveOrbVersions returns the orb versions to pin: the --orb-version and
+// --continuation-orb-version flags win over the orb manifest, which wins over
+// the baked-in defaults. "latest" is resolved on GitHub.
+func (r *runner) resolveOrbVersions(ctx context.Context) (map[string]string, error) {
+	pins := map[string]string{}
+
+	manifest := r.flag.OrbManifest
+	if manifest == "" {
+		if _, err := os.Stat(circleci.DefaultOrbManifest); err == nil {
+			manifest = circleci.DefaultOrbManifest
+		}
+	}
+	if manifest != "" {
+		var err error
+		pins, err = circleci.ReadOrbManifest(manifest)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	if r.flag.OrbVersion != "" {
+		pins[circleci.OrbArchitect] = r.flag.OrbVersion
+	}
+	if r.flag.ContinuationOrbVersion != "" {
+		pins[circleci.OrbContinuation] = r.flag.ContinuationOrbVersion
+	}
+
+	// Only "latest" needs GitHub, so pinned and default versions generate
+	// offline.
+	var latest circleci.LatestTagFunc
+	if slices.Contains(slices.Collect(maps.Values(pins)), circleci.OrbVersionLatest) {
+		client, err := r.githubClient()
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		latest = client.LatestTag
+	}
+
+	versions, err := circleci.ResolveOrbVersions(ctx, latest, pins)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return versions, nil
+}
+
+// checkOrbs reports, for the working directory or each --check-orb-repo,
+// the orbs whose pinned version lags behind their latest release.
+func (r *runner) checkOrbs(ctx context.Context) error {
+	client, err := r.githubClient()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	// The same orb releases serve every repo checked.
+	cache := map[string][]circleci.OrbRelease{}
+	releases := func(ctx context.Context, owner, repo string) ([]circleci.OrbRelease, error) {
+		key := owner + "/" + repo
+		if cached, ok := cache[key]; ok {
+			return cached, nil
+		}
+
+		list, err := client.ListReleases(ctx, owner, repo)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		var orbReleases []circleci.OrbRelease
+		for _, l := range list {
+			orbReleases = append(orbReleases, circleci.OrbRelease{Version: l.Tag, Notes: l.Body, URL: l.URL})
+		}
+		cache[key] = orbReleases
+
+		return orbReleases, nil
 	}
-	if _, err := os.Stat("pnpm-lock.yaml"); err == nil {
-		return circleci.PackageManagerPNPM
+
+	type target struct {
+		name  string
+		files [][]byte
+	}
+	var targets []target
+	if len(r.flag.CheckOrbRepos) == 0 {
+		t := target{name: "."}
+		for _, path := range []string{".circleci/config.yml", ".circleci/workflows.yml"} {
+			data, err := os.ReadFile(path) // #nosec G304 -- fixed generated file path
+			if err == nil {
+				t.files = append(t.files, data)
+			} else if !os.IsNotExist(err) {
+				return microerror.Mask(err)
+			}
+		}
+		targets = append(targets, t)
 	}
-	if data, err := os.ReadFile("yarn.lock"); err == nil {
-		if strings.Contains(string(data), "yarn lockfile v1") {
-			return circleci.PackageManagerYarnClassic
+	for _, repo := range r.flag.CheckOrbRepos {
+		owner, name, _ := strings.Cut(repo, "/")
+		t := target{name: repo}
+		for _, path := range []string{".circleci/config.yml", ".circleci/workflows.yml"} {
+			file, err := client.GetFile(ctx, owner, name, path, "")
+			if githubclient.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return microerror.Mask(err)
+			}
+			t.files = append(t.files, file.Data)
+		}
+		targets = append(targets, t)
+	}
+
+	for _, t := range targets {
+		pinned, err := circleci.ParseOrbVersions(t.files...)
+		if err != nil {
+			return microerror.Maskf(executionFailedError, "%s: %s", t.name, err)
+		}
+		if len(pinned) == 0 {
+			fmt.Fprintf(r.stdout, "%s: no generated CircleCI config\n", t.name)
+			continue
+		}
+
+		lags, err := circleci.CheckOrbs(ctx, releases, pinned)
+		if err != nil {
+			return microerror.Mask(err)
 		}
-		return circleci.PackageManagerYarn
+		if len(lags) == 0 {
+			fmt.Fprintf(r.stdout, "%s: up to date\n", t.name)
+			continue
+		}
+
+		for _, lag := range lags {
+			fmt.Fprintf(r.stdout, "%s: %s\n", t.name, lag)
+			for _, release := range lag.Releases {
+				fmt.Fprintf(r.stdout, "  %s %s\n", release.Version, release.URL)
+				excerpt := circleci.Excerpt(release.Notes, orbNotesLines)
+				if excerpt != "" {
+					fmt.Fprintf(r.stdout, "    %s\n", strings.ReplaceAll(excerpt, "\n", "\n    "))
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// orbNotesLines is how many lines of each release's notes --check-orb shows.
+const orbNotesLines = 5
+
+// githubClient returns a GitHub client authenticated with GITHUB_TOKEN.
+func (r *runner) githubClient() (*githubclient.Client, error) {
+	token := env.GitHubToken.Val()
+	if token == "" {
+		return nil, microerror.Maskf(envVarNotFoundError, "--%s and %q orb versions need a GitHub token, set GITHUB_TOKEN or OPSCTL_GITHUB_TOKEN", flagCheckOrb, circleci.OrbVersionLatest)
+	}
+
+	client, err := githubclient.New(githubclient.Config{
+		Logger:      logrus.StandardLogger(),
+		AccessToken: token,
+	})
+	if err != nil {
+		return nil, microerror.Mask(err)
 	}
 
-	return ""
+	return client, nil
 }
 
 // detectNodeVersion reads the repo's .nvmrc, mirroring the lockfile probe. It
@@ -188,6 +422,39 @@ func detectNodeVersion() (version, rejected string) {
 	return "", ""
 }
 
+// detectPythonVersion reads the repo's .python-version, as pyenv and uv do,
+// the Python analogue of detectNodeVersion. Unlike .nvmrc, a major.minor is
+// honoured: cimg/python publishes floating major.minor tags, and the cache
+// salt only has to be exact to the minor version. Names of other
+// interpreters ("pypy3.10", "system") are rejected and reported, as they name
+// no cimg/python tag.
+//
+// The second return value is the raw value when the file exists but names no
+// usable version.
+func detectPythonVersion() (version, rejected string) {
+	data, err := os.ReadFile(".python-version")
+	if err != nil {
+		return "", ""
+	}
+
+	for line := range strings.Lines(string(data)) {
+		value, _, _ := strings.Cut(line, "#")
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if !pythonVersionRE.MatchString(value) {
+			return "", value
+		}
+		return value, ""
+	}
+
+	return "", ""
+}
+
+// pythonVersionRE matches a major.minor or major.minor.patch Python version.
+var pythonVersionRE = regexp.MustCompile(`^\d+\.\d+(\.\d+)?$`)
+
 // exactNodeVersionRE matches a fully-qualified Node version (major.minor.patch).
 // See detectNodeVersion for why the less specific forms cimg does publish are
 // still rejected.
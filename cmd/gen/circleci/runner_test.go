@@ -122,3 +122,66 @@ func Test_detectNodeVersion(t *testing.T) {
 		})
 	}
 }
+
+// Test_detectPythonVersion covers the .python-version probe. Unlike .nvmrc a
+// major.minor is honoured, as cimg/python publishes floating minor tags.
+func Test_detectPythonVersion(t *testing.T) {
+	testCases := []struct {
+		name         string
+		content      string
+		absent       bool
+		want         string
+		wantRejected string
+	}{
+		{
+			name:   "no .python-version keeps the baked-in default silently",
+			absent: true,
+		},
+		{
+			name:    "major.minor",
+			content: "3.12\n",
+			want:    "3.12",
+		},
+		{
+			name:    "exact version",
+			content: "3.12.4\n",
+			want:    "3.12.4",
+		},
+		{
+			name:    "first of several versions wins",
+			content: "# pyenv local 3.12 3.11\n3.12\n3.11\n",
+			want:    "3.12",
+		},
+		{
+			name:         "other interpreter is rejected and reported",
+			content:      "pypy3.10\n",
+			wantRejected: "pypy3.10",
+		},
+		{
+			name:         "bare major is rejected and reported",
+			content:      "3\n",
+			wantRejected: "3",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if !tc.absent {
+				path := filepath.Join(dir, ".python-version")
+				if err := os.WriteFile(path, []byte(tc.content), 0o600); err != nil {
+					t.Fatalf("write .python-version: %v", err)
+				}
+			}
+			t.Chdir(dir)
+
+			got, rejected := detectPythonVersion()
+			if got != tc.want {
+				t.Errorf("detectPythonVersion() version = %q, want %q", got, tc.want)
+			}
+			if rejected != tc.wantRejected {
+				t.Errorf("detectPythonVersion() rejected = %q, want %q", rejected, tc.wantRejected)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package gen
 
 import (
+	"fmt"
 	"io"
 	"os"
 
@@ -12,11 +13,17 @@ import (
 	"github.com/giantswarm/devctl/v8/cmd/gen/apptest"
 	"github.com/giantswarm/devctl/v8/cmd/gen/circleci"
 	"github.com/giantswarm/devctl/v8/cmd/gen/dependabot"
+	"github.com/giantswarm/devctl/v8/cmd/gen/fleet"
 	"github.com/giantswarm/devctl/v8/cmd/gen/llm"
 	"github.com/giantswarm/devctl/v8/cmd/gen/makefile"
+	"github.com/giantswarm/devctl/v8/cmd/gen/plugin"
+	"github.com/giantswarm/devctl/v8/cmd/gen/plugins"
 	"github.com/giantswarm/devctl/v8/cmd/gen/precommit"
 	"github.com/giantswarm/devctl/v8/cmd/gen/renovate"
+	"github.com/giantswarm/devctl/v8/cmd/gen/status"
 	"github.com/giantswarm/devctl/v8/cmd/gen/workflows"
+	"github.com/giantswarm/devctl/v8/internal/env"
+	genplugin "github.com/giantswarm/devctl/v8/pkg/gen/plugin"
 )
 
 const (
@@ -99,6 +106,20 @@ func New(config Config) (*cobra.Command, error) {
 		}
 	}
 
+	var fleetCmd *cobra.Command
+	{
+		c := fleet.Config{
+			Logger: config.Logger,
+			Stderr: config.Stderr,
+			Stdout: config.Stdout,
+		}
+
+		fleetCmd, err = fleet.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	var llmCmd *cobra.Command
 	{
 		c := llm.Config{
@@ -127,6 +148,20 @@ func New(config Config) (*cobra.Command, error) {
 		}
 	}
 
+	var pluginsCmd *cobra.Command
+	{
+		c := plugins.Config{
+			Logger: config.Logger,
+			Stderr: config.Stderr,
+			Stdout: config.Stdout,
+		}
+
+		pluginsCmd, err = plugins.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	var precommitCmd *cobra.Command
 	{
 		c := precommit.Config{
@@ -155,6 +190,20 @@ func New(config Config) (*cobra.Command, error) {
 		}
 	}
 
+	var statusCmd *cobra.Command
+	{
+		c := status.Config{
+			Logger: config.Logger,
+			Stderr: config.Stderr,
+			Stdout: config.Stdout,
+		}
+
+		statusCmd, err = status.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	var workflowsCmd *cobra.Command
 	{
 		c := workflows.Config{
@@ -190,12 +239,52 @@ func New(config Config) (*cobra.Command, error) {
 	c.AddCommand(amiCmd)
 	c.AddCommand(circleciCmd)
 	c.AddCommand(dependabotCmd)
+	c.AddCommand(fleetCmd)
 	c.AddCommand(llmCmd)
 	c.AddCommand(makefileCmd)
 	c.AddCommand(precommitCmd)
 	c.AddCommand(renovateCmd)
+	c.AddCommand(statusCmd)
 	c.AddCommand(workflowsCmd)
 	c.AddCommand(apptestCmd)
+	c.AddCommand(pluginsCmd)
+
+	// Plugins are registered last so they cannot shadow built-in
+	// generators. A broken plugin must not break devctl, so load errors
+	// are only reported.
+	{
+		builtin := map[string]bool{"help": true}
+		for _, sub := range c.Commands() {
+			builtin[sub.Name()] = true
+		}
+
+		loaded, errs := genplugin.Discover(env.GenPluginPath.Val())
+		for _, err := range errs {
+			_, _ = fmt.Fprintf(config.Stderr, "Warning: skipping gen plugin: %s\n", err)
+		}
+
+		for _, p := range loaded {
+			if builtin[p.Descriptor.Name] {
+				_, _ = fmt.Fprintf(config.Stderr, "Warning: skipping gen plugin %#q loaded from %s: the name is taken by a built-in generator\n", p.Descriptor.Name, p.Dir)
+				continue
+			}
+
+			pc := plugin.Config{
+				Logger: config.Logger,
+				Plugin: p,
+				Stderr: config.Stderr,
+				Stdout: config.Stdout,
+			}
+
+			pluginCmd, err := plugin.New(pc)
+			if err != nil {
+				_, _ = fmt.Fprintf(config.Stderr, "Warning: skipping gen plugin %#q loaded from %s: %s\n", p.Descriptor.Name, p.Dir, err)
+				continue
+			}
+
+			c.AddCommand(pluginCmd)
+		}
+	}
 
 	return c, nil
 }
@@ -11,7 +11,7 @@ import (
 
 const (
 	name        = "dependabot"
-	description = "Generates GitHub Dependabot config for go and docker dependencies (.github/dependabot.yml)."
+	description = "Generates GitHub Dependabot config (.github/dependabot.yml) for the package manifests found in the repository."
 	example     = `  devctl gen dependabot
   devctl gen dependabot --interval daily --reviewers giantswarm/team-firecracker
   devctl gen dependabot --interval weekly --reviewers giantswarm/team-firecracker,njuettner`
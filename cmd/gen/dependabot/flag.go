@@ -1,7 +1,6 @@
 package dependabot
 
 import (
-	"os"
 	"strings"
 
 	"github.com/giantswarm/microerror"
@@ -11,61 +10,45 @@ import (
 )
 
 const (
-	flagEcosystems = "ecosystems"
-	flagInterval   = "interval"
-	flagReviewers  = "reviewers"
+	flagDirectorySettings = "directory-settings"
+	flagEcosystems        = "ecosystems"
+	flagIgnore            = "ignore"
+	flagInterval          = "interval"
+	flagRecursive         = "recursive"
+	flagReviewers         = "reviewers"
+	flagRules             = "rules"
 )
 
 type flag struct {
-	Interval   string
-	Reviewers  []string
-	Ecosystems []string
+	DirectorySettings string
+	Ecosystems        []string
+	Ignore            []string
+	Interval          string
+	Recursive         bool
+	Reviewers         []string
+	Rules             string
 }
 
 func (f *flag) Init(cmd *cobra.Command) {
 	cmd.Flags().StringVarP(&f.Interval, flagInterval, "i", "weekly", "Check for daily, weekly or monthly updates (default: weekly).")
 	cmd.Flags().StringSliceVarP(&f.Reviewers, flagReviewers, "r", []string{}, "Reviewers you want to assign automatically when Dependabot creates a PR, e.g. giantswarm/team-firecracker.")
-	cmd.Flags().StringSliceVarP(&f.Ecosystems, flagEcosystems, "e", []string{}, "Ecosystem for each one package manager that you want GitHub Dependabot to monitor for new versions , e.g. go, docker. Setting this flag disables autodetection of files.")
+	cmd.Flags().StringSliceVarP(&f.Ecosystems, flagEcosystems, "e", []string{}, "Ecosystem for each one package manager that you want GitHub Dependabot to monitor for new versions , e.g. go, docker. Setting this flag disables autodetection of files, or with --recursive restricts it to the given ecosystems.")
+	cmd.Flags().BoolVar(&f.Recursive, flagRecursive, false, "Discover manifests in all subdirectories and generate one update entry per directory and ecosystem, e.g. for monorepos.")
+	cmd.Flags().StringSliceVar(&f.Ignore, flagIgnore, []string{}, "Globs of directories, relative to the repository root, which are not searched for manifests with --recursive, e.g. examples/*.")
+	cmd.Flags().StringVar(&f.Rules, flagRules, "", "YAML file with private registries and per-ecosystem groups, ignore and allow rules, see docs/gen.md.")
+	cmd.Flags().StringVar(&f.DirectorySettings, flagDirectorySettings, "", "YAML file with ignore globs and per-directory reviewers, intervals and groups, see docs/gen.md.")
 }
 
 func (f *flag) Validate() error {
 	if !gen.IsValidSchedule(f.Interval) {
 		return microerror.Maskf(invalidFlagError, "--%s must be one of <%s>", flagInterval, strings.Join(gen.AllowedSchedule(), "|"))
 	}
-
-	// in case ecosystem was not set specifically, we autodetect files
-	if len(f.Ecosystems) == 0 {
-		if exists("Dockerfile") {
-			f.Ecosystems = append(f.Ecosystems, gen.EcosystemDocker.String())
-		}
-		if exists(".github/workflows/") {
-			f.Ecosystems = append(f.Ecosystems, gen.EcosystemGithubActions.String())
-		}
-		if exists("go.mod") && exists("go.sum") {
-			f.Ecosystems = append(f.Ecosystems, gen.EcosystemGomod.String())
-		}
-		if exists("package.json") {
-			f.Ecosystems = append(f.Ecosystems, gen.EcosystemNPM.String())
-		}
-		// Detecting setup.py tells us the entire repo is likely using python.
-		// Detecting .abs/main.yaml tells us that abs tests written in python are likely used.
-		if exists("setup.py") || exists(".abs/main.yaml") {
-			f.Ecosystems = append(f.Ecosystems, gen.EcosystemPIP.String())
-		}
-	}
-
 	if !gen.IsValidEcoSystem(f.Ecosystems) {
 		return microerror.Maskf(invalidFlagError, "--%s must be one of <%s>", flagEcosystems, strings.Join(gen.AllowedEcosystems(), "|"))
 	}
+	if len(f.Ignore) > 0 && !f.Recursive {
+		return microerror.Maskf(invalidFlagError, "--%s requires --%s", flagIgnore, flagRecursive)
+	}
 
 	return nil
 }
-
-func exists(file string) bool {
-	if _, err := os.Stat(file); err != nil {
-		if os.IsNotExist(err) {
-			return false
-		}
-	}
-	return true
-}
@@ -3,6 +3,7 @@ package dependabot
 import (
 	"context"
 	"io"
+	"slices"
 
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
@@ -11,6 +12,7 @@ import (
 	"github.com/giantswarm/devctl/v8/pkg/gen"
 	"github.com/giantswarm/devctl/v8/pkg/gen/input"
 	"github.com/giantswarm/devctl/v8/pkg/gen/input/dependabot"
+	"github.com/giantswarm/devctl/v8/pkg/gen/monorepo"
 )
 
 type runner struct {
@@ -39,12 +41,68 @@ func (r *runner) Run(cmd *cobra.Command, args []string) error {
 func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) error {
 	var err error
 
+	var settings monorepo.Settings
+	if r.flag.DirectorySettings != "" {
+		settings, err = monorepo.ReadSettings(r.flag.DirectorySettings)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	var rules dependabot.Rules
+	if r.flag.Rules != "" {
+		rules, err = dependabot.ReadRules(r.flag.Rules)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	newDirectory := func(path string, ecosystems []string) dependabot.Directory {
+		d := dependabot.Directory{
+			Path:       path,
+			Ecosystems: ecosystems,
+		}
+		s, ok := settings.For(path)
+		if ok {
+			d.Interval = s.Interval
+			d.Reviewers = s.Reviewers
+			d.Group = s.Group
+		}
+		return d
+	}
+
+	var directories []dependabot.Directory
+	// Ecosystems set explicitly disable autodetection of files unless the
+	// whole tree is searched.
+	if len(r.flag.Ecosystems) > 0 && !r.flag.Recursive {
+		directories = append(directories, newDirectory(monorepo.Root, r.flag.Ecosystems))
+	} else {
+		found, err := monorepo.Discover(monorepo.Config{
+			Ignore:    append(settings.Ignore, r.flag.Ignore...),
+			Recursive: r.flag.Recursive,
+		})
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		for _, d := range found {
+			ecosystems := filterEcosystems(d.Ecosystems, r.flag.Ecosystems)
+			if len(ecosystems) == 0 {
+				continue
+			}
+
+			directories = append(directories, newDirectory(d.Path, ecosystems))
+			r.logger.Debugf(ctx, "found ecosystems %v in directory %#q", ecosystems, d.Path)
+		}
+	}
+
 	var dependabotInput *dependabot.Dependabot
 	{
 		c := dependabot.Config{
-			Interval:   r.flag.Interval,
-			Reviewers:  r.flag.Reviewers,
-			Ecosystems: r.flag.Ecosystems,
+			Interval:    r.flag.Interval,
+			Reviewers:   r.flag.Reviewers,
+			Directories: directories,
+			Rules:       rules,
 		}
 
 		dependabotInput, err = dependabot.New(c)
@@ -65,3 +123,19 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 
 	return nil
 }
+
+// filterEcosystems returns the ecosystems found which are also wanted. All
+// found ecosystems are wanted when wanted is empty.
+func filterEcosystems(found, wanted []string) []string {
+	if len(wanted) == 0 {
+		return found
+	}
+
+	var out []string
+	for _, e := range found {
+		if slices.Contains(wanted, e) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
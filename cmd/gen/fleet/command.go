@@ -0,0 +1,81 @@
+package fleet
+
+import (
+	"io"
+	"os"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/spf13/cobra"
+)
+
+const (
+	name             = "fleet"
+	shortDescription = `Regenerates files across many repositories and opens a PR per repository.`
+	longDescription  = `Regenerates files across many repositories and opens a PR per repository.
+
+Every target repository is cloned into a temporary directory, the configured
+generators are run in it and, when they changed anything, the changes are
+pushed to a branch and a pull request is opened against the default branch.
+When a pull request for the branch is already open, the branch is overwritten
+and the pull request is updated instead. Repositories without changes are
+skipped.
+
+Targets are read with --repos-from, which is either
+  - a file listing one repository per line (owner/repo, or a bare repository
+    name resolved against --owner; blank lines and # comments are ignored), or
+  - a GitHub team in the form org/team-slug, whose repositories are used.
+
+Each --generator is a devctl gen invocation without the leading "devctl gen",
+split on whitespace. Generators run in the order given.
+
+With --dry-run nothing is pushed and a summary of the repositories that would
+receive a PR is printed instead.
+
+A GitHub token is read from the GITHUB_TOKEN environment variable.
+`
+	example = `  devctl gen fleet --repos-from repos.txt --generator "dependabot --interval weekly"
+  devctl gen fleet --repos-from giantswarm/team-honeybadger \
+    --generator "workflows --flavour app --language go" \
+    --generator "makefile --flavour app --language go" \
+    --concurrency 8 --dry-run`
+)
+
+type Config struct {
+	Logger micrologger.Logger
+	Stderr io.Writer
+	Stdout io.Writer
+}
+
+func New(config Config) (*cobra.Command, error) {
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.Stderr == nil {
+		config.Stderr = os.Stderr
+	}
+	if config.Stdout == nil {
+		config.Stdout = os.Stdout
+	}
+
+	f := &flag{}
+
+	r := &runner{
+		flag:   f,
+		logger: config.Logger,
+		stderr: config.Stderr,
+		stdout: config.Stdout,
+	}
+
+	c := &cobra.Command{
+		Use:     name,
+		Short:   shortDescription,
+		Long:    longDescription,
+		Example: example,
+		RunE:    r.Run,
+	}
+
+	f.Init(c)
+
+	return c, nil
+}
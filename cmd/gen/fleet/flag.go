@@ -0,0 +1,67 @@
+package fleet
+
+import (
+	"strings"
+
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagBranch      = "branch"
+	flagConcurrency = "concurrency"
+	flagDryRun      = "dry-run"
+	flagGenerator   = "generator"
+	flagOwner       = "owner"
+	flagReposFrom   = "repos-from"
+	flagTitle       = "title"
+)
+
+type flag struct {
+	Branch      string
+	Concurrency int
+	DryRun      bool
+	Generators  []string
+	Owner       string
+	ReposFrom   string
+	Title       string
+}
+
+func (f *flag) Init(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.Branch, flagBranch, "devctl-gen-fleet", "Branch the regenerated files are pushed to.")
+	cmd.Flags().IntVar(&f.Concurrency, flagConcurrency, 4, "Maximum number of repositories processed at the same time.")
+	cmd.Flags().BoolVar(&f.DryRun, flagDryRun, false, "Only show which repositories would get a PR, without pushing anything.")
+	cmd.Flags().StringArrayVarP(&f.Generators, flagGenerator, "g", nil, `devctl gen invocation to run in every repository, e.g. "dependabot --interval weekly". Can be repeated.`)
+	cmd.Flags().StringVar(&f.Owner, flagOwner, "giantswarm", "Owner of repositories listed without one in the --repos-from file.")
+	cmd.Flags().StringVar(&f.ReposFrom, flagReposFrom, "", "File listing target repositories, or a GitHub team as org/team-slug.")
+	cmd.Flags().StringVar(&f.Title, flagTitle, "chore: regenerate files with devctl gen", "Title of the pull requests and commits.")
+}
+
+func (f *flag) Validate() error {
+	if f.ReposFrom == "" {
+		return microerror.Maskf(invalidFlagError, "--%s must not be empty", flagReposFrom)
+	}
+	if len(f.Generators) == 0 {
+		return microerror.Maskf(invalidFlagError, "at least one --%s must be given", flagGenerator)
+	}
+	for _, g := range f.Generators {
+		fields := strings.Fields(g)
+		if len(fields) == 0 {
+			return microerror.Maskf(invalidFlagError, "--%s must not be empty", flagGenerator)
+		}
+		if fields[0] == name {
+			return microerror.Maskf(invalidFlagError, "--%s must not run %#q itself", flagGenerator, name)
+		}
+	}
+	if f.Concurrency < 1 {
+		return microerror.Maskf(invalidFlagError, "--%s must be at least 1", flagConcurrency)
+	}
+	if f.Branch == "" {
+		return microerror.Maskf(invalidFlagError, "--%s must not be empty", flagBranch)
+	}
+	if f.Title == "" {
+		return microerror.Maskf(invalidFlagError, "--%s must not be empty", flagTitle)
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package fleet
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+	"github.com/google/go-github/v90/github"
+)
+
+type repository struct {
+	Owner string
+	Name  string
+}
+
+func (r repository) String() string {
+	return r.Owner + "/" + r.Name
+}
+
+// readRepositories resolves the --repos-from value. An existing file is
+// parsed as a repository list, anything else is treated as an org/team-slug
+// whose repositories are listed through the GitHub API.
+func readRepositories(ctx context.Context, client *github.Client, reposFrom, defaultOwner string) ([]repository, error) {
+	data, err := os.ReadFile(reposFrom)
+	if err == nil {
+		return parseRepositories(data, defaultOwner)
+	} else if !os.IsNotExist(err) {
+		return nil, microerror.Mask(err)
+	}
+
+	org, slug, ok := strings.Cut(reposFrom, "/")
+	if !ok || org == "" || slug == "" || strings.Contains(slug, "/") {
+		return nil, microerror.Maskf(invalidFlagError, "--%s must be an existing file or a team in the form org/team-slug, got %#q", flagReposFrom, reposFrom)
+	}
+
+	return listTeamRepositories(ctx, client, org, slug)
+}
+
+// parseRepositories parses a repository list with one owner/repo or bare
+// repo name per line. Blank lines and # comments are ignored and duplicates
+// are dropped.
+func parseRepositories(data []byte, defaultOwner string) ([]repository, error) {
+	seen := map[repository]bool{}
+	var repos []repository
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		r := repository{Owner: defaultOwner, Name: line}
+		if owner, name, ok := strings.Cut(line, "/"); ok {
+			r = repository{Owner: owner, Name: name}
+		}
+		if r.Owner == "" || r.Name == "" || strings.ContainsAny(r.Name, "/ \t") {
+			return nil, microerror.Maskf(invalidConfigError, "line %d: expected owner/repo or repo, got %#q", lineNo, line)
+		}
+
+		if seen[r] {
+			continue
+		}
+		seen[r] = true
+		repos = append(repos, r)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return repos, nil
+}
+
+func listTeamRepositories(ctx context.Context, client *github.Client, org, slug string) ([]repository, error) {
+	var repos []repository
+
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		page, resp, err := client.Teams.ListTeamReposBySlug(ctx, org, slug, opts)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		for _, r := range page {
+			if r.GetArchived() {
+				continue
+			}
+			repos = append(repos, repository{Owner: r.GetOwner().GetLogin(), Name: r.GetName()})
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	sort.Slice(repos, func(i, j int) bool {
+		return repos[i].String() < repos[j].String()
+	})
+
+	return repos, nil
+}
@@ -0,0 +1,59 @@
+package fleet
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRepositories(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []repository
+		wantErr bool
+	}{
+		{
+			name: "owner and bare names",
+			data: "giantswarm/devctl\nkyverno-app\n",
+			want: []repository{
+				{Owner: "giantswarm", Name: "devctl"},
+				{Owner: "giantswarm", Name: "kyverno-app"},
+			},
+		},
+		{
+			name: "comments, blank lines and duplicates",
+			data: "# team repos\n\nother/repo # trailing comment\n  other/repo  \n",
+			want: []repository{
+				{Owner: "other", Name: "repo"},
+			},
+		},
+		{
+			name:    "too many path segments",
+			data:    "giantswarm/devctl/extra\n",
+			wantErr: true,
+		},
+		{
+			name:    "missing owner",
+			data:    "/devctl\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseRepositories([]byte(tc.data), "giantswarm")
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("parseRepositories() returned no error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseRepositories() returned unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("parseRepositories() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
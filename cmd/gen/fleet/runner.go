@@ -0,0 +1,387 @@
+package fleet
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/go-git/go-git/v5"
+	"github.com/google/go-github/v90/github"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/devctl/v8/internal/env"
+	"github.com/giantswarm/devctl/v8/internal/pr"
+	"github.com/giantswarm/devctl/v8/pkg/githubclient"
+	"github.com/giantswarm/devctl/v8/pkg/project"
+)
+
+const (
+	statusNoChanges = "No changes"
+	statusFailed    = "Failed"
+)
+
+type runner struct {
+	flag   *flag
+	logger micrologger.Logger
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// result is the outcome of regenerating a single repository.
+type result struct {
+	repo    repository
+	changed []string
+	prURL   string
+	err     error
+}
+
+func (r *runner) Run(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	err := r.flag.Validate()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = r.run(ctx, cmd, args)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) error {
+	token := env.GitHubToken.Val()
+	if token == "" {
+		return microerror.Maskf(envVarNotFoundError, "environment variable GITHUB_TOKEN not found, please set it to your GitHub personal access token")
+	}
+
+	devctl, err := os.Executable()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	// Only show errors to avoid cluttering the table UI.
+	logger := logrus.StandardLogger()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	ghClientService, err := githubclient.New(githubclient.Config{
+		Logger:      logger,
+		AccessToken: token,
+		DryRun:      r.flag.DryRun,
+	})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	githubClient := ghClientService.GetUnderlyingClient(ctx)
+
+	repos, err := readRepositories(ctx, githubClient, r.flag.ReposFrom, r.flag.Owner)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if len(repos) == 0 {
+		_, _ = fmt.Fprintln(r.stdout, "No repositories found.")
+		return nil
+	}
+
+	if r.flag.DryRun {
+		_, _ = fmt.Fprintln(r.stdout, "🔍 DRY RUN MODE")
+		_, _ = fmt.Fprintln(r.stdout, "")
+	}
+
+	prStatuses := make([]*pr.PRStatus, len(repos))
+	for i, repo := range repos {
+		prStatuses[i] = &pr.PRStatus{
+			Owner:        repo.Owner,
+			Repo:         repo.Name,
+			URL:          fmt.Sprintf("https://github.com/%s", repo),
+			Status:       "Queued",
+			DisplayLabel: repo.String(),
+			LastUpdate:   time.Now(),
+		}
+	}
+
+	pr.PrintTableHeader(r.stdout, "Repository")
+	for range prStatuses {
+		_, _ = fmt.Fprintln(r.stdout, "")
+	}
+
+	results := make([]result, len(repos))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, r.flag.Concurrency)
+	for i, repo := range repos {
+		wg.Add(1)
+		go func(i int, repo repository) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results[i] = r.processRepository(ctx, logger, token, devctl, githubClient, repo, prStatuses[i])
+		}(i, repo)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			pr.UpdateTable(r.stdout, prStatuses)
+			_, _ = fmt.Fprintln(r.stdout, "")
+
+			return microerror.Mask(r.printSummary(results))
+		case <-ticker.C:
+			pr.UpdateTable(r.stdout, prStatuses)
+		}
+	}
+}
+
+func (r *runner) processRepository(ctx context.Context, logger *logrus.Logger, token, devctl string, githubClient *github.Client, repo repository, ps *pr.PRStatus) result {
+	res := result{repo: repo}
+
+	fail := func(status string, err error) result {
+		ps.UpdateStatus(fmt.Sprintf("%s: %s", statusFailed, status))
+		res.err = microerror.Mask(err)
+		return res
+	}
+
+	workDir, err := os.MkdirTemp("", "devctl-gen-fleet-*")
+	if err != nil {
+		return fail("temp dir", err)
+	}
+	defer func() { _ = os.RemoveAll(workDir) }()
+
+	// Every repository gets its own client as the client tracks the
+	// working directory of the clone it operates on.
+	client, err := githubclient.New(githubclient.Config{
+		Logger:      logger,
+		AccessToken: token,
+		Progress:    io.Discard,
+	})
+	if err != nil {
+		return fail("client", err)
+	}
+
+	ps.UpdateStatus("Cloning...")
+	err = client.CloneRepository(ctx, repo.Owner, repo.Name, workDir)
+	if err != nil {
+		return fail("clone", err)
+	}
+
+	gitRepo, err := git.PlainOpen(workDir)
+	if err != nil {
+		return fail("open clone", err)
+	}
+	head, err := gitRepo.Head()
+	if err != nil {
+		return fail("resolve HEAD", err)
+	}
+	baseBranch := head.Name().Short()
+
+	for _, g := range r.flag.Generators {
+		ps.UpdateStatus(fmt.Sprintf("Running gen %s...", strings.Fields(g)[0]))
+
+		err = runGenerator(ctx, devctl, workDir, g)
+		if err != nil {
+			return fail(fmt.Sprintf("gen %s", strings.Fields(g)[0]), err)
+		}
+	}
+
+	res.changed, err = changedFiles(gitRepo)
+	if err != nil {
+		return fail("git status", err)
+	}
+	if len(res.changed) == 0 {
+		ps.UpdateStatus(statusNoChanges)
+		return res
+	}
+
+	if r.flag.DryRun {
+		ps.UpdateStatus(fmt.Sprintf("Would open PR (%d files)", len(res.changed)))
+		return res
+	}
+
+	ps.UpdateStatus("Pushing...")
+	err = client.CreateBranch(ctx, r.flag.Branch)
+	if err != nil {
+		return fail("branch", err)
+	}
+	err = client.CommitAndForcePush(ctx, repo.Owner, repo.Name, r.flag.Branch, r.flag.Title)
+	if err != nil {
+		return fail("push", err)
+	}
+
+	body := r.pullRequestBody(res.changed)
+
+	existing, _, err := githubClient.PullRequests.List(ctx, repo.Owner, repo.Name, &github.PullRequestListOptions{
+		State: "open",
+		Head:  repo.Owner + ":" + r.flag.Branch,
+	})
+	if err != nil {
+		return fail("list PRs", err)
+	}
+
+	if len(existing) > 0 {
+		updated, _, err := githubClient.PullRequests.Edit(ctx, repo.Owner, repo.Name, existing[0].GetNumber(), &github.PullRequest{
+			Title: github.Ptr(r.flag.Title),
+			Body:  github.Ptr(body),
+		})
+		if err != nil {
+			return fail("update PR", err)
+		}
+		res.prURL = updated.GetHTMLURL()
+		ps.UpdateStatus(fmt.Sprintf("Updated PR #%d", updated.GetNumber()))
+		return res
+	}
+
+	created, _, err := githubClient.PullRequests.Create(ctx, repo.Owner, repo.Name, github.CreatePullRequest{
+		Title: github.Ptr(r.flag.Title),
+		Head:  r.flag.Branch,
+		Base:  baseBranch,
+		Body:  github.Ptr(body),
+	})
+	if err != nil {
+		return fail("create PR", err)
+	}
+	res.prURL = created.GetHTMLURL()
+	ps.UpdateStatus(fmt.Sprintf("Opened PR #%d", created.GetNumber()))
+
+	return res
+}
+
+func (r *runner) pullRequestBody(changed []string) string {
+	var b strings.Builder
+
+	b.WriteString("This PR was generated with `devctl gen fleet` running:\n\n")
+	for _, g := range r.flag.Generators {
+		fmt.Fprintf(&b, "- `devctl gen %s`\n", g)
+	}
+
+	b.WriteString("\nChanged files:\n\n")
+	for _, f := range changed {
+		fmt.Fprintf(&b, "- `%s`\n", f)
+	}
+
+	return b.String()
+}
+
+func (r *runner) printSummary(results []result) error {
+	var changed, unchanged, failed int
+	for _, res := range results {
+		switch {
+		case res.err != nil:
+			failed++
+		case len(res.changed) == 0:
+			unchanged++
+		default:
+			changed++
+		}
+	}
+
+	_, _ = fmt.Fprintln(r.stdout, "─────────────────────────────")
+	_, _ = fmt.Fprintln(r.stdout, "Summary:")
+	if r.flag.DryRun {
+		_, _ = fmt.Fprintf(r.stdout, "  Repositories that would get a PR: %d\n", changed)
+	} else {
+		_, _ = fmt.Fprintf(r.stdout, "  PRs opened or updated: %d\n", changed)
+	}
+	_, _ = fmt.Fprintf(r.stdout, "  Repositories without changes: %d\n", unchanged)
+	if failed > 0 {
+		_, _ = fmt.Fprintf(r.stdout, "  Repositories failed: %d\n", failed)
+	}
+
+	for _, res := range results {
+		if res.err != nil || len(res.changed) == 0 {
+			continue
+		}
+
+		_, _ = fmt.Fprintln(r.stdout, "")
+		if res.prURL != "" {
+			_, _ = fmt.Fprintf(r.stdout, "%s: %s\n", res.repo, res.prURL)
+		} else {
+			_, _ = fmt.Fprintf(r.stdout, "%s:\n", res.repo)
+		}
+		for _, f := range res.changed {
+			_, _ = fmt.Fprintf(r.stdout, "  %s\n", f)
+		}
+	}
+
+	if failed > 0 {
+		_, _ = fmt.Fprintln(r.stderr, "")
+		for _, res := range results {
+			if res.err != nil {
+				_, _ = fmt.Fprintf(r.stderr, "%s: %s\n", res.repo, res.err)
+			}
+		}
+
+		return microerror.Maskf(executionFailedError, "%d of %d repositories failed", failed, len(results))
+	}
+
+	return nil
+}
+
+// runGenerator runs a single devctl gen invocation in the given repository
+// clone. The running devctl binary is reused, and as it already passed the
+// version check the child process is told to skip it.
+func runGenerator(ctx context.Context, devctl, dir, generator string) error {
+	args := append([]string{"gen"}, strings.Fields(generator)...)
+
+	var output bytes.Buffer
+	c := exec.CommandContext(ctx, devctl, args...)
+	c.Dir = dir
+	c.Env = append(os.Environ(), fmt.Sprintf("%s=%s", env.DevctlUnsafeForceVersion.Key(), project.Version()))
+	c.Stdout = &output
+	c.Stderr = &output
+
+	err := c.Run()
+	if err != nil {
+		return microerror.Maskf(executionFailedError, "devctl %s: %s\n%s", strings.Join(args, " "), err, strings.TrimSpace(output.String()))
+	}
+
+	return nil
+}
+
+// changedFiles returns the sorted paths of all files the generators added,
+// modified or deleted in the clone.
+func changedFiles(gitRepo *git.Repository) ([]string, error) {
+	worktree, err := gitRepo.Worktree()
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	status, err := worktree.Status()
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var changed []string
+	for path, s := range status {
+		if s.Worktree == git.Unmodified && s.Staging == git.Unmodified {
+			continue
+		}
+		changed = append(changed, path)
+	}
+	sort.Strings(changed)
+
+	return changed, nil
+}
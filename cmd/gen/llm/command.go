@@ -15,8 +15,18 @@ const (
 	longDescription  = `Generates rules for LLM assistants.
 
 devctl gen llm --flavour app --language go
+devctl gen llm --flavour cluster-app --format cursor,agents,copilot
 
-A base rule set is always generated. Some flavour- and language-specific rule sets are also supported.
+A base rule set is always generated. Rule sets for the go, python and node
+languages and the app, cluster-app and k8sapi flavours are added as
+configured, as well as facts detected in the repo: documented make targets,
+test commands and generated files.
+
+Formats:
+
+- cursor: one .cursor/rules/zz_generated.*.mdc file per rule set
+- agents: all rule sets in AGENTS.md
+- copilot: all rule sets in .github/copilot-instructions.md
 `
 )
 
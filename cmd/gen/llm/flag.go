@@ -2,30 +2,48 @@ package llm
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
+	"github.com/giantswarm/microerror"
 	"github.com/spf13/cobra"
 
 	"github.com/giantswarm/devctl/v8/pkg/gen"
+	"github.com/giantswarm/devctl/v8/pkg/gen/input/llm"
 )
 
 const (
+	flagFacts    = "facts"
 	flagFlavour  = "flavour"
+	flagFormat   = "format"
 	flagLanguage = "language"
 )
 
 type flag struct {
+	Facts    bool
 	Flavours gen.FlavourSlice
+	Formats  []string
 	Language string
 }
 
 func (f *flag) Init(cmd *cobra.Command) {
 	cmd.Flags().VarP(gen.NewFlavourSliceFlagValue(&f.Flavours, gen.FlavourSlice{}), flagFlavour, "f", fmt.Sprintf(`The type of project that you want to generate rules for. Possible values: <%s>`, strings.Join(gen.AllFlavours(), "|")))
 	cmd.Flags().StringVarP(&f.Language, flagLanguage, "l", "", "Language of the repo, for generating additional language-specific rules.")
+	cmd.Flags().StringSliceVar(&f.Formats, flagFormat, []string{llm.FormatCursor}, fmt.Sprintf(`Agent instruction formats to generate. Possible values: <%s>`, strings.Join(llm.AllFormats(), "|")))
+	cmd.Flags().BoolVar(&f.Facts, flagFacts, true, "Include facts detected in the repo, like make targets, test commands and generated files.")
 }
 
 func (f *flag) Validate() error {
 	// Always generate a base rule set.
 
+	if len(f.Formats) == 0 {
+		return microerror.Maskf(invalidFlagError, "--%s must not be empty", flagFormat)
+	}
+	for _, format := range f.Formats {
+		if !slices.Contains(llm.AllFormats(), format) {
+			return microerror.Maskf(invalidFlagError, "--%s must be one of <%s>, got %#q", flagFormat, strings.Join(llm.AllFormats(), "|"), format)
+		}
+	}
+
 	return nil
 }
@@ -9,7 +9,6 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/giantswarm/devctl/v8/pkg/gen"
-	"github.com/giantswarm/devctl/v8/pkg/gen/input"
 	"github.com/giantswarm/devctl/v8/pkg/gen/input/llm"
 )
 
@@ -39,11 +38,22 @@ func (r *runner) Run(cmd *cobra.Command, args []string) error {
 func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) error {
 	var err error
 
+	var facts llm.Facts
+	if r.flag.Facts {
+		facts, err = llm.DetectFacts(".")
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
 	var llmInput *llm.LLM
 	{
 		c := llm.Config{
 			Flavours: r.flag.Flavours,
 			Language: r.flag.Language,
+
+			Formats: r.flag.Formats,
+			Facts:   facts,
 		}
 
 		llmInput, err = llm.New(c)
@@ -52,18 +62,9 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 		}
 	}
 
-	inputs := []input.Input{
-		llmInput.BaseLLMRules(),
-	}
-
-	// Add additional rules files for different flavours and languages
-	if r.flag.Language == "go" {
-		inputs = append(inputs, llmInput.GoLLMRules())
-	}
-
 	err = gen.Execute(
 		ctx,
-		inputs...,
+		llmInput.Files()...,
 	)
 	if err != nil {
 		return microerror.Mask(err)
@@ -11,8 +11,8 @@ import (
 
 const (
 	name             = "makefile"
-	shortDescription = `Generates a Makefile for Golang projects.`
-	longDescription  = `Generates a Makefile for Golang projects.
+	shortDescription = `Generates a Makefile and its includes.`
+	longDescription  = `Generates a Makefile and its includes.
 
 There are different generation flavours:
 
@@ -22,6 +22,13 @@ There are different generation flavours:
   - k8sapi - project containing a Kubernetes API
   - fleet - project containing clusters using GitOps
   - cluster-app - project containing helm chart, that is a cluster app (e.g. cluster-aws, cluster-azure, ...)
+
+The language adds an include with its build and test targets:
+
+  - go - Makefile.gen.go.mk
+  - python - Makefile.gen.python.mk (uv or a virtualenv, ruff, mypy, pytest, build)
+  - node - Makefile.gen.node.mk (wrapping the ci:verify and ci:build package.json scripts)
+  - kyverno-policy - Makefile.gen.chainsaw.mk
 `
 )
 
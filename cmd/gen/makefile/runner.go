@@ -44,6 +44,8 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 	// Makefile
 	// Makefile.app.mk
 	// Makefile.go.mk
+	// Makefile.node.mk
+	// Makefile.python.mk
 	{
 		c := makefile.Config{
 			Flavours: r.flag.Flavours,
@@ -72,6 +74,14 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 			inputs = append(inputs, in.MakefileGenGo()...)
 		}
 
+		if r.flag.Language == gen.LanguageNode {
+			inputs = append(inputs, in.MakefileGenNode())
+		}
+
+		if r.flag.Language == gen.LanguagePython {
+			inputs = append(inputs, in.MakefileGenPython())
+		}
+
 		if r.flag.Language == "kyverno-policy" {
 			inputs = append(inputs, in.MakefileGenChainsaw()...)
 		}
@@ -0,0 +1,65 @@
+package plugin
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/devctl/v8/pkg/gen/plugin"
+)
+
+type Config struct {
+	Logger micrologger.Logger
+	Plugin *plugin.Plugin
+	Stderr io.Writer
+	Stdout io.Writer
+}
+
+// New returns the `devctl gen` subcommand of a plugin generator.
+func New(config Config) (*cobra.Command, error) {
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.Plugin == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Plugin must not be empty", config)
+	}
+	if config.Stderr == nil {
+		config.Stderr = os.Stderr
+	}
+	if config.Stdout == nil {
+		config.Stdout = os.Stdout
+	}
+
+	f := &flag{
+		descriptor: config.Plugin.Descriptor,
+	}
+
+	r := &runner{
+		flag:   f,
+		logger: config.Logger,
+		plugin: config.Plugin,
+		stderr: config.Stderr,
+		stdout: config.Stdout,
+	}
+
+	shortDescription := config.Plugin.Descriptor.Description
+	if shortDescription == "" {
+		shortDescription = fmt.Sprintf("Generates files of the %#q plugin.", config.Plugin.Descriptor.Name)
+	}
+	longDescription := fmt.Sprintf("%s\n\nThis command is provided by the plugin loaded from %s.", shortDescription, config.Plugin.Dir)
+
+	c := &cobra.Command{
+		Use:   config.Plugin.Descriptor.Name,
+		Short: shortDescription,
+		Long:  longDescription,
+		RunE:  r.Run,
+	}
+
+	f.Init(c)
+
+	return c, nil
+}
@@ -0,0 +1,89 @@
+package plugin
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/devctl/v8/pkg/gen"
+	"github.com/giantswarm/devctl/v8/pkg/gen/plugin"
+)
+
+const (
+	flagFlavour  = "flavour"
+	flagLanguage = "language"
+)
+
+type flag struct {
+	Flavours gen.FlavourSlice
+	Language string
+
+	descriptor plugin.Descriptor
+	cmd        *cobra.Command
+}
+
+func (f *flag) Init(cmd *cobra.Command) {
+	f.cmd = cmd
+
+	cmd.Flags().VarP(gen.NewFlavourSliceFlagValue(&f.Flavours, gen.FlavourSlice{}), flagFlavour, "f", fmt.Sprintf(`The type of project that you want to generate files for. Possible values: <%s>`, strings.Join(gen.AllFlavours(), "|")))
+	cmd.Flags().StringVarP(&f.Language, flagLanguage, "l", "", fmt.Sprintf(`Language of the repo. Possible values: <%s>`, strings.Join(gen.AllLanguages(), "|")))
+
+	// The descriptor is validated when the plugin is loaded, so defaults
+	// that do not parse fall back to the zero value.
+	for _, pf := range f.descriptor.Flags {
+		switch pf.FlagType() {
+		case plugin.FlagTypeBool:
+			def, _ := strconv.ParseBool(pf.Default)
+			cmd.Flags().BoolP(pf.Name, pf.Shorthand, def, pf.Description)
+		case plugin.FlagTypeStringSlice:
+			var def []string
+			if pf.Default != "" {
+				def = strings.Split(pf.Default, ",")
+			}
+			cmd.Flags().StringSliceP(pf.Name, pf.Shorthand, def, pf.Description)
+		default:
+			cmd.Flags().StringP(pf.Name, pf.Shorthand, pf.Default, pf.Description)
+		}
+
+		if pf.Required {
+			_ = cmd.MarkFlagRequired(pf.Name)
+		}
+	}
+}
+
+func (f *flag) Validate() error {
+	if f.Language != "" && !gen.IsValidLanguage(f.Language) {
+		return microerror.Maskf(invalidFlagError, "--%s must be one of <%s>", flagLanguage, strings.Join(gen.AllLanguages(), "|"))
+	}
+
+	return nil
+}
+
+// Values returns the plugin flag values keyed by their template data key.
+func (f *flag) Values() (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+
+	for _, pf := range f.descriptor.Flags {
+		var v interface{}
+		var err error
+
+		switch pf.FlagType() {
+		case plugin.FlagTypeBool:
+			v, err = f.cmd.Flags().GetBool(pf.Name)
+		case plugin.FlagTypeStringSlice:
+			v, err = f.cmd.Flags().GetStringSlice(pf.Name)
+		default:
+			v, err = f.cmd.Flags().GetString(pf.Name)
+		}
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		values[pf.TemplateKey()] = v
+	}
+
+	return values, nil
+}
@@ -0,0 +1,62 @@
+package plugin
+
+import (
+	"context"
+	"io"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/devctl/v8/pkg/gen"
+	"github.com/giantswarm/devctl/v8/pkg/gen/plugin"
+)
+
+type runner struct {
+	flag   *flag
+	logger micrologger.Logger
+	plugin *plugin.Plugin
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func (r *runner) Run(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	err := r.flag.Validate()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = r.run(ctx, cmd, args)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) error {
+	values, err := r.flag.Values()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	c := plugin.Config{
+		Flavours: r.flag.Flavours,
+		Language: r.flag.Language,
+		Values:   values,
+	}
+
+	inputs, err := r.plugin.Inputs(c)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = gen.Execute(ctx, inputs...)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
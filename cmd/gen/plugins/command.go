@@ -0,0 +1,86 @@
+package plugins
+
+import (
+	"io"
+	"os"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/devctl/v8/cmd/gen/plugins/install"
+)
+
+const (
+	name             = "plugins"
+	shortDescription = `Lists and installs plugin generators.`
+	longDescription  = `Lists and installs plugin generators.
+
+A plugin generator is a directory holding a devctl-plugin.yaml descriptor and
+the Go text templates it references. Every plugin registers a "devctl gen"
+subcommand named after the plugin.
+
+Plugins are loaded from the directories listed in DEVCTL_GEN_PLUGIN_PATH
+(separated like PATH) and from the directory "devctl gen plugins install"
+installs plugins into. Each directory is either a plugin itself or holds
+plugins in its subdirectories.
+
+Without a subcommand the loaded plugins are listed.
+`
+)
+
+type Config struct {
+	Logger micrologger.Logger
+	Stderr io.Writer
+	Stdout io.Writer
+}
+
+func New(config Config) (*cobra.Command, error) {
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.Stderr == nil {
+		config.Stderr = os.Stderr
+	}
+	if config.Stdout == nil {
+		config.Stdout = os.Stdout
+	}
+
+	var err error
+
+	var installCmd *cobra.Command
+	{
+		c := install.Config{
+			Logger: config.Logger,
+			Stderr: config.Stderr,
+			Stdout: config.Stdout,
+		}
+
+		installCmd, err = install.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	f := &flag{}
+
+	r := &runner{
+		flag:   f,
+		logger: config.Logger,
+		stderr: config.Stderr,
+		stdout: config.Stdout,
+	}
+
+	c := &cobra.Command{
+		Use:   name,
+		Short: shortDescription,
+		Long:  longDescription,
+		RunE:  r.Run,
+	}
+
+	f.Init(c)
+
+	c.AddCommand(installCmd)
+
+	return c, nil
+}
@@ -0,0 +1,67 @@
+package install
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/spf13/cobra"
+)
+
+const (
+	name             = "install"
+	shortDescription = `Installs a plugin generator from a Git repository.`
+	longDescription  = `Installs a plugin generator from a Git repository.
+
+The repository is cloned and the plugin found in its root, or in --subdir, is
+copied into the devctl plugin directory. An installed plugin with the same name
+is replaced, so running the command again updates the plugin.
+
+A GitHub token from the environment is used to authenticate the clone when
+set, so plugins can be installed from private repositories.
+`
+	example = `  devctl gen plugins install https://github.com/giantswarm/devctl-gen-backstage
+  devctl gen plugins install https://github.com/giantswarm/gen-plugins --subdir backstage --ref v1.2.0`
+)
+
+type Config struct {
+	Logger micrologger.Logger
+	Stderr io.Writer
+	Stdout io.Writer
+}
+
+func New(config Config) (*cobra.Command, error) {
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.Stderr == nil {
+		config.Stderr = os.Stderr
+	}
+	if config.Stdout == nil {
+		config.Stdout = os.Stdout
+	}
+
+	f := &flag{}
+
+	r := &runner{
+		flag:   f,
+		logger: config.Logger,
+		stderr: config.Stderr,
+		stdout: config.Stdout,
+	}
+
+	c := &cobra.Command{
+		Use:     fmt.Sprintf("%s [flags] URL", name),
+		Short:   shortDescription,
+		Long:    longDescription,
+		Example: example,
+		Args:    cobra.ExactArgs(1),
+		RunE:    r.Run,
+	}
+
+	f.Init(c)
+
+	return c, nil
+}
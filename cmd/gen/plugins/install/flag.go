@@ -0,0 +1,22 @@
+package install
+
+import "github.com/spf13/cobra"
+
+const (
+	flagRef    = "ref"
+	flagSubdir = "subdir"
+)
+
+type flag struct {
+	Ref    string
+	Subdir string
+}
+
+func (f *flag) Init(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.Ref, flagRef, "", "Branch or tag to install. Defaults to the default branch.")
+	cmd.Flags().StringVar(&f.Subdir, flagSubdir, "", "Directory of the plugin within the repository. Defaults to the repository root.")
+}
+
+func (f *flag) Validate() error {
+	return nil
+}
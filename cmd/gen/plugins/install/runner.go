@@ -0,0 +1,61 @@
+package install
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/devctl/v8/internal/env"
+	"github.com/giantswarm/devctl/v8/pkg/gen/plugin"
+)
+
+type runner struct {
+	flag   *flag
+	logger micrologger.Logger
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func (r *runner) Run(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	err := r.flag.Validate()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = r.run(ctx, cmd, args)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) error {
+	c := plugin.InstallConfig{
+		URL:    args[0],
+		Ref:    r.flag.Ref,
+		Subdir: r.flag.Subdir,
+		Dir:    env.GenPluginPath.InstallDir(),
+	}
+
+	// Only hand the GitHub token to GitHub.
+	if strings.HasPrefix(c.URL, "https://github.com/") {
+		c.Token = env.GitHubToken.Val()
+	}
+
+	p, err := plugin.Install(ctx, c)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	_, _ = fmt.Fprintf(r.stdout, "Installed plugin %#q into %s. Run it with \"devctl gen %s\".\n", p.Descriptor.Name, p.Dir, p.Descriptor.Name)
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/devctl/v8/internal/env"
+	"github.com/giantswarm/devctl/v8/pkg/gen/plugin"
+)
+
+type runner struct {
+	flag   *flag
+	logger micrologger.Logger
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func (r *runner) Run(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	err := r.flag.Validate()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = r.run(ctx, cmd, args)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) error {
+	plugins, errs := plugin.Discover(env.GenPluginPath.Val())
+	for _, err := range errs {
+		_, _ = fmt.Fprintf(r.stderr, "Warning: skipping plugin: %s\n", err)
+	}
+
+	if len(plugins) == 0 {
+		_, _ = fmt.Fprintln(r.stdout, "No plugins found.")
+		return nil
+	}
+
+	t := table.NewWriter()
+	t.SetOutputMirror(r.stdout)
+	t.SetStyle(table.StyleDefault)
+	t.AppendHeader(table.Row{"NAME", "DESCRIPTION", "DIRECTORY"})
+	for _, p := range plugins {
+		t.AppendRow(table.Row{p.Descriptor.Name, p.Descriptor.Description, p.Dir})
+	}
+	t.Render()
+
+	return nil
+}
@@ -14,14 +14,18 @@ const (
 	flagFlavors          = "flavors"
 	flagRepoName         = "repo-name"
 	flagK8sSchemaVersion = "k8s-schema-version"
+	flagUpdateRevs       = "update-revs"
 
 	defaultK8sSchemaVersion = "v1.33.1"
 )
 
 var allowedFlavors = map[string]bool{
-	"bash":      true,
-	"md":        true,
-	"helmchart": true,
+	"actions":    true,
+	"bash":       true,
+	"dockerfile": true,
+	"helmchart":  true,
+	"kyverno":    true,
+	"md":         true,
 }
 
 type flag struct {
@@ -29,13 +33,15 @@ type flag struct {
 	Flavors          []string
 	RepoName         string
 	K8sSchemaVersion string
+	UpdateRevs       bool
 }
 
 func (f *flag) Init(cmd *cobra.Command) {
-	cmd.Flags().StringVarP(&f.Language, flagLanguage, "l", "", "Language for pre-commit hooks, e.g. go, generic.")
+	cmd.Flags().StringVarP(&f.Language, flagLanguage, "l", "", "Language for pre-commit hooks, one of go, python, node, terraform, generic.")
 	cmd.Flags().StringSliceVarP(&f.Flavors, flagFlavors, "f", []string{}, fmt.Sprintf("Comma-separated list of additional checker flavors (%s).", strings.Join(allowedFlavorsList(), ", ")))
 	cmd.Flags().StringVarP(&f.RepoName, flagRepoName, "r", "", "Repository name under giantswarm organization (e.g. devctl). Optional for --language go: auto-detected from the local go.mod when omitted.")
 	cmd.Flags().StringVar(&f.K8sSchemaVersion, flagK8sSchemaVersion, defaultK8sSchemaVersion, "Kubernetes JSON schema version used in helm chart .schema.yaml (e.g. v1.33.1).")
+	cmd.Flags().BoolVar(&f.UpdateRevs, flagUpdateRevs, false, "Pin each hook repository to its latest tag on GitHub, or to the revision in the existing .pre-commit-config.yaml when that is newer. Requires GITHUB_TOKEN.")
 }
 
 func (f *flag) Validate() error {
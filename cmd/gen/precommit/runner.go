@@ -4,16 +4,21 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"maps"
 	"os"
+	"slices"
 
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"golang.org/x/mod/modfile"
 
+	"github.com/giantswarm/devctl/v8/internal/env"
 	"github.com/giantswarm/devctl/v8/pkg/gen"
 	"github.com/giantswarm/devctl/v8/pkg/gen/input"
 	"github.com/giantswarm/devctl/v8/pkg/gen/input/precommit"
+	"github.com/giantswarm/devctl/v8/pkg/githubclient"
 )
 
 type runner struct {
@@ -64,17 +69,38 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 
 	var precommitInput *precommit.PreCommit
 	{
+		// Revisions pinned in the existing config which are newer than the
+		// defaults are kept, so plain regenerations do not undo
+		// --update-revs.
+		existing, err := precommit.ReadRevs(".pre-commit-config.yaml")
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
 		c := precommit.Config{
 			Language:         r.flag.Language,
 			Flavors:          r.flag.Flavors,
 			RepoName:         r.flag.RepoName,
 			K8sSchemaVersion: r.flag.K8sSchemaVersion,
+			Revs:             precommit.KeepRevs(existing),
 		}
 
 		precommitInput, err = precommit.New(c)
 		if err != nil {
 			return microerror.Mask(err)
 		}
+
+		if r.flag.UpdateRevs {
+			c.Revs, err = r.resolveRevs(ctx, precommitInput, existing)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+
+			precommitInput, err = precommit.New(c)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+		}
 	}
 
 	var inputs []input.Input
@@ -92,3 +118,40 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 
 	return nil
 }
+
+// resolveRevs resolves the latest revisions of the hooks p emits on GitHub,
+// keeping newer ones pinned in existing, and reports the ones differing from
+// the defaults.
+func (r *runner) resolveRevs(ctx context.Context, p *precommit.PreCommit, existing map[string]string) (map[string]string, error) {
+	token := env.GitHubToken.Val()
+	if token == "" {
+		return nil, microerror.Maskf(envVarNotFoundError, "--%s needs a GitHub token, set GITHUB_TOKEN or OPSCTL_GITHUB_TOKEN", flagUpdateRevs)
+	}
+
+	client, err := githubclient.New(githubclient.Config{
+		Logger:      logrus.StandardLogger(),
+		AccessToken: token,
+	})
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	repos, err := p.HookRepos(ctx)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	revs, err := precommit.ResolveRevs(ctx, client.LatestTag, existing, repos)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	defaults := precommit.DefaultRevs()
+	for _, repo := range slices.Sorted(maps.Keys(revs)) {
+		if revs[repo] != defaults[repo] {
+			_, _ = fmt.Fprintf(r.stdout, "%s: %s -> %s\n", repo, defaults[repo], revs[repo])
+		}
+	}
+
+	return revs, nil
+}
@@ -12,6 +12,8 @@ const (
 	flagCircleCIGenerated = "circleci-generated"
 	flagRepoName          = "repo-name"
 	flagDeprecated        = "deprecated"
+	flagDirectorySettings = "directory-settings"
+	flagIgnore            = "ignore"
 )
 
 type flag struct {
@@ -21,14 +23,18 @@ type flag struct {
 	CircleCIGenerated bool
 	RepoName          string
 	Deprecated        bool
+	DirectorySettings string
+	Ignore            []string
 }
 
 func (f *flag) Init(cmd *cobra.Command) {
 	cmd.Flags().StringVarP(&f.Interval, flagInterval, "i", "", "Check for daily, weekly or monthly updates.")
 	cmd.Flags().StringVarP(&f.Language, flagLanguage, "l", "", "Language for Renovate to  monitor for new versions , e.g. go, docker.")
 	cmd.Flags().StringSliceVarP(&f.Reviewers, flagReviewers, "r", []string{}, "Reviewers to set in the generated config's `reviewers` array, e.g. team:team-rocket. Repeat or comma-separate for multiple.")
-	cmd.Flags().BoolVar(&f.CircleCIGenerated, flagCircleCIGenerated, false, "Disable Renovate updates for the giantswarm/architect orb because .circleci/config.yml is generated by `devctl gen circleci` (which bakes in the orb version).")
+	cmd.Flags().BoolVar(&f.CircleCIGenerated, flagCircleCIGenerated, false, "Disable Renovate updates for the giantswarm/architect orb because .circleci/config.yml is generated by `devctl gen circleci` (which pins the orb version; see its --orb-version and --check-orb).")
 	cmd.Flags().StringVar(&f.RepoName, flagRepoName, "", "Repository name under the giantswarm organization, used for the renovate-custom.json5 extends entry. Defaults to the working directory's basename.")
+	cmd.Flags().StringSliceVar(&f.Ignore, flagIgnore, []string{}, "Globs of directories, relative to the repository root, whose manifests Renovate ignores, e.g. examples/*. Replaces the ignorePaths of the presets.")
+	cmd.Flags().StringVar(&f.DirectorySettings, flagDirectorySettings, "", "YAML file with ignore globs and per-directory reviewers, intervals and groups, see docs/gen.md.")
 	cmd.Flags().BoolVar(&f.Deprecated, flagDeprecated, false, "Extend the renovate-presets deprecated.json5 preset, which disables all routine updates and keeps only security/vulnerability remediation. Set for repos marked lifecycle: deprecated.")
 }
 
@@ -13,6 +13,7 @@ import (
 	"github.com/giantswarm/devctl/v8/pkg/gen"
 	"github.com/giantswarm/devctl/v8/pkg/gen/input"
 	"github.com/giantswarm/devctl/v8/pkg/gen/input/renovate"
+	"github.com/giantswarm/devctl/v8/pkg/gen/monorepo"
 )
 
 type runner struct {
@@ -56,6 +57,41 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 		repoName = filepath.Base(wd)
 	}
 
+	var settings monorepo.Settings
+	if r.flag.DirectorySettings != "" {
+		settings, err = monorepo.ReadSettings(r.flag.DirectorySettings)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+	ignore := append(settings.Ignore, r.flag.Ignore...)
+
+	// Renovate finds manifests on its own. Discovery only resolves the
+	// directory settings globs to the manifest directories they apply to.
+	var directories []renovate.Directory
+	if len(settings.Directories) > 0 {
+		found, err := monorepo.Discover(monorepo.Config{
+			Ignore:    ignore,
+			Recursive: true,
+		})
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		for _, d := range found {
+			s, ok := settings.For(d.Path)
+			if !ok {
+				continue
+			}
+			directories = append(directories, renovate.Directory{
+				Path:      d.Path,
+				Reviewers: s.Reviewers,
+				Interval:  s.Interval,
+				Group:     s.Group,
+			})
+		}
+	}
+
 	var renovateInput *renovate.Renovate
 	{
 		c := renovate.Config{
@@ -66,6 +102,8 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 			RepoName:          repoName,
 			HasCustomConfig:   hasCustomConfig,
 			Deprecated:        r.flag.Deprecated,
+			IgnorePaths:       ignore,
+			Directories:       directories,
 		}
 
 		renovateInput, err = renovate.New(c)
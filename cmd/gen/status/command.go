@@ -0,0 +1,72 @@
+package status
+
+import (
+	"io"
+	"os"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/spf13/cobra"
+)
+
+const (
+	name             = "status"
+	shortDescription = `Reports the devctl template revision of generated files.`
+	longDescription  = `Reports the devctl template revision of generated files.
+
+Scans the repository for files generated by devctl and parses the provenance
+header written at the top of each of them. For every generated file it reports
+the devctl revision of the template the file was rendered from, whether that
+revision is outdated compared to the template embedded in the running devctl,
+and whether the file was edited by hand after it was generated.
+
+Hand edits are detected with the content hash devctl stamps into the header.
+Files generated by older devctl versions carry no content hash, so their
+modification state is reported as unknown.
+
+With --check the command fails when any generated file is outdated or modified,
+which makes it usable as a CI gate.
+`
+	example = `  devctl gen status
+  devctl gen status --dir ../my-repo
+  devctl gen status --check`
+)
+
+type Config struct {
+	Logger micrologger.Logger
+	Stderr io.Writer
+	Stdout io.Writer
+}
+
+func New(config Config) (*cobra.Command, error) {
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.Stderr == nil {
+		config.Stderr = os.Stderr
+	}
+	if config.Stdout == nil {
+		config.Stdout = os.Stdout
+	}
+
+	f := &flag{}
+
+	r := &runner{
+		flag:   f,
+		logger: config.Logger,
+		stderr: config.Stderr,
+		stdout: config.Stdout,
+	}
+
+	c := &cobra.Command{
+		Use:     name,
+		Short:   shortDescription,
+		Long:    longDescription,
+		Example: example,
+		RunE:    r.Run,
+	}
+
+	f.Init(c)
+
+	return c, nil
+}
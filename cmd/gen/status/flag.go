@@ -0,0 +1,29 @@
+package status
+
+import (
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagCheck = "check"
+	flagDir   = "dir"
+)
+
+type flag struct {
+	Check bool
+	Dir   string
+}
+
+func (f *flag) Init(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&f.Check, flagCheck, false, "Fail when any generated file is outdated or was modified after generation.")
+	cmd.Flags().StringVarP(&f.Dir, flagDir, "d", ".", "Root directory of the repository to scan.")
+}
+
+func (f *flag) Validate() error {
+	if f.Dir == "" {
+		return microerror.Maskf(invalidFlagError, "--%s must not be empty", flagDir)
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"sort"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/devctl/v8/pkg/gen"
+	"github.com/giantswarm/devctl/v8/pkg/gen/input"
+)
+
+const (
+	stateUpToDate = "up-to-date"
+	stateOutdated = "outdated"
+	stateUnknown  = "unknown template"
+
+	modifiedYes     = "yes"
+	modifiedNo      = "no"
+	modifiedUnknown = "unknown"
+)
+
+// skipDirs are never descended into when scanning for generated files.
+var skipDirs = map[string]bool{
+	".git":         true,
+	"node_modules": true,
+	"vendor":       true,
+}
+
+type runner struct {
+	flag   *flag
+	logger micrologger.Logger
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func (r *runner) Run(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	err := r.flag.Validate()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = r.run(ctx, cmd, args)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) error {
+	revisions, err := input.TemplateRevisions()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	var provenances []gen.Provenance
+	err = filepath.WalkDir(r.flag.Dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		if d.IsDir() {
+			if path != r.flag.Dir && skipDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		p, ok, err := gen.ReadProvenance(path)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		if !ok {
+			return nil
+		}
+
+		rel, err := filepath.Rel(r.flag.Dir, path)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		p.Path = rel
+
+		provenances = append(provenances, p)
+
+		return nil
+	})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if len(provenances) == 0 {
+		_, _ = fmt.Fprintf(r.stdout, "No devctl generated files found in %#q.\n", r.flag.Dir)
+		return nil
+	}
+
+	sort.Slice(provenances, func(i, j int) bool {
+		return provenances[i].Path < provenances[j].Path
+	})
+
+	var drifted int
+
+	t := table.NewWriter()
+	t.SetOutputMirror(r.stdout)
+	t.SetStyle(table.StyleDefault)
+	t.AppendHeader(table.Row{"FILE", "REVISION", "CURRENT REVISION", "STATE", "MODIFIED"})
+
+	for _, p := range provenances {
+		current := revisions[p.TemplatePath]
+
+		state := templateState(p, revisions)
+		modified := modifiedState(p)
+
+		if state == stateOutdated || modified == modifiedYes {
+			drifted++
+		}
+
+		switch state {
+		case stateUpToDate:
+			state = text.FgGreen.Sprint(state)
+		case stateOutdated:
+			state = text.FgYellow.Sprint(state)
+		}
+		if modified == modifiedYes {
+			modified = text.FgRed.Sprint(modified)
+		}
+
+		t.AppendRow(table.Row{p.Path, shortRevision(p.Revision), shortRevision(current), state, modified})
+	}
+
+	t.Render()
+
+	if r.flag.Check && drifted > 0 {
+		return microerror.Maskf(driftError, "%d generated file(s) are outdated or were modified after generation", drifted)
+	}
+
+	return nil
+}
+
+// templateState compares the template revisions recorded in a generated
+// file with the revisions of the same templates embedded in the running
+// devctl. Files combining several templates are outdated when any of them
+// is.
+func templateState(p gen.Provenance, revisions map[string]string) string {
+	state := stateUpToDate
+	for _, t := range p.Templates {
+		current := revisions[t.TemplatePath]
+		switch {
+		case current == "":
+			// The template was renamed or removed, or this devctl was
+			// built from a checkout where it was never committed.
+			return stateUnknown
+		case current != t.Revision:
+			state = stateOutdated
+		}
+	}
+
+	return state
+}
+
+func modifiedState(p gen.Provenance) string {
+	switch {
+	case p.ContentHash == "":
+		return modifiedUnknown
+	case p.Modified:
+		return modifiedYes
+	default:
+		return modifiedNo
+	}
+}
+
+func shortRevision(revision string) string {
+	if len(revision) > 12 {
+		return revision[:12]
+	}
+	if revision == "" {
+		return "-"
+	}
+	return revision
+}
@@ -0,0 +1,76 @@
+package status
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/giantswarm/devctl/v8/pkg/gen"
+	"github.com/giantswarm/devctl/v8/pkg/gen/input/llm"
+	"github.com/giantswarm/devctl/v8/pkg/gen/input/renovate"
+)
+
+// Test_runListsGeneratedFiles verifies that gen status finds the files of
+// the generators whose headers differ from the usual top-of-file one:
+// renovate.json5 with its own comment, and the gen llm files closing with an
+// HTML comment, AGENTS.md and the Copilot instructions listing several
+// templates.
+func Test_runListsGeneratedFiles(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	r, err := renovate.New(renovate.Config{Language: "go"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := llm.New(llm.Config{
+		Formats:  []string{llm.FormatCursor, llm.FormatAgents, llm.FormatCopilot},
+		Language: "go",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = gen.Execute(context.Background(), append(l.Files(), r.CreateRenovate())...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout bytes.Buffer
+	sr := &runner{
+		flag:   &flag{Dir: "."},
+		stdout: &stdout,
+	}
+	err = sr.run(context.Background(), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{
+		"renovate.json5",
+		".cursor/rules/zz_generated.base-llm-rules.mdc",
+		".cursor/rules/zz_generated.go-llm-rules.mdc",
+		"AGENTS.md",
+		".github/copilot-instructions.md",
+	} {
+		row := rowOf(stdout.String(), path)
+		if row == "" {
+			t.Errorf("%s is not listed:\n%s", path, stdout.String())
+			continue
+		}
+		// The files were just generated, so their content hash matches.
+		if !strings.Contains(row, " "+modifiedNo+" ") {
+			t.Errorf("%s is not reported unmodified: %s", path, row)
+		}
+	}
+}
+
+// rowOf returns the table row of path in out, or an empty string.
+func rowOf(out, path string) string {
+	for _, line := range strings.Split(out, "\n") {
+		if strings.Contains(line, " "+path+" ") {
+			return line
+		}
+	}
+
+	return ""
+}
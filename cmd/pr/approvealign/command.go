@@ -13,7 +13,7 @@ const (
 	name        = "approvealign"
 	shortCmd    = "approvealignfiles"   // Alias or a more descriptive name if needed
 	longCmd     = "approve-align-files" // Used for cobra command registration
-	description = "Approves 'Align files' PRs with passing status checks."
+	description = "Approves 'Align files' PRs and queues them to merge where the repository allows it."
 )
 
 type Config struct {
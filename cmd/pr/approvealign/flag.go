@@ -1,17 +1,48 @@
 package approvealign
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/giantswarm/microerror"
 	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/devctl/v8/internal/pr"
 )
 
 type flag struct {
-	DryRun bool
+	DryRun    bool
+	Resume    bool
+	StateFile string
+
+	Output     string
+	ReportOnly bool
+
+	MergeMethod  string
+	TrackTimeout time.Duration
 }
 
 func (f *flag) Init(cmd *cobra.Command) {
 	cmd.Flags().BoolVar(&f.DryRun, "dry-run", false, "Only show what would be done without making changes")
+	cmd.Flags().BoolVar(&f.Resume, "resume", false, "Continue the previous run, keeping the PRs it merged as they are")
+	cmd.Flags().StringVar(&f.StateFile, "state-file", "", fmt.Sprintf("File the actions of each run are logged to (default %s in the devctl config dir)", pr.StateFile))
+	cmd.Flags().StringVar(&f.MergeMethod, "merge-method", "", fmt.Sprintf("Merge method for auto-merge, one of <%s> (default the first the repository allows, in that order). Merge queues use their own", strings.Join(pr.AllMergeMethods(), "|")))
+	cmd.Flags().DurationVar(&f.TrackTimeout, "track-timeout", 10*time.Minute, "How long to follow PRs handed to a merge queue or auto-merge until they merge, 0 to stop once they are queued")
+	cmd.Flags().StringVarP(&f.Output, "output", "o", pr.OutputTable, fmt.Sprintf("Output format, one of <%s>. The json and markdown reports are written to stdout once the run ends, progress goes to stderr", strings.Join(pr.AllOutputs(), "|")))
+	cmd.Flags().BoolVar(&f.ReportOnly, "report-only", false, "Only report the matching PRs with their checks, mergeability and what would be done, without approving or updating anything")
 }
 
 func (f *flag) Validate() error {
+	if !slices.Contains(pr.AllOutputs(), f.Output) {
+		return microerror.Maskf(invalidFlagsError, "--output must be one of <%s>, got %q", strings.Join(pr.AllOutputs(), "|"), f.Output)
+	}
+	if f.MergeMethod != "" && !slices.Contains(pr.AllMergeMethods(), f.MergeMethod) {
+		return microerror.Maskf(invalidFlagsError, "--merge-method must be one of <%s>, got %q", strings.Join(pr.AllMergeMethods(), "|"), f.MergeMethod)
+	}
+	if f.TrackTimeout < 0 {
+		return microerror.Maskf(invalidFlagsError, "--track-timeout must not be negative")
+	}
 	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -18,11 +19,26 @@ import (
 	"github.com/giantswarm/devctl/v8/pkg/githubclient"
 )
 
+// trackInterval is how often PRs handed to a merge queue or auto-merge are
+// looked at.
+const trackInterval = 15 * time.Second
+
 type runner struct {
 	flag   *flag
 	logger *logrus.Logger
 	stdout io.Writer
 	stderr io.Writer
+	// out receives the report of --output and --report-only. Non-table
+	// reports take stdout over, and stdout is pointed at stderr.
+	out io.Writer
+
+	// state logs the actions of the run, nil in dry runs.
+	state *pr.StateLog
+
+	// entries collects what the run learns about each PR for the report,
+	// by PR URL.
+	entries   map[string]*pr.ReportEntry
+	entriesMu sync.Mutex
 }
 
 func (r *runner) Run(cmd *cobra.Command, args []string) error {
@@ -30,6 +46,12 @@ func (r *runner) Run(cmd *cobra.Command, args []string) error {
 	if err := r.flag.Validate(); err != nil {
 		return microerror.Mask(err)
 	}
+
+	r.out = r.stdout
+	if r.flag.Output != pr.OutputTable {
+		r.stdout = r.stderr
+	}
+
 	return r.run(ctx, cmd, args)
 }
 
@@ -61,7 +83,30 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 	// Search for align-files PRs
 	// Note: We don't filter by status:success here because we want to find all PRs
 	// and then check/wait for their status in processPR (similar to approve-merge-renovate)
-	searchQuery := `is:pr is:open archived:false org:giantswarm review-requested:@me "chore: align files according to platform standards"`
+	searchQuery := fmt.Sprintf(`is:pr is:open archived:false org:giantswarm review-requested:@me %q`, pr.AlignFilesTitle)
+
+	// PRs the resumed run merged stay as they are, the others are retried.
+	var previous pr.RunState
+	if r.flag.Resume {
+		records, err := pr.ReadState(r.stateFile())
+		if err != nil {
+			return microerror.Maskf(executionFailedError, "%s", err)
+		}
+		var ok bool
+		previous, ok = pr.LastRun(records, longCmd)
+		if !ok {
+			return microerror.Maskf(executionFailedError, "no previous run to resume in %s", r.stateFile())
+		}
+		fmt.Fprintf(r.stdout, "Resuming run %s.\n\n", previous.Run)
+	}
+
+	if !r.flag.DryRun && !r.flag.ReportOnly {
+		r.state = pr.NewStateLog(r.stateFile(), longCmd, previous.Run)
+		err = r.state.Start(searchQuery, "", pr.Search{})
+		if err != nil {
+			return microerror.Maskf(executionFailedError, "failed to write state file: %v", err)
+		}
+	}
 
 	searchOpts := &github.SearchOptions{
 		ListOptions: github.ListOptions{PerPage: 100},
@@ -71,9 +116,13 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 		return microerror.Maskf(executionFailedError, "failed to search for PRs: %v", err)
 	}
 
+	if r.flag.ReportOnly {
+		return r.reportOnly(ctx, githubClient, searchResults.Issues, previous)
+	}
+
 	if searchResults.GetTotal() == 0 {
 		fmt.Fprintln(r.stdout, "No PRs found.")
-		return nil
+		return r.writeReport(nil)
 	}
 
 	// Initialize PR statuses with mutex protection for concurrent updates
@@ -99,16 +148,23 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 		prStatuses = append(prStatuses, ps)
 	}
 
-	pr.PrintTableHeader(r.stdout, "Repository")
+	live := r.flag.Output == pr.OutputTable
+	if live {
+		pr.PrintTableHeader(r.stdout, "Repository")
 
-	// Print initial empty rows for all PRs
-	for range prStatuses {
-		fmt.Fprintln(r.stdout, "")
+		// Print initial empty rows for all PRs
+		for range prStatuses {
+			fmt.Fprintln(r.stdout, "")
+		}
 	}
 
 	// Start processing all PRs in parallel
 	var wg sync.WaitGroup
 	for _, ps := range prStatuses {
+		if last, ok := previous.Last[ps.URL]; ok && last.Action.Done() {
+			ps.UpdateStatus("Merged (previous run)")
+			continue
+		}
 		wg.Add(1)
 		go func(ps *pr.PRStatus) {
 			defer wg.Done()
@@ -131,18 +187,14 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 		case <-done:
 			// Final update
 			prStatusesMu.Lock()
-			pr.UpdateTable(r.stdout, prStatuses)
-			prStatusesMu.Unlock()
-
-			fmt.Fprintln(r.stdout, "")
-
-			prStatusesMu.Lock()
-			r.printSummary(prStatuses)
-			prStatusesMu.Unlock()
+			defer prStatusesMu.Unlock()
 
-			return nil
+			return r.finish(prStatuses)
 
 		case <-ticker.C:
+			if !live {
+				continue
+			}
 			prStatusesMu.Lock()
 			pr.UpdateTable(r.stdout, prStatuses)
 			prStatusesMu.Unlock()
@@ -150,7 +202,378 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 	}
 }
 
+// finish shows the final state of prStatuses with the summary, and writes
+// the report of --output.
+func (r *runner) finish(prStatuses []*pr.PRStatus) error {
+	if r.flag.Output == pr.OutputTable {
+		pr.UpdateTable(r.stdout, prStatuses)
+		fmt.Fprintln(r.stdout, "")
+	}
+
+	r.printSummary(prStatuses)
+
+	return r.writeReport(prStatuses)
+}
+
+// note updates what the report says about the PR at url.
+func (r *runner) note(url string, update func(e *pr.ReportEntry)) {
+	r.entriesMu.Lock()
+	defer r.entriesMu.Unlock()
+
+	if r.entries == nil {
+		r.entries = map[string]*pr.ReportEntry{}
+	}
+	e, ok := r.entries[url]
+	if !ok {
+		e = &pr.ReportEntry{}
+		r.entries[url] = e
+	}
+	update(e)
+}
+
+// writeReport writes the report of --output on prStatuses, with what the
+// run noted about them. The table needs no report, it is already shown.
+func (r *runner) writeReport(prStatuses []*pr.PRStatus) error {
+	if r.flag.Output == pr.OutputTable {
+		return nil
+	}
+
+	entries := make([]pr.ReportEntry, 0, len(prStatuses))
+	r.entriesMu.Lock()
+	for _, ps := range prStatuses {
+		e := pr.ReportEntry{
+			Group:  pr.BotAlignFiles,
+			Owner:  ps.Owner,
+			Repo:   ps.Repo,
+			Number: ps.Number,
+			Title:  ps.Title,
+			URL:    ps.URL,
+			Status: ps.GetStatus(),
+		}
+		if noted, ok := r.entries[ps.URL]; ok {
+			e.Checks = noted.Checks
+			e.Mergeable = noted.Mergeable
+			e.Approved = noted.Approved
+			e.AutoMerge = noted.AutoMerge
+			e.Merge = noted.Merge
+		}
+		entries = append(entries, e)
+	}
+	r.entriesMu.Unlock()
+
+	return r.report(entries, false)
+}
+
+// reportOnly inspects the PRs of issues and reports what a run would do with
+// them, without doing it. PRs the resumed run merged are reported as such.
+func (r *runner) reportOnly(ctx context.Context, githubClient *github.Client, issues []*github.Issue, previous pr.RunState) error {
+	fmt.Fprintf(r.stdout, "Inspecting %d PRs...\n", len(issues))
+
+	var entries []pr.ReportEntry
+	for _, issue := range issues {
+		owner, repoName, err := pr.ParseRepoFromURL(issue.GetHTMLURL())
+		if err != nil {
+			continue
+		}
+		entries = append(entries, pr.ReportEntry{
+			Group:  pr.BotAlignFiles,
+			Owner:  owner,
+			Repo:   repoName,
+			Number: issue.GetNumber(),
+			Title:  issue.GetTitle(),
+			URL:    issue.GetHTMLURL(),
+		})
+	}
+
+	var wg sync.WaitGroup
+	for i := range entries {
+		if last, ok := previous.Last[entries[i].URL]; ok && last.Action.Done() {
+			entries[i].Action = "none: merged (previous run)"
+			continue
+		}
+		wg.Add(1)
+		go func(e *pr.ReportEntry) {
+			defer wg.Done()
+			r.inspect(ctx, githubClient, e)
+		}(&entries[i])
+	}
+	wg.Wait()
+
+	if r.flag.Output == pr.OutputTable {
+		fmt.Fprintln(r.stdout, "")
+	}
+
+	return r.report(entries, true)
+}
+
+// inspect fills e in with the state of its PR and the action a run would
+// take on it.
+func (r *runner) inspect(ctx context.Context, githubClient *github.Client, e *pr.ReportEntry) {
+	prData, _, err := githubClient.PullRequests.Get(ctx, e.Owner, e.Repo, e.Number)
+	if err != nil {
+		r.logger.Errorf("failed to get %s: %v", e.URL, err)
+		e.Action = "skip: failed to get PR"
+		return
+	}
+	e.Mergeable = prData.GetMergeableState()
+	e.AutoMerge = prData.GetAutoMerge() != nil
+
+	mode, _, failure := r.mergeMode(ctx, githubClient, e.Owner, e.Repo, prData)
+	if failure != "" {
+		e.Action = "skip: " + strings.ToLower(failure)
+		return
+	}
+	e.Merge = mode
+
+	headSHA := prData.GetHead().GetSHA()
+	combinedStatus, _, _ := githubClient.Repositories.GetCombinedStatus(ctx, e.Owner, e.Repo, headSHA, nil)
+	checkRuns, _, _ := githubClient.Checks.ListCheckRunsForRef(ctx, e.Owner, e.Repo, headSHA, nil)
+	e.Checks = pr.ChecksState(combinedStatus, checkRuns)
+
+	reviews, _, err := githubClient.PullRequests.ListReviews(ctx, e.Owner, e.Repo, e.Number, nil)
+	if err != nil {
+		r.logger.Errorf("failed to get reviews of %s: %v", e.URL, err)
+	}
+	for _, review := range reviews {
+		if review.GetState() == "APPROVED" {
+			e.Approved = true
+			break
+		}
+	}
+
+	// Align-files PRs carry no dependency update to assess. Branches behind
+	// their base are updated on top, merge queues need no up-to-date
+	// branches.
+	action := pr.PlannedAction(*e, pr.DecisionApprove)
+	switch {
+	case e.Mergeable != "behind" || e.Merge == pr.MergeModeQueue || strings.HasPrefix(action, "skip:"):
+	case strings.HasPrefix(action, "none:"):
+		action = "update branch"
+	default:
+		action += ", then update branch"
+	}
+	e.Action = action
+}
+
+// report writes entries as the report of --output.
+func (r *runner) report(entries []pr.ReportEntry, reportOnly bool) error {
+	report := pr.Report{
+		Command:    longCmd,
+		Generated:  time.Now(),
+		ReportOnly: reportOnly,
+		PRs:        entries,
+	}
+
+	err := pr.WriteReport(r.out, report, r.flag.Output)
+	if err != nil {
+		return microerror.Maskf(executionFailedError, "failed to write report: %v", err)
+	}
+
+	return nil
+}
+
+func (r *runner) stateFile() string {
+	if r.flag.StateFile != "" {
+		return r.flag.StateFile
+	}
+	return filepath.Join(env.ConfigDir.Val(), pr.StateFile)
+}
+
+// record logs action on the PR of ps to the state file. Dry runs are not
+// recorded.
+func (r *runner) record(ps *pr.PRStatus, action pr.Action, reason string) {
+	if r.state == nil {
+		return
+	}
+	err := r.state.Record(ps, action, reason)
+	if err != nil {
+		r.logger.Errorf("failed to record %s of %s: %v", action, ps.URL, err)
+	}
+}
+
 func (r *runner) processPR(ctx context.Context, githubClient *github.Client, ps *pr.PRStatus) {
+	ps.UpdateStatus("Checking...")
+	prData, _, err := githubClient.PullRequests.Get(ctx, ps.Owner, ps.Repo, ps.Number)
+	if err != nil {
+		ps.UpdateStatus("Failed to get PR")
+		r.record(ps, pr.ActionFailed, "Failed to get PR")
+		return
+	}
+
+	if prData.GetMerged() {
+		ps.UpdateStatus("Already merged")
+		r.record(ps, pr.ActionMerged, "")
+		return
+	}
+
+	mode, mergeMethod, failure := r.mergeMode(ctx, githubClient, ps.Owner, ps.Repo, prData)
+	if failure != "" {
+		ps.UpdateStatus(failure)
+		r.record(ps, pr.ActionFailed, failure)
+		return
+	}
+	r.note(ps.URL, func(e *pr.ReportEntry) { e.Merge = mode })
+
+	if mode == pr.MergeModeNone {
+		r.approve(ctx, githubClient, ps)
+		return
+	}
+
+	r.queueForMerge(ctx, githubClient, ps, prData, mode, mergeMethod)
+}
+
+// mergeMode returns how the PR of prData in owner/repo gets merged and with
+// which merge method, or the status to fail the PR with. Where GitHub cannot
+// merge, the PR is only approved.
+func (r *runner) mergeMode(ctx context.Context, githubClient *github.Client, owner, repoName string, prData *github.PullRequest) (pr.MergeMode, string, string) {
+	repo, _, err := githubClient.Repositories.Get(ctx, owner, repoName)
+	if err != nil {
+		return "", "", "Failed to get repo"
+	}
+
+	mergeMethod, err := pr.MergeMethod(repo, r.flag.MergeMethod)
+	if err != nil {
+		r.logger.Errorf("%v", err)
+		if r.flag.MergeMethod != "" {
+			return "", "", "Merge method not allowed"
+		}
+		return "", "", "No merge methods"
+	}
+
+	mode, err := pr.ResolveMergeMode(ctx, githubClient, repo, prData.GetBase().GetRef(), prData.GetAutoMerge() != nil)
+	if err != nil {
+		r.logger.Errorf("%v", err)
+		mode = pr.MergeModeDirect
+	}
+	if mode == pr.MergeModeDirect {
+		mode = pr.MergeModeNone
+	}
+
+	return mode, mergeMethod, ""
+}
+
+// queueForMerge approves the PR of ps and hands it over to the merge queue
+// or auto-merge, as mode says, without waiting for its checks. GitHub merges
+// it once they pass, and the PR is followed for --track-timeout.
+func (r *runner) queueForMerge(ctx context.Context, githubClient *github.Client, ps *pr.PRStatus, prData *github.PullRequest, mode pr.MergeMode, mergeMethod string) {
+	headSHA := prData.GetHead().GetSHA()
+	combinedStatus, _, _ := githubClient.Repositories.GetCombinedStatus(ctx, ps.Owner, ps.Repo, headSHA, nil)
+	checkRuns, _, _ := githubClient.Checks.ListCheckRunsForRef(ctx, ps.Owner, ps.Repo, headSHA, nil)
+
+	checks := pr.ChecksState(combinedStatus, checkRuns)
+	hasAutoMerge := prData.GetAutoMerge() != nil
+	behind := prData.GetMergeableState() == "behind" && mode != pr.MergeModeQueue
+	r.note(ps.URL, func(e *pr.ReportEntry) {
+		e.Checks = checks
+		e.Mergeable = prData.GetMergeableState()
+		e.AutoMerge = hasAutoMerge
+	})
+
+	if checks == pr.ChecksFailing {
+		ps.UpdateStatus("Failed checks")
+		r.record(ps, pr.ActionFailed, "Failed checks")
+		return
+	}
+
+	// Neither a merge queue nor auto-merge merges a conflicting PR, so it is
+	// not approved either, as the report plans.
+	if prData.GetMergeableState() == "dirty" {
+		ps.UpdateStatus("Merge conflicts")
+		r.record(ps, pr.ActionFailed, "Merge conflicts")
+		return
+	}
+
+	reviews, _, err := githubClient.PullRequests.ListReviews(ctx, ps.Owner, ps.Repo, ps.Number, nil)
+	if err != nil {
+		ps.UpdateStatus("Failed to get reviews")
+		r.record(ps, pr.ActionFailed, "Failed to get reviews")
+		return
+	}
+
+	alreadyApproved := false
+	for _, review := range reviews {
+		if review.GetState() == "APPROVED" {
+			alreadyApproved = true
+			break
+		}
+	}
+	r.note(ps.URL, func(e *pr.ReportEntry) { e.Approved = alreadyApproved })
+
+	if r.flag.DryRun {
+		what := "enqueue"
+		switch {
+		case hasAutoMerge:
+			what = "leave to auto-merge"
+		case mode == pr.MergeModeAutoMerge:
+			what = fmt.Sprintf("enable auto-merge (%s)", mergeMethod)
+		}
+		if !alreadyApproved {
+			what = "approve & " + what
+		}
+		if behind {
+			what += " & update branch"
+		}
+		ps.UpdateStatus("Would " + what)
+		return
+	}
+
+	if !alreadyApproved {
+		ps.UpdateStatus("Approving...")
+		reviewRequest := &github.PullRequestReviewRequest{
+			Event: github.String("APPROVE"),
+		}
+		_, _, err = githubClient.PullRequests.CreateReview(ctx, ps.Owner, ps.Repo, ps.Number, reviewRequest)
+		if err != nil {
+			ps.UpdateStatus("Failed to approve")
+			r.record(ps, pr.ActionFailed, "Failed to approve")
+			return
+		}
+		r.record(ps, pr.ActionApproved, "")
+		r.note(ps.URL, func(e *pr.ReportEntry) { e.Approved = true })
+	}
+
+	// Auto-merge waits for branches required to be up to date.
+	if behind {
+		ps.UpdateStatus("Updating branch...")
+		_, _, err := githubClient.PullRequests.UpdateBranch(ctx, ps.Owner, ps.Repo, ps.Number, nil)
+		if err != nil {
+			ps.UpdateStatus("Failed to update branch")
+			r.record(ps, pr.ActionFailed, "Failed to update branch")
+			return
+		}
+	}
+
+	ps.UpdateStatus("Queueing...")
+	position, err := pr.QueueForMerge(ctx, githubClient, prData, mode, mergeMethod, checks)
+	if err != nil {
+		// GitHub refuses auto-merge on PRs it could merge right away, which
+		// are left approved like in repositories without auto-merge.
+		r.logger.Errorf("failed to queue %s: %v", ps.URL, err)
+		if alreadyApproved {
+			ps.UpdateStatus("Already approved")
+		} else {
+			ps.UpdateStatus("Approved")
+		}
+		return
+	}
+
+	if position > 0 {
+		ps.UpdateStatus(fmt.Sprintf("Queued to merge (merge queue #%d)", position))
+	} else {
+		ps.UpdateStatus("Queued to merge (auto-merge)")
+	}
+	r.record(ps, pr.ActionMergeQueued, "")
+
+	action, reason := pr.WaitForMerge(ctx, githubClient, ps, r.flag.TrackTimeout, trackInterval)
+	if action != pr.ActionMergeQueued {
+		r.record(ps, action, reason)
+	}
+}
+
+// approve waits for the checks of the PR of ps and approves it, updating
+// its branch when it is behind, for repositories without merge queue or
+// auto-merge.
+func (r *runner) approve(ctx context.Context, githubClient *github.Client, ps *pr.PRStatus) {
 	maxRetries := 60 // Poll for up to 5 minutes (60 * 5 seconds)
 	retryDelay := 5 * time.Second
 
@@ -164,12 +587,14 @@ func (r *runner) processPR(ctx context.Context, githubClient *github.Client, ps
 		prData, _, err := githubClient.PullRequests.Get(ctx, ps.Owner, ps.Repo, ps.Number)
 		if err != nil {
 			ps.UpdateStatus("Failed to get PR")
+			r.record(ps, pr.ActionFailed, "Failed to get PR")
 			return
 		}
 
 		// Check if already merged
 		if prData.GetMerged() {
 			ps.UpdateStatus("Already merged")
+			r.record(ps, pr.ActionMerged, "")
 			return
 		}
 
@@ -181,56 +606,20 @@ func (r *runner) processPR(ctx context.Context, githubClient *github.Client, ps
 		combinedStatus, _, _ := githubClient.Repositories.GetCombinedStatus(ctx, ps.Owner, ps.Repo, headSHA, nil)
 		checkRuns, _, _ := githubClient.Checks.ListCheckRunsForRef(ctx, ps.Owner, ps.Repo, headSHA, nil)
 
-		// Check if any checks are failing or pending
-		hasFailedChecks := false
-		checksPending := false
-
-		// Check combined status first - this is for traditional status checks
-		if combinedStatus != nil {
-			state := combinedStatus.GetState()
-			totalCount := combinedStatus.GetTotalCount()
-
-			if state == "success" {
-				// All required checks passed
-				checksPending = false
-				hasFailedChecks = false
-			} else if state == "failure" || state == "error" {
-				hasFailedChecks = true
-			} else if state == "pending" && totalCount > 0 {
-				// Only treat as pending if there are actual status checks
-				// pending with totalCount=0 just means no checks exist, not that checks are waiting
-				checksPending = true
-			}
-		}
-
-		// Check individual check runs (GitHub Actions checks)
-		if checkRuns != nil && len(checkRuns.CheckRuns) > 0 && !hasFailedChecks {
-			// Only check runs if combinedStatus didn't already give us a definitive answer
-			if combinedStatus == nil || (combinedStatus.GetState() != "success" && combinedStatus.GetState() != "failure") {
-				for _, run := range checkRuns.CheckRuns {
-					conclusion := run.GetConclusion()
-					status := run.GetStatus()
-
-					if status == "completed" {
-						if conclusion == "failure" || conclusion == "cancelled" || conclusion == "timed_out" {
-							hasFailedChecks = true
-							break
-						}
-						// success, neutral, skipped are OK
-					} else {
-						// Check is not completed (queued, in_progress)
-						checksPending = true
-					}
-				}
-			}
-		}
+		checks := pr.ChecksState(combinedStatus, checkRuns)
+		r.note(ps.URL, func(e *pr.ReportEntry) {
+			e.Checks = checks
+			e.Mergeable = prData.GetMergeableState()
+			e.AutoMerge = hasAutoMerge
+		})
 
-		if hasFailedChecks {
+		if checks == pr.ChecksFailing {
 			ps.UpdateStatus("Failed checks")
+			r.record(ps, pr.ActionFailed, "Failed checks")
 			return
 		}
 
-		if checksPending {
+		if checks == pr.ChecksPending {
 			ps.UpdateStatus(fmt.Sprintf("Waiting for checks (%d/%d)", attempt+1, maxRetries))
 			continue
 		}
@@ -240,6 +629,7 @@ func (r *runner) processPR(ctx context.Context, githubClient *github.Client, ps
 		reviews, _, err := githubClient.PullRequests.ListReviews(ctx, ps.Owner, ps.Repo, ps.Number, nil)
 		if err != nil {
 			ps.UpdateStatus("Failed to get reviews")
+			r.record(ps, pr.ActionFailed, "Failed to get reviews")
 			return
 		}
 
@@ -250,12 +640,14 @@ func (r *runner) processPR(ctx context.Context, githubClient *github.Client, ps
 				break
 			}
 		}
+		r.note(ps.URL, func(e *pr.ReportEntry) { e.Approved = alreadyApproved })
 
 		if alreadyApproved {
 			// Check if it merged after being approved
 			prCheck, _, err := githubClient.PullRequests.Get(ctx, ps.Owner, ps.Repo, ps.Number)
 			if err == nil && prCheck.GetMerged() {
 				ps.UpdateStatus("Merged (auto-merge)")
+				r.record(ps, pr.ActionMerged, "")
 				return
 			}
 
@@ -270,6 +662,7 @@ func (r *runner) processPR(ctx context.Context, githubClient *github.Client, ps
 				_, _, err := githubClient.PullRequests.UpdateBranch(ctx, ps.Owner, ps.Repo, ps.Number, nil)
 				if err != nil {
 					ps.UpdateStatus("Failed to update branch")
+					r.record(ps, pr.ActionFailed, "Failed to update branch")
 					return
 				}
 
@@ -278,11 +671,13 @@ func (r *runner) processPR(ctx context.Context, githubClient *github.Client, ps
 				prCheck, _, err = githubClient.PullRequests.Get(ctx, ps.Owner, ps.Repo, ps.Number)
 				if err == nil && prCheck.GetMerged() {
 					ps.UpdateStatus("Merged (auto-merge)")
+					r.record(ps, pr.ActionMerged, "")
 					return
 				}
 
 				if hasAutoMerge {
 					ps.UpdateStatus("Updated, queued to merge")
+					r.record(ps, pr.ActionMergeQueued, "")
 				} else {
 					ps.UpdateStatus("Branch updated")
 				}
@@ -291,6 +686,7 @@ func (r *runner) processPR(ctx context.Context, githubClient *github.Client, ps
 
 			if hasAutoMerge {
 				ps.UpdateStatus("Already approved, queued")
+				r.record(ps, pr.ActionMergeQueued, "")
 			} else {
 				ps.UpdateStatus("Already approved")
 			}
@@ -314,14 +710,18 @@ func (r *runner) processPR(ctx context.Context, githubClient *github.Client, ps
 		_, _, err = githubClient.PullRequests.CreateReview(ctx, ps.Owner, ps.Repo, ps.Number, reviewRequest)
 		if err != nil {
 			ps.UpdateStatus("Failed to approve")
+			r.record(ps, pr.ActionFailed, "Failed to approve")
 			return
 		}
+		r.record(ps, pr.ActionApproved, "")
+		r.note(ps.URL, func(e *pr.ReportEntry) { e.Approved = true })
 
 		// After approval, check if PR needs to be updated with base branch
 		time.Sleep(2 * time.Second)
 		prCheck, _, err := githubClient.PullRequests.Get(ctx, ps.Owner, ps.Repo, ps.Number)
 		if err == nil && prCheck.GetMerged() {
 			ps.UpdateStatus("Merged (auto-merge)")
+			r.record(ps, pr.ActionMerged, "")
 			return
 		}
 
@@ -331,6 +731,7 @@ func (r *runner) processPR(ctx context.Context, githubClient *github.Client, ps
 			_, _, err := githubClient.PullRequests.UpdateBranch(ctx, ps.Owner, ps.Repo, ps.Number, nil)
 			if err != nil {
 				ps.UpdateStatus("Failed to update branch")
+				r.record(ps, pr.ActionFailed, "Failed to update branch")
 				return
 			}
 
@@ -339,11 +740,13 @@ func (r *runner) processPR(ctx context.Context, githubClient *github.Client, ps
 			prCheck, _, err = githubClient.PullRequests.Get(ctx, ps.Owner, ps.Repo, ps.Number)
 			if err == nil && prCheck.GetMerged() {
 				ps.UpdateStatus("Merged (auto-merge)")
+				r.record(ps, pr.ActionMerged, "")
 				return
 			}
 
 			if hasAutoMerge {
 				ps.UpdateStatus("Updated, queued to merge")
+				r.record(ps, pr.ActionMergeQueued, "")
 			} else {
 				ps.UpdateStatus("Branch updated")
 			}
@@ -353,6 +756,7 @@ func (r *runner) processPR(ctx context.Context, githubClient *github.Client, ps
 		// Not merged yet, not behind
 		if hasAutoMerge {
 			ps.UpdateStatus("Approved, queued to merge")
+			r.record(ps, pr.ActionMergeQueued, "")
 		} else {
 			ps.UpdateStatus("Approved")
 		}
@@ -382,13 +786,13 @@ func (r *runner) printSummary(prStatuses []*pr.PRStatus) {
 		} else if strings.Contains(status, "Branch updated") {
 			updated++
 			approved++
-		} else if strings.Contains(status, "queued to merge") {
+		} else if strings.Contains(strings.ToLower(status), "queued to merge") {
 			queued++
 		} else if strings.Contains(status, "Approved") && !strings.Contains(status, "Would") && !strings.Contains(status, "Already") {
 			approved++
 		} else if strings.Contains(status, "Already") {
 			skipped++
-		} else if strings.Contains(status, "Failed") {
+		} else if strings.Contains(status, "Failed") || strings.Contains(status, "Closed") || strings.Contains(status, "not allowed") {
 			failed++
 		} else if strings.Contains(status, "Waiting") || strings.Contains(status, "Timeout") {
 			waiting++
@@ -12,7 +12,7 @@ import (
 const (
 	name        = "approvemergerenovate"
 	longCmd     = "approve-merge-renovate"
-	description = "Approves and auto-merges Renovate PRs matching a search query. If no query is provided, presents an interactive group selector."
+	description = "Approves and auto-merges Renovate (or other bot) PRs matching a search query. If no query is provided, presents an interactive group selector."
 	usage       = "approve-merge-renovate [query]"
 )
 
@@ -57,7 +57,20 @@ func New(config Config) (*cobra.Command, error) {
   devctl pr amr "architect v1.2.3"
   
   # Watch mode with query
-  devctl pr amr --watch "architect v1.2.3"`,
+  devctl pr amr --watch "architect v1.2.3"
+
+  # Renovate and Dependabot PRs requesting review from a team, at least a day old
+  devctl pr amr --bot renovate,dependabot --reviewer giantswarm/team-honeybadger --min-age 24h
+
+  # Save these search flags as a profile, then run the daily sweep with it
+  devctl pr amr --bot renovate,dependabot --reviewer giantswarm/team-honeybadger --min-age 24h --save-profile honeybadger
+  devctl pr amr --profile honeybadger
+
+  # Approve minor updates without asking, and major ones after confirmation
+  devctl pr amr --auto-approve-risk medium --max-risk high
+
+  # Continue the previous run after it was interrupted
+  devctl pr amr --resume`,
 		RunE: r.Run,
 	}
 
@@ -2,9 +2,15 @@ package approvemergerenovate
 
 import (
 	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/giantswarm/microerror"
 	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/devctl/v8/internal/pr"
 )
 
 const (
@@ -12,16 +18,85 @@ const (
 	GroupingRepo       = "repo"
 )
 
+const (
+	flagBot          = "bot"
+	flagReviewer     = "reviewer"
+	flagLabel        = "label"
+	flagExcludeLabel = "exclude-label"
+	flagRepo         = "repo"
+	flagExcludeRepo  = "exclude-repo"
+	flagMinAge       = "min-age"
+	flagProfile      = "profile"
+	flagSaveProfile  = "save-profile"
+	flagProfilesFile = "profiles-file"
+	flagAutoApprove  = "auto-approve-risk"
+	flagMaxRisk      = "max-risk"
+	flagResume       = "resume"
+	flagStateFile    = "state-file"
+	flagOutput       = "output"
+	flagReportOnly   = "report-only"
+	flagMergeMethod  = "merge-method"
+	flagTrackTimeout = "track-timeout"
+)
+
 type flag struct {
 	DryRun   bool
 	Watch    bool
 	Grouping string
+
+	Bots          []string
+	Reviewers     []string
+	Labels        []string
+	ExcludeLabels []string
+	Repos         []string
+	ExcludeRepos  []string
+	MinAge        time.Duration
+
+	Profile      string
+	SaveProfile  string
+	ProfilesFile string
+
+	AutoApproveRisk string
+	MaxRisk         string
+
+	Resume    bool
+	StateFile string
+
+	Output     string
+	ReportOnly bool
+
+	MergeMethod  string
+	TrackTimeout time.Duration
 }
 
 func (f *flag) Init(cmd *cobra.Command) {
 	cmd.Flags().BoolVar(&f.DryRun, "dry-run", false, "Only show what would be done without making changes")
 	cmd.Flags().BoolVarP(&f.Watch, "watch", "w", false, "Keep running and watch for new PRs (poll every minute, exit with Ctrl+C)")
 	cmd.Flags().StringVar(&f.Grouping, "grouping", GroupingDependency, fmt.Sprintf("In interactive mode, group PRs by %q or %q", GroupingDependency, GroupingRepo))
+
+	cmd.Flags().StringSliceVar(&f.Bots, flagBot, nil, fmt.Sprintf("Bots whose PRs to process, any of <%s> (default %s)", strings.Join(pr.AllBots(), "|"), pr.BotRenovate))
+	cmd.Flags().StringSliceVar(&f.Reviewers, flagReviewer, nil, fmt.Sprintf("Reviewers the PRs request: %s, a user or an org/team (default %s)", pr.ReviewerMe, pr.ReviewerMe))
+	cmd.Flags().StringSliceVar(&f.Labels, flagLabel, nil, "Only process PRs carrying all of these labels")
+	cmd.Flags().StringSliceVar(&f.ExcludeLabels, flagExcludeLabel, nil, "Skip PRs carrying any of these labels")
+	cmd.Flags().StringSliceVar(&f.Repos, flagRepo, nil, "Only process PRs of these repositories (owner/name)")
+	cmd.Flags().StringSliceVar(&f.ExcludeRepos, flagExcludeRepo, nil, "Skip PRs of these repositories (owner/name)")
+	cmd.Flags().DurationVar(&f.MinAge, flagMinAge, 0, "Skip PRs opened less than this long ago, e.g. 24h")
+
+	cmd.Flags().StringVar(&f.AutoApproveRisk, flagAutoApprove, pr.RiskLow.String(), fmt.Sprintf("Highest risk of PRs approved without asking, one of <%s>", strings.Join(pr.AllRisks(), "|")))
+	cmd.Flags().StringVar(&f.MaxRisk, flagMaxRisk, pr.RiskMedium.String(), fmt.Sprintf("Highest risk of PRs approved after confirmation, riskier PRs are skipped, one of <%s>", strings.Join(pr.AllRisks(), "|")))
+
+	cmd.Flags().BoolVar(&f.Resume, flagResume, false, "Continue the previous run with its query and search, keeping the PRs it merged or skipped as they are and not asking again for the ones it approved")
+	cmd.Flags().StringVar(&f.StateFile, flagStateFile, "", fmt.Sprintf("File the actions of each run are logged to (default %s in the devctl config dir)", pr.StateFile))
+
+	cmd.Flags().StringVar(&f.MergeMethod, flagMergeMethod, "", fmt.Sprintf("Merge method for auto-merge and direct merges, one of <%s> (default the first the repository allows, in that order). Merge queues use their own", strings.Join(pr.AllMergeMethods(), "|")))
+	cmd.Flags().DurationVar(&f.TrackTimeout, flagTrackTimeout, 10*time.Minute, "How long to follow PRs handed to a merge queue or auto-merge until they merge, 0 to stop once they are queued")
+
+	cmd.Flags().StringVarP(&f.Output, flagOutput, "o", pr.OutputTable, fmt.Sprintf("Output format, one of <%s>. The json and markdown reports are written to stdout once the run ends, progress goes to stderr", strings.Join(pr.AllOutputs(), "|")))
+	cmd.Flags().BoolVar(&f.ReportOnly, flagReportOnly, false, "Only report the matching PRs with their group, checks, mergeability and what would be done, without approving, merging or asking anything")
+
+	cmd.Flags().StringVar(&f.Profile, flagProfile, "", "Load the search flags saved under this profile name, flags given on the command line win")
+	cmd.Flags().StringVar(&f.SaveProfile, flagSaveProfile, "", "Save the search flags under this profile name and exit")
+	cmd.Flags().StringVar(&f.ProfilesFile, flagProfilesFile, "", fmt.Sprintf("File the profiles are saved in (default %s in the devctl config dir)", pr.ProfilesFile))
 }
 
 func (f *flag) Validate() error {
@@ -30,5 +105,64 @@ func (f *flag) Validate() error {
 	default:
 		return microerror.Maskf(invalidFlagsError, "--grouping must be %q or %q, got %q", GroupingDependency, GroupingRepo, f.Grouping)
 	}
+	if err := f.Search().Validate(); err != nil {
+		return microerror.Maskf(invalidFlagsError, "%s", err)
+	}
+	policy, err := f.Policy()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	if policy.AutoApprove > policy.Max {
+		return microerror.Maskf(invalidFlagsError, "--%s %s must not exceed --%s %s", flagAutoApprove, policy.AutoApprove, flagMaxRisk, policy.Max)
+	}
+	if f.Resume && (f.Profile != "" || f.SaveProfile != "" || !reflect.DeepEqual(f.Search(), pr.Search{})) {
+		return microerror.Maskf(invalidFlagsError, "--%s reuses the search of the previous run and cannot be combined with search flags or profiles", flagResume)
+	}
+	if !slices.Contains(pr.AllOutputs(), f.Output) {
+		return microerror.Maskf(invalidFlagsError, "--%s must be one of <%s>, got %q", flagOutput, strings.Join(pr.AllOutputs(), "|"), f.Output)
+	}
+	if f.MergeMethod != "" && !slices.Contains(pr.AllMergeMethods(), f.MergeMethod) {
+		return microerror.Maskf(invalidFlagsError, "--%s must be one of <%s>, got %q", flagMergeMethod, strings.Join(pr.AllMergeMethods(), "|"), f.MergeMethod)
+	}
+	if f.TrackTimeout < 0 {
+		return microerror.Maskf(invalidFlagsError, "--%s must not be negative", flagTrackTimeout)
+	}
+	if f.Watch && f.ReportOnly {
+		return microerror.Maskf(invalidFlagsError, "--watch and --%s are mutually exclusive", flagReportOnly)
+	}
+	if f.Watch && f.Output != pr.OutputTable {
+		return microerror.Maskf(invalidFlagsError, "--watch never ends, so there is no report to write with --%s %s", flagOutput, f.Output)
+	}
+	if f.Profile != "" && f.SaveProfile != "" {
+		return microerror.Maskf(invalidFlagsError, "--%s and --%s are mutually exclusive", flagProfile, flagSaveProfile)
+	}
 	return nil
 }
+
+// Search returns the search the search flags describe, without a profile.
+func (f *flag) Search() pr.Search {
+	return pr.Search{
+		Bots:          f.Bots,
+		Reviewers:     f.Reviewers,
+		Labels:        f.Labels,
+		ExcludeLabels: f.ExcludeLabels,
+		Repos:         f.Repos,
+		ExcludeRepos:  f.ExcludeRepos,
+		MinAge:        f.MinAge,
+	}
+}
+
+// Policy returns the risk policy of the --auto-approve-risk and --max-risk
+// flags.
+func (f *flag) Policy() (pr.Policy, error) {
+	autoApprove, err := pr.ParseRisk(f.AutoApproveRisk)
+	if err != nil {
+		return pr.Policy{}, microerror.Maskf(invalidFlagsError, "--%s: %s", flagAutoApprove, err)
+	}
+	maxRisk, err := pr.ParseRisk(f.MaxRisk)
+	if err != nil {
+		return pr.Policy{}, microerror.Maskf(invalidFlagsError, "--%s: %s", flagMaxRisk, err)
+	}
+
+	return pr.Policy{AutoApprove: autoApprove, Max: maxRisk}, nil
+}
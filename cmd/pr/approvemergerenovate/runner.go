@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"strings"
 	"sync"
 	"syscall"
@@ -22,11 +23,36 @@ import (
 	"github.com/giantswarm/devctl/v8/pkg/githubclient"
 )
 
+// trackInterval is how often PRs handed to a merge queue or auto-merge are
+// looked at.
+const trackInterval = 15 * time.Second
+
 type runner struct {
 	flag   *flag
 	logger *logrus.Logger
 	stdout io.Writer
 	stderr io.Writer
+	// out receives the report of --output and --report-only. Non-table
+	// reports take stdout over, and stdout is pointed at stderr.
+	out io.Writer
+
+	// state logs the actions of the run, nil in dry runs.
+	state *pr.StateLog
+	// previous is the run --resume continues.
+	previous pr.RunState
+	// selectedGroup is the dependency group picked interactively, or resumed.
+	// The run only acts on its PRs.
+	selectedGroup string
+
+	// branches caches the head branches of PRs by URL, which grouping falls
+	// back to and search results lack.
+	branches   map[string]string
+	branchesMu sync.Mutex
+
+	// entries collects what the run learns about each PR for the report,
+	// by PR URL.
+	entries   map[string]*pr.ReportEntry
+	entriesMu sync.Mutex
 }
 
 func (r *runner) Run(cmd *cobra.Command, args []string) error {
@@ -34,10 +60,130 @@ func (r *runner) Run(cmd *cobra.Command, args []string) error {
 	if err := r.flag.Validate(); err != nil {
 		return microerror.Mask(err)
 	}
-	return r.run(ctx, cmd, args)
+	if r.flag.Resume && len(args) > 0 {
+		return microerror.Maskf(invalidFlagsError, "--%s reuses the query of the previous run and takes no query", flagResume)
+	}
+	if r.flag.Output != pr.OutputTable && !r.flag.ReportOnly && !r.flag.Resume && len(args) == 0 {
+		return microerror.Maskf(invalidFlagsError, "--%s %s needs a query or --%s, selecting a group interactively needs the terminal", flagOutput, r.flag.Output, flagResume)
+	}
+
+	r.out = r.stdout
+	if r.flag.Output != pr.OutputTable {
+		r.stdout = r.stderr
+	}
+
+	search, err := r.search()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if r.flag.Resume {
+		records, err := pr.ReadState(r.stateFile())
+		if err != nil {
+			return microerror.Maskf(executionFailedError, "%s", err)
+		}
+		previous, ok := pr.LastRun(records, longCmd)
+		if !ok {
+			return microerror.Maskf(executionFailedError, "no previous run to resume in %s", r.stateFile())
+		}
+		r.previous = previous
+		search = previous.Search
+	}
+
+	if r.flag.SaveProfile != "" {
+		err = pr.SaveProfile(r.profilesFile(), r.flag.SaveProfile, search)
+		if err != nil {
+			return microerror.Maskf(invalidFlagsError, "%s", err)
+		}
+		fmt.Fprintf(r.stdout, "Saved profile %q to %s.\n", r.flag.SaveProfile, r.profilesFile())
+		return nil
+	}
+
+	return r.run(ctx, cmd, args, search)
+}
+
+// search returns the search the flags describe, on top of the profile given
+// with --profile.
+func (r *runner) search() (pr.Search, error) {
+	var search pr.Search
+	if r.flag.Profile != "" {
+		var err error
+		search, err = pr.ReadProfile(r.profilesFile(), r.flag.Profile)
+		if err != nil {
+			return pr.Search{}, microerror.Maskf(invalidFlagsError, "%s", err)
+		}
+	}
+
+	return search.Merge(r.flag.Search()), nil
+}
+
+func (r *runner) stateFile() string {
+	if r.flag.StateFile != "" {
+		return r.flag.StateFile
+	}
+	return filepath.Join(env.ConfigDir.Val(), pr.StateFile)
+}
+
+// record logs action on the PR of ps to the state file. Dry runs are not
+// recorded.
+func (r *runner) record(ps *pr.PRStatus, action pr.Action, reason string) {
+	if r.state == nil {
+		return
+	}
+	err := r.state.Record(ps, action, reason)
+	if err != nil {
+		r.logger.Errorf("failed to record %s of %s: %v", action, ps.URL, err)
+	}
+}
+
+// recordRiskSkip records that the risk policy skipped the PR of ps, so a
+// resumed run with a more permissive policy takes it up again.
+func (r *runner) recordRiskSkip(ps *pr.PRStatus, a pr.Assessment) {
+	if r.state == nil {
+		return
+	}
+	err := r.state.RecordRiskSkip(ps, a)
+	if err != nil {
+		r.logger.Errorf("failed to record %s of %s: %v", pr.ActionSkipped, ps.URL, err)
+	}
 }
 
-func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) error {
+func (r *runner) profilesFile() string {
+	if r.flag.ProfilesFile != "" {
+		return r.flag.ProfilesFile
+	}
+	return filepath.Join(env.ConfigDir.Val(), pr.ProfilesFile)
+}
+
+// searchIssues runs the queries of search for the given terms and returns
+// the PRs they find, each once, in the repositories search allows.
+func (r *runner) searchIssues(ctx context.Context, githubClient *github.Client, search pr.Search, terms string) ([]*github.Issue, error) {
+	searchOpts := &github.SearchOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	var issues []*github.Issue
+	seen := map[string]bool{}
+	for _, query := range search.Queries(terms, time.Now()) {
+		results, _, err := githubClient.Search.Issues(ctx, query, searchOpts)
+		if err != nil {
+			return nil, microerror.Maskf(executionFailedError, "failed to search for PRs: %v", err)
+		}
+
+		for _, issue := range results.Issues {
+			owner, repoName, err := pr.ParseRepoFromURL(issue.GetHTMLURL())
+			if err != nil || seen[issue.GetHTMLURL()] || !search.AllowsRepo(owner, repoName) {
+				continue
+			}
+			seen[issue.GetHTMLURL()] = true
+			issues = append(issues, issue)
+		}
+	}
+
+	return issues, nil
+}
+
+func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string, search pr.Search) error {
 	// Set logger to only show errors to avoid cluttering the table UI
 	r.logger.SetLevel(logrus.ErrorLevel)
 
@@ -58,38 +204,60 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 	githubClient := ghClientService.GetUnderlyingClient(ctx)
 
 	var query string
-	if len(args) == 0 {
-		// Interactive mode: let user select from grouped PRs
-		selectedQuery, err := r.selectGroupInteractively(ctx, githubClient)
+	if r.flag.Resume {
+		query = r.previous.Query
+		r.selectedGroup = r.previous.Group
+		fmt.Fprintf(r.stdout, "Resuming run %s.\n\n", r.previous.Run)
+	} else if len(args) > 0 {
+		// Direct mode: use provided query
+		query = args[0]
+	} else if !r.flag.ReportOnly {
+		// Interactive mode: let user select from grouped PRs. Reports
+		// without a query cover every PR of the search instead.
+		selectedGroup, err := r.selectGroupInteractively(ctx, githubClient, search)
 		if err != nil {
 			return microerror.Mask(err)
 		}
-		query = selectedQuery
-	} else {
-		// Direct mode: use provided query
-		query = args[0]
+		if r.flag.Grouping == GroupingRepo {
+			// Narrow the search to the repository rather than adding a
+			// repo qualifier, which GitHub would OR with the --repo ones.
+			search.Repos = []string{selectedGroup.Name}
+		} else {
+			// The query only narrows the search, it is empty for groups
+			// named from the dependency table or the branch. filterGroup
+			// selects the PRs of the group.
+			query = selectedGroup.SearchQuery
+			r.selectedGroup = selectedGroup.Name
+		}
 	}
 
-	if r.flag.DryRun {
+	if r.flag.ReportOnly {
+		// Nothing is done, so nothing is recorded.
+	} else if r.flag.DryRun {
 		fmt.Fprintln(r.stdout, "🔍 DRY RUN MODE")
 		fmt.Fprintln(r.stdout, "")
+	} else {
+		r.state = pr.NewStateLog(r.stateFile(), longCmd, r.previous.Run)
+		err = r.state.Start(query, r.selectedGroup, search)
+		if err != nil {
+			return microerror.Maskf(executionFailedError, "failed to write state file: %v", err)
+		}
 	}
 
-	// Build search query
-	searchQuery := fmt.Sprintf("%s is:pr is:open archived:false review-requested:@me author:app/renovate", query)
-
-	searchOpts := &github.SearchOptions{
-		ListOptions: github.ListOptions{PerPage: 100},
-	}
-	searchResults, _, err := githubClient.Search.Issues(ctx, searchQuery, searchOpts)
+	issues, err := r.searchIssues(ctx, githubClient, search, query)
 	if err != nil {
-		return microerror.Maskf(executionFailedError, "failed to search for PRs: %v", err)
+		return microerror.Mask(err)
 	}
 
-	if searchResults.GetTotal() == 0 {
+	if r.flag.ReportOnly {
+		return r.reportOnly(ctx, githubClient, issues)
+	}
+	issues = r.filterGroup(ctx, githubClient, issues)
+
+	if len(issues) == 0 {
 		if !r.flag.Watch {
 			fmt.Fprintln(r.stdout, "No PRs found.")
-			return nil
+			return r.writeReport(nil)
 		}
 		// In watch mode, continue even if no PRs found initially
 		fmt.Fprintln(r.stdout, "No PRs found yet. Watching for new PRs...")
@@ -98,7 +266,7 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 
 	// Initialize PR statuses with mutex protection for concurrent updates
 	var prStatusesMu sync.Mutex
-	prStatuses := make([]*pr.PRStatus, 0, len(searchResults.Issues))
+	prStatuses := make([]*pr.PRStatus, 0, len(issues))
 	prNumbersMap := make(map[int]bool) // Track PR numbers to avoid duplicates
 
 	addPRs := func(issues []*github.Issue) []*pr.PRStatus {
@@ -122,7 +290,7 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 			title := issue.GetTitle()
 			displayLabel := repoName
 			if r.flag.Grouping == GroupingRepo {
-				displayLabel = pr.ExtractDependencyName(title)
+				displayLabel = pr.GroupName(&pr.PRInfo{Title: title, Body: issue.GetBody(), Branch: r.branch(issue.GetHTMLURL())})
 			}
 
 			ps := &pr.PRStatus{
@@ -131,6 +299,7 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 				Repo:         repoName,
 				Title:        title,
 				URL:          issue.GetHTMLURL(),
+				Body:         issue.GetBody(),
 				Status:       "Queued",
 				DisplayLabel: displayLabel,
 				LastUpdate:   time.Now(),
@@ -143,22 +312,32 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 	}
 
 	// Add initial PRs
-	initialPRs := addPRs(searchResults.Issues)
+	initialPRs := addPRs(issues)
 
-	columnHeader := "Repository"
-	if r.flag.Grouping == GroupingRepo {
-		columnHeader = "Dependency"
+	// Apply the risk policy, asking to confirm medium-risk PRs before the
+	// table takes over the terminal. Runs writing a report do not ask.
+	live := r.flag.Output == pr.OutputTable
+	approvable, err := r.applyPolicy(ctx, githubClient, initialPRs, live)
+	if err != nil {
+		return microerror.Mask(err)
 	}
-	pr.PrintTableHeader(r.stdout, columnHeader)
 
-	// Print initial empty rows for all PRs
-	for range initialPRs {
-		fmt.Fprintln(r.stdout, "")
+	if live {
+		columnHeader := "Repository"
+		if r.flag.Grouping == GroupingRepo {
+			columnHeader = "Dependency"
+		}
+		pr.PrintTableHeader(r.stdout, columnHeader)
+
+		// Print initial empty rows for all PRs
+		for range initialPRs {
+			fmt.Fprintln(r.stdout, "")
+		}
 	}
 
 	// Start processing initial PRs in parallel
 	var wg sync.WaitGroup
-	for _, ps := range initialPRs {
+	for _, ps := range approvable {
 		wg.Add(1)
 		go func(ps *pr.PRStatus) {
 			defer wg.Done()
@@ -184,23 +363,33 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 				return
 			case <-ticker.C:
 				// Re-run the search query
-				newResults, _, err := githubClient.Search.Issues(ctx, searchQuery, searchOpts)
+				newIssues, err := r.searchIssues(ctx, githubClient, search, query)
 				if err != nil {
 					continue
 				}
+				newIssues = r.filterGroup(ctx, githubClient, newIssues)
 
 				// Add any new PRs found
-				newPRs := addPRs(newResults.Issues)
+				newPRs := addPRs(newIssues)
 				if len(newPRs) > 0 {
 					// Add empty rows for new PRs
 					prStatusesMu.Lock()
 					for range newPRs {
-						fmt.Fprintln(r.stdout, "")
+						if live {
+							fmt.Fprintln(r.stdout, "")
+						}
 					}
 					prStatusesMu.Unlock()
 
+					// PRs found while the table is shown cannot be
+					// confirmed, so only low enough risks get through.
+					approvable, err := r.applyPolicy(ctx, githubClient, newPRs, false)
+					if err != nil {
+						continue
+					}
+
 					// Start processing new PRs
-					for _, ps := range newPRs {
+					for _, ps := range approvable {
 						wg.Add(1)
 						go func(ps *pr.PRStatus) {
 							defer wg.Done()
@@ -245,16 +434,9 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 
 			// Final update
 			prStatusesMu.Lock()
-			pr.UpdateTable(r.stdout, prStatuses)
-			prStatusesMu.Unlock()
-
-			fmt.Fprintln(r.stdout, "")
-
-			prStatusesMu.Lock()
-			r.printSummary(prStatuses)
-			prStatusesMu.Unlock()
+			defer prStatusesMu.Unlock()
 
-			return nil
+			return r.finish(prStatuses)
 
 		case <-done:
 			// All current PRs processed (only happens in non-watch mode)
@@ -263,18 +445,14 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 
 			// Final update
 			prStatusesMu.Lock()
-			pr.UpdateTable(r.stdout, prStatuses)
-			prStatusesMu.Unlock()
-
-			fmt.Fprintln(r.stdout, "")
-
-			prStatusesMu.Lock()
-			r.printSummary(prStatuses)
-			prStatusesMu.Unlock()
+			defer prStatusesMu.Unlock()
 
-			return nil
+			return r.finish(prStatuses)
 
 		case <-ticker.C:
+			if !live {
+				continue
+			}
 			prStatusesMu.Lock()
 			pr.UpdateTable(r.stdout, prStatuses)
 			prStatusesMu.Unlock()
@@ -282,27 +460,203 @@ func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) err
 	}
 }
 
-func (r *runner) selectGroupInteractively(ctx context.Context, githubClient *github.Client) (string, error) {
-	fmt.Fprintln(r.stdout, "Fetching Renovate PRs...")
+// finish shows the final state of prStatuses with the summary, and writes
+// the report of --output.
+func (r *runner) finish(prStatuses []*pr.PRStatus) error {
+	if r.flag.Output == pr.OutputTable {
+		pr.UpdateTable(r.stdout, prStatuses)
+		fmt.Fprintln(r.stdout, "")
+	}
 
-	// Search for all Renovate PRs requesting review from the user
-	searchQuery := "is:pr is:open archived:false review-requested:@me author:app/renovate"
-	searchOpts := &github.SearchOptions{
-		ListOptions: github.ListOptions{PerPage: 100},
+	r.printSummary(prStatuses)
+
+	return r.writeReport(prStatuses)
+}
+
+// note updates what the report says about the PR at url.
+func (r *runner) note(url string, update func(e *pr.ReportEntry)) {
+	r.entriesMu.Lock()
+	defer r.entriesMu.Unlock()
+
+	if r.entries == nil {
+		r.entries = map[string]*pr.ReportEntry{}
+	}
+	e, ok := r.entries[url]
+	if !ok {
+		e = &pr.ReportEntry{}
+		r.entries[url] = e
+	}
+	update(e)
+}
+
+// writeReport writes the report of --output on prStatuses, with what the
+// run noted about them. The table needs no report, it is already shown.
+func (r *runner) writeReport(prStatuses []*pr.PRStatus) error {
+	if r.flag.Output == pr.OutputTable {
+		return nil
+	}
+
+	infos := make([]*pr.PRInfo, 0, len(prStatuses))
+	statuses := map[string]string{}
+	for _, ps := range prStatuses {
+		infos = append(infos, &pr.PRInfo{Number: ps.Number, Owner: ps.Owner, Repo: ps.Repo, Title: ps.Title, URL: ps.URL, Body: ps.Body, Branch: r.branch(ps.URL)})
+		statuses[ps.URL] = ps.GetStatus()
+	}
+
+	entries := r.reportEntries(infos)
+	r.entriesMu.Lock()
+	for i := range entries {
+		e := &entries[i]
+		if noted, ok := r.entries[e.URL]; ok {
+			e.Checks = noted.Checks
+			e.Mergeable = noted.Mergeable
+			e.Approved = noted.Approved
+			e.AutoMerge = noted.AutoMerge
+			e.Merge = noted.Merge
+			e.Update = noted.Update
+			e.Risk = noted.Risk
+			e.Reasons = noted.Reasons
+		}
+		e.Status = statuses[e.URL]
 	}
+	r.entriesMu.Unlock()
 
-	searchResults, _, err := githubClient.Search.Issues(ctx, searchQuery, searchOpts)
+	return r.report(entries, false)
+}
+
+// reportOnly inspects the PRs of issues and reports what a run would do with
+// them, without doing it.
+func (r *runner) reportOnly(ctx context.Context, githubClient *github.Client, issues []*github.Issue) error {
+	policy, err := r.flag.Policy()
 	if err != nil {
-		return "", microerror.Maskf(executionFailedError, "failed to search for PRs: %v", err)
+		return microerror.Mask(err)
+	}
+
+	fmt.Fprintf(r.stdout, "Inspecting %d PRs...\n", len(issues))
+
+	// Inspect first, grouping uses the branches of the PRs.
+	infos := issueInfos(issues)
+	inspected := make([]pr.ReportEntry, len(infos))
+	var wg sync.WaitGroup
+	for i, info := range infos {
+		inspected[i] = pr.ReportEntry{Owner: info.Owner, Repo: info.Repo, Number: info.Number, Title: info.Title, URL: info.URL}
+		wg.Add(1)
+		go func(e *pr.ReportEntry) {
+			defer wg.Done()
+			r.inspect(ctx, githubClient, e, policy)
+		}(&inspected[i])
+	}
+	wg.Wait()
+
+	byURL := map[string]pr.ReportEntry{}
+	for i, e := range inspected {
+		infos[i].Branch = e.Branch
+		byURL[e.URL] = e
+	}
+	entries := r.reportEntries(infos)
+	for i := range entries {
+		group := entries[i].Group
+		entries[i] = byURL[entries[i].URL]
+		entries[i].Group = group
+	}
+
+	if r.flag.Output == pr.OutputTable {
+		fmt.Fprintln(r.stdout, "")
 	}
 
-	if searchResults.GetTotal() == 0 {
-		return "", microerror.Maskf(executionFailedError, "no Renovate PRs found requesting your review")
+	return r.report(entries, true)
+}
+
+// inspect fills e in with the state of its PR and the action a run would
+// take on it.
+func (r *runner) inspect(ctx context.Context, githubClient *github.Client, e *pr.ReportEntry, policy pr.Policy) {
+	prData, _, err := githubClient.PullRequests.Get(ctx, e.Owner, e.Repo, e.Number)
+	if err != nil {
+		r.logger.Errorf("failed to get %s: %v", e.URL, err)
+		e.Action = "skip: failed to get PR"
+		return
 	}
+	e.Branch = prData.GetHead().GetRef()
+	e.Mergeable = prData.GetMergeableState()
+	e.AutoMerge = prData.GetAutoMerge() != nil
+
+	mode, _, failure := r.mergeMode(ctx, githubClient, e.Owner, e.Repo, prData)
+	if failure != "" {
+		e.Action = "skip: " + strings.ToLower(failure)
+		return
+	}
+	e.Merge = mode
+
+	headSHA := prData.GetHead().GetSHA()
+	combinedStatus, _, _ := githubClient.Repositories.GetCombinedStatus(ctx, e.Owner, e.Repo, headSHA, nil)
+	checkRuns, _, _ := githubClient.Checks.ListCheckRunsForRef(ctx, e.Owner, e.Repo, headSHA, nil)
+	e.Checks = pr.ChecksState(combinedStatus, checkRuns)
+
+	reviews, _, err := githubClient.PullRequests.ListReviews(ctx, e.Owner, e.Repo, e.Number, nil)
+	if err != nil {
+		r.logger.Errorf("failed to get reviews of %s: %v", e.URL, err)
+	}
+	for _, review := range reviews {
+		if review.GetState() == "APPROVED" {
+			e.Approved = true
+			break
+		}
+	}
+
+	a := pr.Assess(e.Title, prData.GetBody(), r.changedFiles(ctx, githubClient, e.Owner, e.Repo, e.Number))
+	e.SetAssessment(a)
+	e.Action = pr.PlannedAction(*e, policy.Decide(a))
+}
+
+// report writes entries as the report of --output.
+func (r *runner) report(entries []pr.ReportEntry, reportOnly bool) error {
+	report := pr.Report{
+		Command:    longCmd,
+		Generated:  time.Now(),
+		ReportOnly: reportOnly,
+		PRs:        entries,
+	}
+
+	err := pr.WriteReport(r.out, report, r.flag.Output)
+	if err != nil {
+		return microerror.Maskf(executionFailedError, "failed to write report: %v", err)
+	}
+
+	return nil
+}
+
+// reportEntries returns the report entries of infos, by group in the order
+// of the interactive selection.
+func (r *runner) reportEntries(infos []*pr.PRInfo) []pr.ReportEntry {
+	entries := make([]pr.ReportEntry, 0, len(infos))
+	for _, g := range r.group(infos) {
+		for _, p := range g.PRs {
+			entries = append(entries, pr.ReportEntry{
+				Group:  g.Name,
+				Owner:  p.Owner,
+				Repo:   p.Repo,
+				Number: p.Number,
+				Title:  p.Title,
+				URL:    p.URL,
+			})
+		}
+	}
+
+	return entries
+}
 
-	// Convert GitHub issues to PRInfo
+// group groups PRs by dependency or repository, as --grouping says.
+func (r *runner) group(infos []*pr.PRInfo) []*pr.PRGroup {
+	if r.flag.Grouping == GroupingRepo {
+		return pr.GroupRenovatePRsByRepo(infos)
+	}
+	return pr.GroupRenovatePRs(infos)
+}
+
+// issueInfos converts GitHub issues to PRInfo.
+func issueInfos(issues []*github.Issue) []*pr.PRInfo {
 	var prInfos []*pr.PRInfo
-	for _, issue := range searchResults.Issues {
+	for _, issue := range issues {
 		owner, repoName, err := pr.ParseRepoFromURL(issue.GetHTMLURL())
 		if err != nil {
 			continue
@@ -314,19 +668,101 @@ func (r *runner) selectGroupInteractively(ctx context.Context, githubClient *git
 			Repo:   repoName,
 			Title:  issue.GetTitle(),
 			URL:    issue.GetHTMLURL(),
+			Body:   issue.GetBody(),
 		})
 	}
 
-	// Group PRs by dependency or repository
-	var groups []*pr.PRGroup
-	if r.flag.Grouping == GroupingRepo {
-		groups = pr.GroupRenovatePRsByRepo(prInfos)
-	} else {
-		groups = pr.GroupRenovatePRs(prInfos)
+	return prInfos
+}
+
+// filterGroup looks up the head branches of the PRs of issues and returns
+// the PRs in the group picked interactively, or all of them without one. The
+// quoted group name the search runs with also finds PRs of other groups, e.g.
+// ones mentioning the dependency in their body.
+func (r *runner) filterGroup(ctx context.Context, githubClient *github.Client, issues []*github.Issue) []*github.Issue {
+	infos := issueInfos(issues)
+	r.lookUpBranches(ctx, githubClient, infos)
+	if r.selectedGroup == "" {
+		return issues
+	}
+
+	inGroup := map[string]bool{}
+	for _, info := range infos {
+		inGroup[info.URL] = pr.GroupName(info) == r.selectedGroup
+	}
+
+	var filtered []*github.Issue
+	for _, issue := range issues {
+		if inGroup[issue.GetHTMLURL()] {
+			filtered = append(filtered, issue)
+		}
+	}
+
+	return filtered
+}
+
+// lookUpBranches sets the head branches of infos, which search results lack.
+// Branches that cannot be looked up stay empty, grouping then goes by the
+// title.
+func (r *runner) lookUpBranches(ctx context.Context, githubClient *github.Client, infos []*pr.PRInfo) {
+	var wg sync.WaitGroup
+	for _, info := range infos {
+		r.branchesMu.Lock()
+		branch, ok := r.branches[info.URL]
+		r.branchesMu.Unlock()
+		if ok {
+			info.Branch = branch
+			continue
+		}
+
+		wg.Add(1)
+		go func(info *pr.PRInfo) {
+			defer wg.Done()
+			prData, _, err := githubClient.PullRequests.Get(ctx, info.Owner, info.Repo, info.Number)
+			if err != nil {
+				r.logger.Errorf("failed to get %s: %v", info.URL, err)
+				return
+			}
+			info.Branch = prData.GetHead().GetRef()
+
+			r.branchesMu.Lock()
+			defer r.branchesMu.Unlock()
+			if r.branches == nil {
+				r.branches = map[string]string{}
+			}
+			r.branches[info.URL] = info.Branch
+		}(info)
+	}
+	wg.Wait()
+}
+
+// branch returns the head branch of the PR at url looked up before, empty if
+// it was not.
+func (r *runner) branch(url string) string {
+	r.branchesMu.Lock()
+	defer r.branchesMu.Unlock()
+	return r.branches[url]
+}
+
+func (r *runner) selectGroupInteractively(ctx context.Context, githubClient *github.Client, search pr.Search) (*pr.PRGroup, error) {
+	fmt.Fprintln(r.stdout, "Fetching PRs...")
+
+	// Search for all PRs the search flags match
+	issues, err := r.searchIssues(ctx, githubClient, search, "")
+	if err != nil {
+		return nil, microerror.Mask(err)
 	}
 
+	if len(issues) == 0 {
+		return nil, microerror.Maskf(executionFailedError, "no PRs found matching the search")
+	}
+
+	prInfos := issueInfos(issues)
+	r.lookUpBranches(ctx, githubClient, prInfos)
+	groups := r.group(prInfos)
+
 	if len(groups) == 0 {
-		return "", microerror.Maskf(executionFailedError, "no PR groups found")
+		return nil, microerror.Maskf(executionFailedError, "no PR groups found")
 	}
 
 	fmt.Fprintf(r.stdout, "Found %d PRs in %d groups.\n\n", len(prInfos), len(groups))
@@ -354,18 +790,307 @@ func (r *runner) selectGroupInteractively(ctx context.Context, githubClient *git
 	idx, _, err := prompt.Run()
 	if err != nil {
 		if err == promptui.ErrInterrupt {
-			return "", microerror.Maskf(executionFailedError, "selection cancelled by user")
+			return nil, microerror.Maskf(executionFailedError, "selection cancelled by user")
 		}
-		return "", microerror.Maskf(executionFailedError, "selection failed: %v", err)
+		return nil, microerror.Maskf(executionFailedError, "selection failed: %v", err)
 	}
 
-	selectedGroup := groups[idx]
 	fmt.Fprintln(r.stdout, "")
 
-	return selectedGroup.SearchQuery, nil
+	return groups[idx], nil
+}
+
+// applyPolicy assesses the risk of prs and returns those the policy lets
+// through. The others get the reason they are skipped as status. PRs the
+// policy wants confirmed are listed and confirmed together if confirm is
+// set, and skipped otherwise.
+func (r *runner) applyPolicy(ctx context.Context, githubClient *github.Client, prs []*pr.PRStatus, confirm bool) ([]*pr.PRStatus, error) {
+	policy, err := r.flag.Policy()
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	// PRs the resumed run finished stay as they were, unless it skipped them
+	// for a risk the policy now allows, and PRs it approved were confirmed
+	// already.
+	var approvable, pending []*pr.PRStatus
+	for _, ps := range prs {
+		previous, ok := r.previous.Last[ps.URL]
+		switch {
+		case ok && previous.Action.Done() && !previous.Reconsider(policy):
+			ps.UpdateStatus(previousStatus(previous))
+		case ok && (previous.Action == pr.ActionApproved || previous.Action == pr.ActionMergeQueued):
+			ps.UpdateStatus("Queued")
+			approvable = append(approvable, ps)
+		default:
+			pending = append(pending, ps)
+		}
+	}
+	prs = pending
+
+	assessments := make([]pr.Assessment, len(prs))
+	var wg sync.WaitGroup
+	for i, ps := range prs {
+		wg.Add(1)
+		go func(i int, ps *pr.PRStatus) {
+			defer wg.Done()
+			ps.UpdateStatus("Assessing...")
+			assessments[i] = pr.Assess(ps.Title, ps.Body, r.changedFiles(ctx, githubClient, ps.Owner, ps.Repo, ps.Number))
+			r.note(ps.URL, func(e *pr.ReportEntry) { e.SetAssessment(assessments[i]) })
+		}(i, ps)
+	}
+	wg.Wait()
+
+	var toConfirm []*pr.PRStatus
+	var confirmReasons []pr.Assessment
+	for i, ps := range prs {
+		a := assessments[i]
+		switch policy.Decide(a) {
+		case pr.DecisionApprove:
+			ps.UpdateStatus("Queued")
+			approvable = append(approvable, ps)
+		case pr.DecisionConfirm:
+			switch {
+			case r.flag.DryRun:
+				ps.UpdateStatus("Would ask: " + strings.Join(a.Reasons, ", "))
+			case !confirm:
+				// Not recorded, a resumed run asks again.
+				ps.UpdateStatus("Skipped: needs confirmation")
+			default:
+				ps.UpdateStatus("Queued")
+				toConfirm = append(toConfirm, ps)
+				confirmReasons = append(confirmReasons, a)
+			}
+		case pr.DecisionSkip:
+			ps.UpdateStatus("Skipped: " + strings.Join(a.Reasons, ", "))
+			r.recordRiskSkip(ps, a)
+		}
+	}
+
+	if len(toConfirm) == 0 {
+		return approvable, nil
+	}
+
+	fmt.Fprintf(r.stdout, "%d PRs need confirmation:\n", len(toConfirm))
+	for i, ps := range toConfirm {
+		fmt.Fprintf(r.stdout, "  #%-5d %-40s %s\n", ps.Number, ps.Owner+"/"+ps.Repo, confirmReasons[i])
+	}
+
+	prompt := promptui.Prompt{
+		Label:     fmt.Sprintf("Approve these %d PRs above the %s risk auto-approval threshold", len(toConfirm), policy.AutoApprove),
+		IsConfirm: true,
+	}
+	_, err = prompt.Run()
+	fmt.Fprintln(r.stdout, "")
+	if err == promptui.ErrInterrupt {
+		return nil, microerror.Maskf(executionFailedError, "confirmation cancelled by user")
+	} else if err != nil {
+		for _, ps := range toConfirm {
+			ps.UpdateStatus("Skipped: not confirmed")
+			r.record(ps, pr.ActionSkipped, "not confirmed")
+		}
+		return approvable, nil
+	}
+
+	return append(approvable, toConfirm...), nil
+}
+
+// previousStatus is the status of a PR the resumed run finished.
+func previousStatus(previous pr.Record) string {
+	switch previous.Action {
+	case pr.ActionMerged:
+		return "Merged (previous run)"
+	default:
+		return "Skipped: " + previous.Reason + " (previous run)"
+	}
+}
+
+// changedFiles returns the files the PR changes. When they cannot be listed
+// the PR is assessed by its title and body alone.
+func (r *runner) changedFiles(ctx context.Context, githubClient *github.Client, owner, repo string, number int) []pr.ChangedFile {
+	var files []pr.ChangedFile
+
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		page, resp, err := githubClient.PullRequests.ListFiles(ctx, owner, repo, number, opts)
+		if err != nil {
+			r.logger.Errorf("failed to list files of %s/%s#%d: %v", owner, repo, number, err)
+			return files
+		}
+		for _, f := range page {
+			files = append(files, pr.ChangedFile{Path: f.GetFilename(), Patch: f.GetPatch()})
+		}
+		if resp.NextPage == 0 {
+			return files
+		}
+		opts.Page = resp.NextPage
+	}
 }
 
 func (r *runner) processPR(ctx context.Context, githubClient *github.Client, ps *pr.PRStatus) {
+	ps.UpdateStatus("Checking...")
+	prData, _, err := githubClient.PullRequests.Get(ctx, ps.Owner, ps.Repo, ps.Number)
+	if err != nil {
+		ps.UpdateStatus("Failed to get PR")
+		r.record(ps, pr.ActionFailed, "Failed to get PR")
+		return
+	}
+
+	if prData.GetMerged() {
+		ps.UpdateStatus("Already merged")
+		r.record(ps, pr.ActionMerged, "")
+		return
+	}
+
+	mode, mergeMethod, failure := r.mergeMode(ctx, githubClient, ps.Owner, ps.Repo, prData)
+	if failure != "" {
+		ps.UpdateStatus(failure)
+		r.record(ps, pr.ActionFailed, failure)
+		return
+	}
+	r.note(ps.URL, func(e *pr.ReportEntry) { e.Merge = mode })
+
+	if mode == pr.MergeModeDirect {
+		r.mergeDirectly(ctx, githubClient, ps, mergeMethod)
+		return
+	}
+
+	r.queueForMerge(ctx, githubClient, ps, prData, mode, mergeMethod)
+}
+
+// mergeMode returns how the PR of prData in owner/repo gets merged and with
+// which merge method, or the status to fail the PR with.
+func (r *runner) mergeMode(ctx context.Context, githubClient *github.Client, owner, repoName string, prData *github.PullRequest) (pr.MergeMode, string, string) {
+	repo, _, err := githubClient.Repositories.Get(ctx, owner, repoName)
+	if err != nil {
+		return "", "", "Failed to get repo"
+	}
+
+	mergeMethod, err := pr.MergeMethod(repo, r.flag.MergeMethod)
+	if err != nil {
+		r.logger.Errorf("%v", err)
+		if r.flag.MergeMethod != "" {
+			return "", "", "Merge method not allowed"
+		}
+		return "", "", "No merge methods"
+	}
+
+	mode, err := pr.ResolveMergeMode(ctx, githubClient, repo, prData.GetBase().GetRef(), prData.GetAutoMerge() != nil)
+	if err != nil {
+		// Without the GraphQL API, merge like before merge queues.
+		r.logger.Errorf("%v", err)
+		mode = pr.MergeModeDirect
+	}
+
+	return mode, mergeMethod, ""
+}
+
+// queueForMerge approves the PR of ps and hands it over to the merge queue
+// or auto-merge, as mode says, without waiting for its checks. GitHub merges
+// it once they pass, and the PR is followed for --track-timeout.
+func (r *runner) queueForMerge(ctx context.Context, githubClient *github.Client, ps *pr.PRStatus, prData *github.PullRequest, mode pr.MergeMode, mergeMethod string) {
+	headSHA := prData.GetHead().GetSHA()
+	combinedStatus, _, _ := githubClient.Repositories.GetCombinedStatus(ctx, ps.Owner, ps.Repo, headSHA, nil)
+	checkRuns, _, _ := githubClient.Checks.ListCheckRunsForRef(ctx, ps.Owner, ps.Repo, headSHA, nil)
+
+	checks := pr.ChecksState(combinedStatus, checkRuns)
+	hasAutoMerge := prData.GetAutoMerge() != nil
+	r.note(ps.URL, func(e *pr.ReportEntry) {
+		e.Checks = checks
+		e.Mergeable = prData.GetMergeableState()
+		e.AutoMerge = hasAutoMerge
+	})
+
+	if checks == pr.ChecksFailing {
+		ps.UpdateStatus("Failed checks")
+		r.record(ps, pr.ActionFailed, "Failed checks")
+		return
+	}
+
+	// Neither a merge queue nor auto-merge merges a conflicting PR, so it is
+	// not approved either, as the report plans.
+	if prData.GetMergeableState() == "dirty" {
+		ps.UpdateStatus("Merge conflicts")
+		r.record(ps, pr.ActionFailed, "Merge conflicts")
+		return
+	}
+
+	reviews, _, err := githubClient.PullRequests.ListReviews(ctx, ps.Owner, ps.Repo, ps.Number, nil)
+	if err != nil {
+		ps.UpdateStatus("Failed to get reviews")
+		r.record(ps, pr.ActionFailed, "Failed to get reviews")
+		return
+	}
+
+	alreadyApproved := false
+	for _, review := range reviews {
+		if review.GetState() == "APPROVED" {
+			alreadyApproved = true
+			break
+		}
+	}
+	r.note(ps.URL, func(e *pr.ReportEntry) { e.Approved = alreadyApproved })
+
+	if r.flag.DryRun {
+		what := "enqueue"
+		switch {
+		case hasAutoMerge:
+			what = "leave to auto-merge"
+		case mode == pr.MergeModeAutoMerge:
+			what = fmt.Sprintf("enable auto-merge (%s)", mergeMethod)
+		}
+		if !alreadyApproved {
+			what = "approve & " + what
+		}
+		ps.UpdateStatus("Would " + what)
+		return
+	}
+
+	if !alreadyApproved {
+		ps.UpdateStatus("Approving...")
+		reviewRequest := &github.PullRequestReviewRequest{
+			Event: github.String("APPROVE"),
+		}
+		_, _, err = githubClient.PullRequests.CreateReview(ctx, ps.Owner, ps.Repo, ps.Number, reviewRequest)
+		if err != nil {
+			ps.UpdateStatus("Failed to approve")
+			r.record(ps, pr.ActionFailed, "Failed to approve")
+			return
+		}
+		r.record(ps, pr.ActionApproved, "")
+		r.note(ps.URL, func(e *pr.ReportEntry) { e.Approved = true })
+	}
+
+	ps.UpdateStatus("Queueing...")
+	position, err := pr.QueueForMerge(ctx, githubClient, prData, mode, mergeMethod, checks)
+	if err != nil {
+		if mode == pr.MergeModeAutoMerge && checks == pr.ChecksPassing {
+			// GitHub refuses auto-merge on PRs it could merge right away.
+			r.mergeDirectly(ctx, githubClient, ps, mergeMethod)
+			return
+		}
+		r.logger.Errorf("failed to queue %s: %v", ps.URL, err)
+		ps.UpdateStatus("Failed to queue")
+		r.record(ps, pr.ActionFailed, "Failed to queue")
+		return
+	}
+
+	if position > 0 {
+		ps.UpdateStatus(fmt.Sprintf("Queued to merge (merge queue #%d)", position))
+	} else {
+		ps.UpdateStatus("Queued to merge (auto-merge)")
+	}
+	r.record(ps, pr.ActionMergeQueued, "")
+
+	action, reason := pr.WaitForMerge(ctx, githubClient, ps, r.flag.TrackTimeout, trackInterval)
+	if action != pr.ActionMergeQueued {
+		r.record(ps, action, reason)
+	}
+}
+
+// mergeDirectly waits for the checks of the PR of ps, approves it and merges
+// it with mergeMethod, for repositories without merge queue or auto-merge.
+func (r *runner) mergeDirectly(ctx context.Context, githubClient *github.Client, ps *pr.PRStatus, mergeMethod string) {
 	maxRetries := 60 // Poll for up to 5 minutes (60 * 5 seconds)
 	retryDelay := 5 * time.Second
 
@@ -376,74 +1101,40 @@ func (r *runner) processPR(ctx context.Context, githubClient *github.Client, ps
 
 		// Get PR details
 		ps.UpdateStatus("Checking...")
-		pr, _, err := githubClient.PullRequests.Get(ctx, ps.Owner, ps.Repo, ps.Number)
+		prData, _, err := githubClient.PullRequests.Get(ctx, ps.Owner, ps.Repo, ps.Number)
 		if err != nil {
 			ps.UpdateStatus("Failed to get PR")
+			r.record(ps, pr.ActionFailed, "Failed to get PR")
 			return
 		}
 
 		// Check if already merged
-		if pr.GetMerged() {
+		if prData.GetMerged() {
 			ps.UpdateStatus("Already merged")
+			r.record(ps, pr.ActionMerged, "")
 			return
 		}
 
 		// Check status checks BEFORE checking auto-merge
 		// This way we report failed checks even if auto-merge is enabled
-		headSHA := pr.GetHead().GetSHA()
+		headSHA := prData.GetHead().GetSHA()
 		combinedStatus, _, _ := githubClient.Repositories.GetCombinedStatus(ctx, ps.Owner, ps.Repo, headSHA, nil)
 		checkRuns, _, _ := githubClient.Checks.ListCheckRunsForRef(ctx, ps.Owner, ps.Repo, headSHA, nil)
 
-		// Check if any checks are failing or pending
-		hasFailedChecks := false
-		checksPending := false
-
-		// Check combined status first - this is for traditional status checks
-		if combinedStatus != nil {
-			state := combinedStatus.GetState()
-			totalCount := combinedStatus.GetTotalCount()
-
-			if state == "success" {
-				// All required checks passed
-				checksPending = false
-				hasFailedChecks = false
-			} else if state == "failure" || state == "error" {
-				hasFailedChecks = true
-			} else if state == "pending" && totalCount > 0 {
-				// Only treat as pending if there are actual status checks
-				// pending with totalCount=0 just means no checks exist, not that checks are waiting
-				checksPending = true
-			}
-		}
-
-		// Check individual check runs (GitHub Actions checks)
-		if checkRuns != nil && len(checkRuns.CheckRuns) > 0 && !hasFailedChecks {
-			// Only check runs if combinedStatus didn't already give us a definitive answer
-			if combinedStatus == nil || (combinedStatus.GetState() != "success" && combinedStatus.GetState() != "failure") {
-				for _, run := range checkRuns.CheckRuns {
-					conclusion := run.GetConclusion()
-					status := run.GetStatus()
-
-					if status == "completed" {
-						if conclusion == "failure" || conclusion == "cancelled" || conclusion == "timed_out" {
-							hasFailedChecks = true
-							break
-						}
-						// success, neutral, skipped are OK
-					} else {
-						// Check is not completed (queued, in_progress)
-						checksPending = true
-					}
-				}
-			}
-		}
+		checks := pr.ChecksState(combinedStatus, checkRuns)
+		r.note(ps.URL, func(e *pr.ReportEntry) {
+			e.Checks = checks
+			e.Mergeable = prData.GetMergeableState()
+			e.AutoMerge = prData.GetAutoMerge() != nil
+		})
 
-		if hasFailedChecks {
+		if checks == pr.ChecksFailing {
 			ps.UpdateStatus("Failed checks")
+			r.record(ps, pr.ActionFailed, "Failed checks")
 			return
 		}
 
-		if checksPending {
+		if checks == pr.ChecksPending {
 			ps.UpdateStatus(fmt.Sprintf("Waiting for checks (%d/%d)", attempt+1, maxRetries))
 			continue
 		}
@@ -453,6 +1144,7 @@ func (r *runner) processPR(ctx context.Context, githubClient *github.Client, ps
 		reviews, _, err := githubClient.PullRequests.ListReviews(ctx, ps.Owner, ps.Repo, ps.Number, nil)
 		if err != nil {
 			ps.UpdateStatus("Failed to get reviews")
+			r.record(ps, pr.ActionFailed, "Failed to get reviews")
 			return
 		}
 
@@ -464,7 +1156,8 @@ func (r *runner) processPR(ctx context.Context, githubClient *github.Client, ps
 			}
 		}
 
-		hasAutoMerge := pr.GetAutoMerge() != nil
+		hasAutoMerge := prData.GetAutoMerge() != nil
+		r.note(ps.URL, func(e *pr.ReportEntry) { e.Approved = alreadyApproved })
 
 		if !alreadyApproved {
 			if r.flag.DryRun {
@@ -481,8 +1174,11 @@ func (r *runner) processPR(ctx context.Context, githubClient *github.Client, ps
 				_, _, err = githubClient.PullRequests.CreateReview(ctx, ps.Owner, ps.Repo, ps.Number, reviewRequest)
 				if err != nil {
 					ps.UpdateStatus("Failed to approve")
+					r.record(ps, pr.ActionFailed, "Failed to approve")
 					return
 				}
+				r.record(ps, pr.ActionApproved, "")
+				r.note(ps.URL, func(e *pr.ReportEntry) { e.Approved = true })
 
 				if hasAutoMerge {
 					// Approve and let auto-merge/merge queue handle it
@@ -493,11 +1189,13 @@ func (r *runner) processPR(ctx context.Context, githubClient *github.Client, ps
 					prCheck, _, err := githubClient.PullRequests.Get(ctx, ps.Owner, ps.Repo, ps.Number)
 					if err == nil && prCheck.GetMerged() {
 						ps.UpdateStatus("Merged (auto-merge)")
+						r.record(ps, pr.ActionMerged, "")
 						return
 					}
 
 					// Not merged yet - likely in merge queue or waiting for other reasons
 					ps.UpdateStatus("Queued to merge")
+					r.record(ps, pr.ActionMergeQueued, "")
 					return
 				}
 				ps.UpdateStatus("Approved")
@@ -507,11 +1205,13 @@ func (r *runner) processPR(ctx context.Context, githubClient *github.Client, ps
 			prCheck, _, err := githubClient.PullRequests.Get(ctx, ps.Owner, ps.Repo, ps.Number)
 			if err == nil && prCheck.GetMerged() {
 				ps.UpdateStatus("Merged (auto-merge)")
+				r.record(ps, pr.ActionMerged, "")
 				return
 			}
 
 			// Not merged yet - queued
 			ps.UpdateStatus("Queued to merge")
+			r.record(ps, pr.ActionMergeQueued, "")
 			return
 		}
 
@@ -523,26 +1223,6 @@ func (r *runner) processPR(ctx context.Context, githubClient *github.Client, ps
 			return
 		}
 
-		// Get repository details for merge method
-		repo, _, err := githubClient.Repositories.Get(ctx, ps.Owner, ps.Repo)
-		if err != nil {
-			ps.UpdateStatus("Failed to get repo")
-			return
-		}
-
-		// Determine merge method from repository settings
-		var mergeMethod string
-		if repo.GetAllowSquashMerge() {
-			mergeMethod = "squash"
-		} else if repo.GetAllowMergeCommit() {
-			mergeMethod = "merge"
-		} else if repo.GetAllowRebaseMerge() {
-			mergeMethod = "rebase"
-		} else {
-			ps.UpdateStatus("No merge methods")
-			return
-		}
-
 		// Attempt to merge
 		if r.flag.DryRun {
 			ps.UpdateStatus(fmt.Sprintf("Would merge (%s)", mergeMethod))
@@ -558,18 +1238,21 @@ func (r *runner) processPR(ctx context.Context, githubClient *github.Client, ps
 		if err != nil {
 			if strings.Contains(err.Error(), "merge conflict") {
 				ps.UpdateStatus("Merge conflicts")
+				r.record(ps, pr.ActionFailed, "Merge conflicts")
 				return
 			} else if strings.Contains(err.Error(), "required status check") {
 				ps.UpdateStatus(fmt.Sprintf("Waiting checks (%d/%d)", attempt+1, maxRetries))
 				continue
 			} else {
 				ps.UpdateStatus("Merge failed")
+				r.record(ps, pr.ActionFailed, "Merge failed")
 				return
 			}
 		}
 
 		if mergeResult.GetMerged() {
 			ps.UpdateStatus(fmt.Sprintf("Merged (%s)", mergeMethod))
+			r.record(ps, pr.ActionMerged, "")
 			return
 		}
 	}
@@ -584,10 +1267,17 @@ func (r *runner) printSummary(prStatuses []*pr.PRStatus) {
 	skipped := 0
 	failed := 0
 	waiting := 0
+	confirm := 0
+	var policySkipped []*pr.PRStatus
 
 	for _, ps := range prStatuses {
 		status := ps.GetStatus()
-		if strings.Contains(status, "Merged") {
+		if strings.HasPrefix(status, "Skipped") {
+			skipped++
+			policySkipped = append(policySkipped, ps)
+		} else if strings.HasPrefix(status, "Would ask") {
+			confirm++
+		} else if strings.Contains(status, "Merged") {
 			merged++
 		} else if strings.Contains(status, "Queued to merge") {
 			queued++
@@ -595,7 +1285,7 @@ func (r *runner) printSummary(prStatuses []*pr.PRStatus) {
 			approved++
 		} else if strings.Contains(status, "Already") || strings.Contains(status, "Auto-merge enabled") {
 			skipped++
-		} else if strings.Contains(status, "Failed") || strings.Contains(status, "conflicts") || strings.Contains(status, "not allowed") {
+		} else if strings.Contains(status, "Failed") || strings.Contains(status, "conflicts") || strings.Contains(status, "not allowed") || strings.Contains(status, "Closed") {
 			failed++
 		} else if strings.Contains(status, "Waiting") || strings.Contains(status, "Timeout") {
 			waiting++
@@ -605,7 +1295,10 @@ func (r *runner) printSummary(prStatuses []*pr.PRStatus) {
 	fmt.Fprintln(r.stdout, "─────────────────────────────")
 	fmt.Fprintln(r.stdout, "Summary:")
 	if r.flag.DryRun {
-		fmt.Fprintf(r.stdout, "  PRs that would be processed: %d\n", len(prStatuses)-skipped-failed)
+		fmt.Fprintf(r.stdout, "  PRs that would be processed: %d\n", len(prStatuses)-skipped-failed-confirm)
+		if confirm > 0 {
+			fmt.Fprintf(r.stdout, "  PRs that would need confirmation: %d\n", confirm)
+		}
 	} else {
 		fmt.Fprintf(r.stdout, "  PRs merged: %d\n", merged)
 		fmt.Fprintf(r.stdout, "  PRs approved: %d\n", approved)
@@ -618,4 +1311,12 @@ func (r *runner) printSummary(prStatuses []*pr.PRStatus) {
 	if waiting > 0 {
 		fmt.Fprintf(r.stdout, "  PRs still waiting: %d\n", waiting)
 	}
+
+	if len(policySkipped) > 0 {
+		fmt.Fprintln(r.stdout, "")
+		fmt.Fprintln(r.stdout, "Skipped by risk policy:")
+		for _, ps := range policySkipped {
+			fmt.Fprintf(r.stdout, "  %s  %-40s %s\n", pr.MakeHyperlink(ps.URL, fmt.Sprintf("#%-5d", ps.Number)), ps.Owner+"/"+ps.Repo, strings.TrimPrefix(ps.GetStatus(), "Skipped: "))
+		}
+	}
 }
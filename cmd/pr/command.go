@@ -10,6 +10,7 @@ import (
 
 	"github.com/giantswarm/devctl/v8/cmd/pr/approvealign"
 	"github.com/giantswarm/devctl/v8/cmd/pr/approvemergerenovate"
+	"github.com/giantswarm/devctl/v8/cmd/pr/history"
 )
 
 const (
@@ -64,6 +65,20 @@ func New(config Config) (*cobra.Command, error) {
 		}
 	}
 
+	var historyCmd *cobra.Command
+	{
+		c := history.Config{
+			Logger: config.Logger,
+			Stderr: config.Stderr,
+			Stdout: config.Stdout,
+		}
+
+		historyCmd, err = history.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	f := &flag{}
 
 	r := &runner{
@@ -84,6 +99,7 @@ func New(config Config) (*cobra.Command, error) {
 
 	c.AddCommand(approveAlignCmd)
 	c.AddCommand(approveMergeRenovateCmd)
+	c.AddCommand(historyCmd)
 
 	return c, nil
 }
@@ -0,0 +1,69 @@
+package history
+
+import (
+	"io"
+	"os"
+
+	"github.com/giantswarm/microerror"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+const (
+	name        = "history"
+	description = "Shows what the PR approval commands approved and merged over time."
+	longDesc    = `Shows what the PR approval commands approved and merged over time.
+
+approve-merge-renovate and approve-align-files log every approval, merge,
+merge queueing, skip and failure to a state file in the devctl config dir.
+This command lists those records, oldest first, for auditing. Dry runs are
+not recorded.`
+	example = `  # Everything recorded
+  devctl pr history
+
+  # What was merged in the last week
+  devctl pr history --since 168h --action merged
+
+  # Everything done to one repository
+  devctl pr history --repo giantswarm/devctl`
+)
+
+type Config struct {
+	Logger *logrus.Logger
+	Stderr io.Writer
+	Stdout io.Writer
+}
+
+func New(config Config) (*cobra.Command, error) {
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.Stderr == nil {
+		config.Stderr = os.Stderr
+	}
+	if config.Stdout == nil {
+		config.Stdout = os.Stdout
+	}
+
+	f := &flag{}
+
+	r := &runner{
+		flag:   f,
+		logger: config.Logger,
+		stderr: config.Stderr,
+		stdout: config.Stdout,
+	}
+
+	c := &cobra.Command{
+		Use:     name,
+		Short:   description,
+		Long:    longDesc,
+		Example: example,
+		Args:    cobra.NoArgs,
+		RunE:    r.Run,
+	}
+
+	f.Init(c)
+
+	return c, nil
+}
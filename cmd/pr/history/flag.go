@@ -0,0 +1,61 @@
+package history
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/devctl/v8/internal/pr"
+)
+
+const (
+	flagSince     = "since"
+	flagCommand   = "command"
+	flagRepo      = "repo"
+	flagAction    = "action"
+	flagStateFile = "state-file"
+)
+
+type flag struct {
+	Since     time.Duration
+	Command   string
+	Repo      string
+	Actions   []string
+	StateFile string
+}
+
+func (f *flag) Init(cmd *cobra.Command) {
+	cmd.Flags().DurationVar(&f.Since, flagSince, 0, "Only show records of this long ago or later, e.g. 168h")
+	cmd.Flags().StringVar(&f.Command, flagCommand, "", "Only show records of this command, e.g. approve-merge-renovate")
+	cmd.Flags().StringVar(&f.Repo, flagRepo, "", "Only show records of this repository (owner/name)")
+	cmd.Flags().StringSliceVar(&f.Actions, flagAction, nil, fmt.Sprintf("Only show records of these actions, any of <%s>", strings.Join(actionNames(), "|")))
+	cmd.Flags().StringVar(&f.StateFile, flagStateFile, "", fmt.Sprintf("State file to read (default %s in the devctl config dir)", pr.StateFile))
+}
+
+func (f *flag) Validate() error {
+	if f.Since < 0 {
+		return microerror.Maskf(invalidFlagsError, "--%s must not be negative", flagSince)
+	}
+	if f.Repo != "" && strings.Count(f.Repo, "/") != 1 {
+		return microerror.Maskf(invalidFlagsError, "--%s must be owner/name, got %q", flagRepo, f.Repo)
+	}
+	for _, a := range f.Actions {
+		if !slices.Contains(actionNames(), a) {
+			return microerror.Maskf(invalidFlagsError, "--%s %q is not one of <%s>", flagAction, a, strings.Join(actionNames(), "|"))
+		}
+	}
+
+	return nil
+}
+
+func actionNames() []string {
+	var names []string
+	for _, a := range pr.AllActions() {
+		names = append(names, string(a))
+	}
+	return names
+}
@@ -0,0 +1,93 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/giantswarm/devctl/v8/internal/env"
+	"github.com/giantswarm/devctl/v8/internal/pr"
+)
+
+type runner struct {
+	flag   *flag
+	logger *logrus.Logger
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func (r *runner) Run(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	err := r.flag.Validate()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = r.run(ctx, cmd, args)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func (r *runner) run(ctx context.Context, cmd *cobra.Command, args []string) error {
+	path := r.flag.StateFile
+	if path == "" {
+		path = filepath.Join(env.ConfigDir.Val(), pr.StateFile)
+	}
+
+	records, err := pr.ReadState(path)
+	if err != nil {
+		return microerror.Maskf(executionFailedError, "%s", err)
+	}
+
+	filter := pr.HistoryFilter{
+		Command: r.flag.Command,
+		Repo:    r.flag.Repo,
+	}
+	if r.flag.Since > 0 {
+		filter.Since = time.Now().Add(-r.flag.Since)
+	}
+	for _, a := range r.flag.Actions {
+		filter.Actions = append(filter.Actions, pr.Action(a))
+	}
+
+	t := table.NewWriter()
+	t.SetOutputMirror(r.stdout)
+	t.SetStyle(table.StyleDefault)
+	t.AppendHeader(table.Row{"TIME", "COMMAND", "ACTION", "PR", "TITLE", "REASON"})
+
+	var n int
+	for _, rec := range records {
+		if !filter.Match(rec) {
+			continue
+		}
+		n++
+		t.AppendRow(table.Row{
+			rec.Time.Local().Format(time.DateTime),
+			rec.Command,
+			rec.Action,
+			fmt.Sprintf("%s/%s#%d", rec.Owner, rec.Repo, rec.Number),
+			rec.Title,
+			rec.Reason,
+		})
+	}
+
+	if n == 0 {
+		fmt.Fprintf(r.stdout, "No matching PR actions recorded in %s.\n", path)
+		return nil
+	}
+
+	t.Render()
+
+	return nil
+}
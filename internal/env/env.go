@@ -13,6 +13,7 @@ var (
 	DevctlUnsafeForceVersion = devctlUnsafeForceVersion{}
 	FlatcarChannel           = flatcarChannel{}
 	FlatcarReleasesURL       = flatcarReleasesURL{}
+	GenPluginPath            = genPluginPath{}
 	GitHubToken              = gitHubToken{}
 )
 
@@ -64,6 +65,29 @@ func (flatcarReleasesURL) Val() string {
 	return fmt.Sprintf("https://www.flatcar.org/releases-json/releases-%s.json", FlatcarChannel.Val())
 }
 
+type genPluginPath struct{}
+
+func (genPluginPath) Key() string { return "DEVCTL_GEN_PLUGIN_PATH" }
+
+// Val returns the directories `devctl gen` plugins are loaded from. The
+// directories listed in DEVCTL_GEN_PLUGIN_PATH (separated like PATH) come
+// first, followed by the directory `devctl gen plugins install` installs
+// plugins into.
+func (genPluginPath) Val() []string {
+	var dirs []string
+	if p := os.Getenv(genPluginPath{}.Key()); p != "" {
+		dirs = append(dirs, filepath.SplitList(p)...)
+	}
+
+	return append(dirs, GenPluginPath.InstallDir())
+}
+
+// InstallDir returns the directory `devctl gen plugins install` installs
+// plugins into.
+func (genPluginPath) InstallDir() string {
+	return filepath.Join(ConfigDir.Val(), "gen-plugins")
+}
+
 type gitHubToken struct{}
 
 // Tries to get the GitHub token from environment variables.
@@ -0,0 +1,68 @@
+package pr
+
+import (
+	"github.com/google/go-github/v90/github"
+)
+
+// Checks is the combined state of the status checks and check runs of a PR
+// head commit.
+type Checks string
+
+const (
+	ChecksPassing Checks = "passing"
+	ChecksFailing Checks = "failing"
+	ChecksPending Checks = "pending"
+)
+
+// ChecksState combines the commit statuses and check runs of a commit. Either
+// may be nil when it could not be fetched.
+func ChecksState(combinedStatus *github.CombinedStatus, checkRuns *github.ListCheckRunsResults) Checks {
+	// Check if any checks are failing or pending
+	hasFailedChecks := false
+	checksPending := false
+
+	// Check combined status first - this is for traditional status checks
+	if combinedStatus != nil {
+		state := combinedStatus.GetState()
+		totalCount := combinedStatus.GetTotalCount()
+
+		if state == "failure" || state == "error" {
+			hasFailedChecks = true
+		} else if state == "pending" && totalCount > 0 {
+			// Only treat as pending if there are actual status checks
+			// pending with totalCount=0 just means no checks exist, not that checks are waiting
+			checksPending = true
+		}
+	}
+
+	// Check individual check runs (GitHub Actions checks)
+	if checkRuns != nil && len(checkRuns.CheckRuns) > 0 && !hasFailedChecks {
+		// Only check runs if combinedStatus didn't already give us a definitive answer
+		if combinedStatus == nil || (combinedStatus.GetState() != "success" && combinedStatus.GetState() != "failure") {
+			for _, run := range checkRuns.CheckRuns {
+				conclusion := run.GetConclusion()
+				status := run.GetStatus()
+
+				if status == "completed" {
+					if conclusion == "failure" || conclusion == "cancelled" || conclusion == "timed_out" {
+						hasFailedChecks = true
+						break
+					}
+					// success, neutral, skipped are OK
+				} else {
+					// Check is not completed (queued, in_progress)
+					checksPending = true
+				}
+			}
+		}
+	}
+
+	switch {
+	case hasFailedChecks:
+		return ChecksFailing
+	case checksPending:
+		return ChecksPending
+	default:
+		return ChecksPassing
+	}
+}
@@ -2,14 +2,17 @@ package pr
 
 import (
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 )
 
 // PRGroup represents a group of related PRs (e.g. by dependency or repository).
 type PRGroup struct {
-	Name        string
-	PRs         []*PRInfo
+	Name string
+	PRs  []*PRInfo
+	// SearchQuery narrows a search to the group, empty when the group name is
+	// not in the PR titles, e.g. for groups named from the branch.
 	SearchQuery string
 }
 
@@ -20,9 +23,18 @@ type PRInfo struct {
 	Repo   string
 	Title  string
 	URL    string
+	// Body is the PR description, for Renovate PRs it lists the updated
+	// dependencies.
+	Body string
+	// Branch is the head branch of the PR, empty when unknown, e.g. for
+	// search results.
+	Branch string
 }
 
-// GroupRenovatePRs clusters PRs by dependency name.
+// LockFileMaintenance is the group of Renovate's lock file maintenance PRs.
+const LockFileMaintenance = "lock file maintenance"
+
+// GroupRenovatePRs clusters PRs by dependency name, see GroupName.
 // Returns groups sorted by PR count (descending).
 // ALL groups are included, even those with only 1 PR.
 func GroupRenovatePRs(prs []*PRInfo) []*PRGroup {
@@ -31,7 +43,7 @@ func GroupRenovatePRs(prs []*PRInfo) []*PRGroup {
 
 	// Group PRs by extracted dependency name
 	for _, pr := range prs {
-		depName := ExtractDependencyName(pr.Title)
+		depName := GroupName(pr)
 		groups[depName] = append(groups[depName], pr)
 	}
 
@@ -60,30 +72,110 @@ func GroupRenovatePRs(prs []*PRInfo) []*PRGroup {
 	return result
 }
 
+// GroupName returns the dependency group of a PR. The dependency table of the
+// Renovate PR body comes first, the title heuristics of ExtractDependencyName
+// and the branch are the fallback for PRs without one:
+//
+//  1. Lock file maintenance PRs, by their Update column, branch or title.
+//  2. The package of the table, when it lists a single one.
+//  3. The group Renovate names in the title, when the table lists several
+//     packages, e.g. for "Update all non-major dependencies" or monorepos.
+//  4. The dependency named in the title, or else the branch topic.
+func GroupName(p *PRInfo) string {
+	rows := renovateTable(p.Body)
+	if isLockFileMaintenance(p, rows) {
+		return LockFileMaintenance
+	}
+
+	var packages []string
+	for _, row := range rows {
+		name := normalizeDepName(packageName(row["Package"]))
+		if name != "" && !slices.Contains(packages, name) {
+			packages = append(packages, name)
+		}
+	}
+	switch {
+	case len(packages) == 1:
+		return packages[0]
+	case len(packages) > 1:
+		if name := normalizeWithVersionStrip(p.Title); name != "" {
+			return name
+		}
+	}
+
+	if name, ok := matchTitlePatterns(p.Title); ok {
+		return name
+	}
+	if topic, ok := strings.CutPrefix(p.Branch, "renovate/"); ok && topic != "" {
+		return topic
+	}
+
+	return ExtractDependencyName(p.Title)
+}
+
+// isLockFileMaintenance reports whether p is a lock file maintenance PR, rows
+// being the dependency table of its body.
+func isLockFileMaintenance(p *PRInfo, rows []map[string]string) bool {
+	for _, row := range rows {
+		if t, ok := rowUpdate(row); ok && t == UpdateLockfile {
+			return true
+		}
+	}
+
+	return p.Branch == "renovate/lock-file-maintenance" ||
+		strings.Contains(strings.ToLower(p.Title), LockFileMaintenance)
+}
+
+// packageName returns the package of a Package cell of the dependency table,
+// without its link, e.g. "[lodash](https://lodash.com/) ([source](...))".
+func packageName(cell string) string {
+	if m := packageLinkRE.FindStringSubmatch(cell); m != nil {
+		cell = m[1]
+	} else if i := strings.Index(cell, " ("); i >= 0 {
+		cell = cell[:i]
+	}
+
+	return strings.Trim(cell, "` ")
+}
+
+var packageLinkRE = regexp.MustCompile(`^\[([^\]]+)\]\(`)
+
+// titlePatterns match the dependency in the titles of Renovate and Dependabot
+// PRs.
+var titlePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`[Uu]pdate dependency (@?[\w\-./]+(?:/[\w\-./]+)*) to`),
+	regexp.MustCompile(`[Uu]pdate [Hh]elm [Rr]elease ([\w\-./]+) to`),
+	regexp.MustCompile(`[Uu]pdate module ([\w\-./]+(?:/[\w\-./]+)*) to`),
+	regexp.MustCompile(`[Uu]pdate ([\w\-./]+(?:/[\w\-./]+)*) digest to`),
+	regexp.MustCompile(`chore\(deps\): update ([\w\-./]+(?:/[\w\-./]+)*) (?:docker tag|to)`),
+	regexp.MustCompile(`[Uu]pdate ([\w\-./]+(?:/[\w\-./]+)*) action to`),
+	regexp.MustCompile(`[Bb]ump (@?[\w\-./]+(?:/[\w\-./]+)*) from`),
+	// Generic patterns for other update formats
+	regexp.MustCompile(`[Uu]pdate ([\w\-./]+(?:/[\w\-./]+)*) [Dd]ocker [Tt]ag to`),
+	regexp.MustCompile(`[Uu]pdate ([\w\-./]+(?:/[\w\-./]+)*) to v?\d+`),
+}
+
+// matchTitlePatterns returns the dependency the first of titlePatterns finds
+// in title.
+func matchTitlePatterns(title string) (string, bool) {
+	for _, re := range titlePatterns {
+		matches := re.FindStringSubmatch(title)
+		if len(matches) > 1 {
+			return normalizeDepName(matches[1]), true
+		}
+	}
+
+	return "", false
+}
+
 // ExtractDependencyName applies clustering algorithms in sequence.
 // Algorithm 1: Pattern-based extraction (primary)
 // Algorithm 2: Version-stripped normalization (fallback)
 // Algorithm 3: Exact title match (last resort)
 func ExtractDependencyName(title string) string {
 	// Algorithm 1: Pattern-Based Extraction
-	patterns := []string{
-		`[Uu]pdate dependency (@?[\w\-./]+(?:/[\w\-./]+)*) to`,
-		`[Uu]pdate [Hh]elm [Rr]elease ([\w\-./]+) to`,
-		`[Uu]pdate module ([\w\-./]+(?:/[\w\-./]+)*) to`,
-		`[Uu]pdate ([\w\-./]+(?:/[\w\-./]+)*) digest to`,
-		`chore\(deps\): update ([\w\-./]+(?:/[\w\-./]+)*) (?:docker tag|to)`,
-		`[Uu]pdate ([\w\-./]+(?:/[\w\-./]+)*) action to`,
-		// Generic patterns for other update formats
-		`[Uu]pdate ([\w\-./]+(?:/[\w\-./]+)*) [Dd]ocker [Tt]ag to`,
-		`[Uu]pdate ([\w\-./]+(?:/[\w\-./]+)*) to v?\d+`,
-	}
-
-	for _, pattern := range patterns {
-		re := regexp.MustCompile(pattern)
-		matches := re.FindStringSubmatch(title)
-		if len(matches) > 1 {
-			return normalizeDepName(matches[1])
-		}
+	if name, ok := matchTitlePatterns(title); ok {
+		return name
 	}
 
 	// Algorithm 2: Version-Stripped Normalization
@@ -206,7 +298,16 @@ func GroupRenovatePRsByRepo(prs []*PRInfo) []*PRGroup {
 }
 
 // generateSearchQuery creates a search query string from the dependency name.
+// Searches match the title, so groups named from the dependency table or the
+// branch get no query when a title lacks the name, rather than one that misses
+// PRs of the group.
 func generateSearchQuery(depName string, prs []*PRInfo) string {
+	for _, pr := range prs {
+		if !strings.Contains(strings.ToLower(pr.Title), strings.ToLower(depName)) {
+			return ""
+		}
+	}
+
 	// Wrap the dependency name in quotes to handle special characters
 	// like @ in scoped packages (e.g., @actions/core, @types/cors)
 	// This ensures GitHub search treats it as a literal string
@@ -96,6 +96,160 @@ func TestExtractDependencyName(t *testing.T) {
 	}
 }
 
+const renovateBodyNonMajor = `This PR contains the following updates:
+
+| Package | Change | Age | Confidence | Type | Update |
+|---|---|---|---|---|---|
+| [github.com/spf13/cobra](https://redirect.github.com/spf13/cobra) | ` + "`v1.9.1` -> `v1.10.1`" + ` | [![age](https://developer.mend.io/api/mc/badges/age/go/github.com%2fspf13%2fcobra/v1.10.1?slim=true)](https://docs.renovatebot.com/merge-confidence/) | [![confidence](https://developer.mend.io/api/mc/badges/confidence/go/github.com%2fspf13%2fcobra/v1.9.1/v1.10.1?slim=true)](https://docs.renovatebot.com/merge-confidence/) | require | minor |
+| [github.com/stretchr/testify](https://redirect.github.com/stretchr/testify) | ` + "`v1.10.0` -> `v1.11.1`" + ` | [![age](https://developer.mend.io/api/mc/badges/age/go/github.com%2fstretchr%2ftestify/v1.11.1?slim=true)](https://docs.renovatebot.com/merge-confidence/) | [![confidence](https://developer.mend.io/api/mc/badges/confidence/go/github.com%2fstretchr%2ftestify/v1.10.0/v1.11.1?slim=true)](https://docs.renovatebot.com/merge-confidence/) | require | minor |
+| golang | ` + "`1.24.3` -> `1.24.6`" + ` | [![age](https://developer.mend.io/api/mc/badges/age/docker/golang/1.24.6?slim=true)](https://docs.renovatebot.com/merge-confidence/) | [![confidence](https://developer.mend.io/api/mc/badges/confidence/docker/golang/1.24.3/1.24.6?slim=true)](https://docs.renovatebot.com/merge-confidence/) | stage | patch |
+
+---
+
+### Release Notes
+
+<details>
+<summary>spf13/cobra (github.com/spf13/cobra)</summary>
+
+| Package | Note |
+|---|---|
+| unrelated | release notes table |
+
+</details>
+`
+
+const renovateBodyMonorepo = `This PR contains the following updates:
+
+| Package | Change | Age | Confidence |
+|---|---|---|---|
+| [go.opentelemetry.io/otel](https://redirect.github.com/open-telemetry/opentelemetry-go) | ` + "`v1.37.0` -> `v1.38.0`" + ` | | |
+| [go.opentelemetry.io/otel/sdk](https://redirect.github.com/open-telemetry/opentelemetry-go) | ` + "`v1.37.0` -> `v1.38.0`" + ` | | |
+| [go.opentelemetry.io/otel/trace](https://redirect.github.com/open-telemetry/opentelemetry-go) | ` + "`v1.37.0` -> `v1.38.0`" + ` | | |
+`
+
+const renovateBodyNPM = `This PR contains the following updates:
+
+| Package | Change | Age | Confidence |
+|---|---|---|---|
+| [@types/cors](https://redirect.github.com/DefinitelyTyped/DefinitelyTyped/tree/master/types/cors) ([source](https://redirect.github.com/DefinitelyTyped/DefinitelyTyped/tree/HEAD/types/cors)) | [` + "`2.8.17` -> `2.8.19`" + `](https://renovatebot.com/diffs/npm/@types%2fcors/2.8.17/2.8.19) | | |
+`
+
+func TestGroupName(t *testing.T) {
+	tests := []struct {
+		name     string
+		pr       PRInfo
+		expected string
+	}{
+		{
+			name:     "single package from the table",
+			pr:       PRInfo{Title: "Update module github.com/google/go-github/v80 to v81", Body: renovateBodyChange},
+			expected: "github.com/google/go-github",
+		},
+		{
+			name:     "table wins over a custom title",
+			pr:       PRInfo{Title: "fix(deps): bump go-github (major)", Body: renovateBodyChange},
+			expected: "github.com/google/go-github",
+		},
+		{
+			name:     "scoped npm package with source link",
+			pr:       PRInfo{Title: "Update dependency @types/cors to v2.8.19", Body: renovateBodyNPM},
+			expected: "@types/cors",
+		},
+		{
+			name:     "package listed for several types",
+			pr:       PRInfo{Title: "Update golang Docker tag to v1.25.0", Body: "| Package | Type | Update | Change |\n|---|---|---|---|\n| golang | stage | minor | `1.24.3` -> `1.25.0` |\n| golang | final | minor | `1.24.3` -> `1.25.0` |\n"},
+			expected: "golang",
+		},
+		{
+			name:     "grouped non-major update",
+			pr:       PRInfo{Title: "Update all non-major dependencies", Body: renovateBodyNonMajor},
+			expected: "all non-major dependencies",
+		},
+		{
+			name:     "grouped update with chore prefix",
+			pr:       PRInfo{Title: "chore(deps): update all non-major dependencies", Body: renovateBodyNonMajor},
+			expected: "all non-major dependencies",
+		},
+		{
+			name:     "monorepo",
+			pr:       PRInfo{Title: "Update opentelemetry-go monorepo to v1.38.0", Body: renovateBodyMonorepo},
+			expected: "opentelemetry-go monorepo",
+		},
+		{
+			name:     "lock file maintenance from the table",
+			pr:       PRInfo{Title: "chore(deps): refresh lock files", Body: renovateBodyLockfile},
+			expected: LockFileMaintenance,
+		},
+		{
+			name:     "lock file maintenance from the branch",
+			pr:       PRInfo{Title: "chore(deps): refresh lock files", Branch: "renovate/lock-file-maintenance"},
+			expected: LockFileMaintenance,
+		},
+		{
+			name:     "lock file maintenance from the title",
+			pr:       PRInfo{Title: "Lock file maintenance"},
+			expected: LockFileMaintenance,
+		},
+		{
+			name:     "branch topic without table",
+			pr:       PRInfo{Title: "Update E2E tests", Branch: "renovate/e2e-tests"},
+			expected: "e2e-tests",
+		},
+		{
+			name:     "title patterns before the branch",
+			pr:       PRInfo{Title: "Update dependency storybook to v7.6.21 [SECURITY]", Branch: "renovate/npm-storybook-vulnerability"},
+			expected: "storybook",
+		},
+		{
+			name:     "dependabot bump",
+			pr:       PRInfo{Title: "Bump golang.org/x/net from 0.17.0 to 0.23.0", Branch: "dependabot/go_modules/golang.org/x/net-0.23.0"},
+			expected: "golang.org/x/net",
+		},
+		{
+			name:     "title heuristics without body and branch",
+			pr:       PRInfo{Title: "chore(deps): update monitoring stack"},
+			expected: "monitoring stack",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GroupName(&tt.pr)
+			if got != tt.expected {
+				t.Errorf("GroupName() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGroupRenovatePRs_Bodies(t *testing.T) {
+	prs := []*PRInfo{
+		{Number: 1, Repo: "devctl", Title: "Update all non-major dependencies", Body: renovateBodyNonMajor},
+		{Number: 2, Repo: "happa", Title: "Update all non-major dependencies", Body: renovateBodyNonMajor},
+		{Number: 3, Repo: "devctl", Title: "Lock file maintenance", Body: renovateBodyLockfile},
+		{Number: 4, Repo: "happa", Title: "chore(deps): lock file maintenance", Body: renovateBodyLockfile},
+		{Number: 5, Repo: "opsctl", Title: "Update module github.com/google/go-github/v80 to v81", Body: renovateBodyChange},
+		{Number: 6, Repo: "kubectl-gs", Title: "Update module github.com/google/go-github/v79 to v81"},
+	}
+
+	groups := GroupRenovatePRs(prs)
+
+	got := map[string][]int{}
+	for _, g := range groups {
+		for _, p := range g.PRs {
+			got[g.Name] = append(got[g.Name], p.Number)
+		}
+	}
+	expected := map[string][]int{
+		"all non-major dependencies":  {1, 2},
+		LockFileMaintenance:           {3, 4},
+		"github.com/google/go-github": {5, 6},
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("GroupRenovatePRs() mismatch (-expected +got):\n%s", diff)
+	}
+}
+
 func TestGroupRenovatePRs(t *testing.T) {
 	prs := []*PRInfo{
 		{Number: 1, Owner: "giantswarm", Repo: "repo1", Title: "Update module github.com/google/go-github/v80 to v81", URL: "https://github.com/giantswarm/repo1/pull/1"},
@@ -145,6 +299,30 @@ func TestGroupRenovatePRs(t *testing.T) {
 	}
 }
 
+// TestGroupRenovatePRs_BranchGroup verifies that a group named from the branch
+// gets no search query, its name not being in the title, so a search for the
+// group does not miss its PRs.
+func TestGroupRenovatePRs_BranchGroup(t *testing.T) {
+	prs := []*PRInfo{
+		{Number: 1, Owner: "giantswarm", Repo: "repo1", Title: "Update e2e test dependencies", Branch: "renovate/e2e-tests", URL: "https://github.com/giantswarm/repo1/pull/1"},
+		{Number: 2, Owner: "giantswarm", Repo: "repo2", Title: "Update e2e test dependencies", Branch: "renovate/e2e-tests", URL: "https://github.com/giantswarm/repo2/pull/2"},
+	}
+
+	groups := GroupRenovatePRs(prs)
+	if len(groups) != 1 {
+		t.Fatalf("Expected 1 group, got %d", len(groups))
+	}
+	if groups[0].Name != "e2e-tests" {
+		t.Errorf("Group should be e2e-tests, got %s", groups[0].Name)
+	}
+	if len(groups[0].PRs) != 2 {
+		t.Errorf("Group should have 2 PRs, got %d", len(groups[0].PRs))
+	}
+	if groups[0].SearchQuery != "" {
+		t.Errorf("Group named from the branch should have no search query, got %s", groups[0].SearchQuery)
+	}
+}
+
 func TestGroupRenovatePRs_SinglePRGroups(t *testing.T) {
 	// Test that single-PR groups are included
 	prs := []*PRInfo{
@@ -257,6 +435,23 @@ func TestGenerateSearchQuery(t *testing.T) {
 			},
 			want: `"github.com/google/go-github"`,
 		},
+		{
+			name:    "name from the branch",
+			depName: "e2e-tests",
+			prs: []*PRInfo{
+				{Title: "Update e2e test dependencies", Branch: "renovate/e2e-tests"},
+			},
+			want: "",
+		},
+		{
+			name:    "lock file maintenance from the table",
+			depName: LockFileMaintenance,
+			prs: []*PRInfo{
+				{Title: "Lock file maintenance"},
+				{Title: "chore(deps): refresh lockfile", Branch: "renovate/lock-file-maintenance"},
+			},
+			want: "",
+		},
 	}
 
 	for _, tt := range tests {
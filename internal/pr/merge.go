@@ -0,0 +1,352 @@
+package pr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v90/github"
+)
+
+// MergeMode is how an approved PR gets merged.
+type MergeMode string
+
+const (
+	// MergeModeQueue enqueues the PR to the merge queue of its base branch.
+	MergeModeQueue MergeMode = "merge-queue"
+	// MergeModeAutoMerge enables auto-merge, GitHub merges the PR once its
+	// requirements are met.
+	MergeModeAutoMerge MergeMode = "auto-merge"
+	// MergeModeDirect is the command waiting for checks and merging the PR
+	// itself, for repositories allowing neither.
+	MergeModeDirect MergeMode = "direct"
+	// MergeModeNone leaves the merge to a human, for commands that only
+	// approve where GitHub cannot merge.
+	MergeModeNone MergeMode = "none"
+)
+
+// Merge methods, in the order MergeMethod prefers them.
+const (
+	MergeMethodSquash = "squash"
+	MergeMethodMerge  = "merge"
+	MergeMethodRebase = "rebase"
+)
+
+// AllMergeMethods returns the methods MergeMethod accepts.
+func AllMergeMethods() []string {
+	return []string{MergeMethodSquash, MergeMethodMerge, MergeMethodRebase}
+}
+
+// MergeMethod returns preferred if repo allows it, or else the first method
+// of AllMergeMethods repo allows when preferred is empty.
+func MergeMethod(repo *github.Repository, preferred string) (string, error) {
+	allowed := map[string]bool{
+		MergeMethodSquash: repo.GetAllowSquashMerge(),
+		MergeMethodMerge:  repo.GetAllowMergeCommit(),
+		MergeMethodRebase: repo.GetAllowRebaseMerge(),
+	}
+
+	if preferred != "" {
+		if !allowed[preferred] {
+			return "", fmt.Errorf("merge method %q is not allowed in %s", preferred, repo.GetFullName())
+		}
+		return preferred, nil
+	}
+	for _, m := range AllMergeMethods() {
+		if allowed[m] {
+			return m, nil
+		}
+	}
+
+	return "", fmt.Errorf("no merge methods allowed in %s", repo.GetFullName())
+}
+
+// ResolveMergeMode returns how PRs into the base branch of repo get merged:
+// through its merge queue, auto-merge if the repository allows it or the PR
+// has it enabled already, or directly.
+func ResolveMergeMode(ctx context.Context, client *github.Client, repo *github.Repository, base string, autoMerge bool) (MergeMode, error) {
+	queue, err := UsesMergeQueue(ctx, client, repo.GetOwner().GetLogin(), repo.GetName(), base)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case queue:
+		return MergeModeQueue, nil
+	case autoMerge || repo.GetAllowAutoMerge():
+		return MergeModeAutoMerge, nil
+	default:
+		return MergeModeDirect, nil
+	}
+}
+
+// UsesMergeQueue reports whether branch of owner/repo has a merge queue.
+func UsesMergeQueue(ctx context.Context, client *github.Client, owner, repo, branch string) (bool, error) {
+	const query = `query($owner: String!, $repo: String!, $branch: String!) {
+  repository(owner: $owner, name: $repo) {
+    mergeQueue(branch: $branch) { id }
+  }
+}`
+
+	var data struct {
+		Repository struct {
+			MergeQueue *struct {
+				ID string `json:"id"`
+			} `json:"mergeQueue"`
+		} `json:"repository"`
+	}
+	err := graphQL(ctx, client, query, map[string]any{"owner": owner, "repo": repo, "branch": branch}, &data)
+	if err != nil {
+		return false, fmt.Errorf("failed to look up the merge queue of %s/%s %s: %w", owner, repo, branch, err)
+	}
+
+	return data.Repository.MergeQueue != nil, nil
+}
+
+// EnqueuePR adds the PR with the GraphQL node ID id to the merge queue of its
+// base branch and returns its position.
+func EnqueuePR(ctx context.Context, client *github.Client, id string) (int, error) {
+	const mutation = `mutation($id: ID!) {
+  enqueuePullRequest(input: {pullRequestId: $id}) {
+    mergeQueueEntry { position }
+  }
+}`
+
+	var data struct {
+		EnqueuePullRequest struct {
+			MergeQueueEntry struct {
+				Position int `json:"position"`
+			} `json:"mergeQueueEntry"`
+		} `json:"enqueuePullRequest"`
+	}
+	err := graphQL(ctx, client, mutation, map[string]any{"id": id}, &data)
+	if err != nil {
+		return 0, fmt.Errorf("failed to enqueue: %w", err)
+	}
+
+	return data.EnqueuePullRequest.MergeQueueEntry.Position, nil
+}
+
+// EnableAutoMerge enables auto-merge with method on the PR with the GraphQL
+// node ID id. An empty method leaves the choice to GitHub, which is how PRs
+// are queued once their checks pass on merge queue branches.
+func EnableAutoMerge(ctx context.Context, client *github.Client, id, method string) error {
+	const mutation = `mutation($id: ID!, $method: PullRequestMergeMethod) {
+  enablePullRequestAutoMerge(input: {pullRequestId: $id, mergeMethod: $method}) {
+    clientMutationId
+  }
+}`
+
+	variables := map[string]any{"id": id, "method": nil}
+	if method != "" {
+		variables["method"] = strings.ToUpper(method)
+	}
+	err := graphQL(ctx, client, mutation, variables, nil)
+	if err != nil {
+		return fmt.Errorf("failed to enable auto-merge: %w", err)
+	}
+
+	return nil
+}
+
+// QueueForMerge hands the approved PR p over to GitHub to merge in the given
+// mode, one of MergeModeQueue and MergeModeAutoMerge. PRs with passing checks
+// are enqueued right away, the others queued once their checks pass. PRs with
+// auto-merge enabled or in the queue already, e.g. from a resumed run, are
+// left as they are. It returns the position in the merge queue, or zero.
+func QueueForMerge(ctx context.Context, client *github.Client, p *github.PullRequest, mode MergeMode, method string, checks Checks) (int, error) {
+	if p.GetAutoMerge() != nil {
+		return 0, nil
+	}
+
+	if mode == MergeModeQueue {
+		base := p.GetBase().GetRepo()
+		state, err := GetMergeState(ctx, client, base.GetOwner().GetLogin(), base.GetName(), p.GetNumber())
+		if err == nil && state.InQueue {
+			return state.Position, nil
+		}
+
+		if checks == ChecksPassing {
+			position, err := EnqueuePR(ctx, client, p.GetNodeID())
+			if err == nil {
+				return position, nil
+			}
+		}
+		// The queue's own method applies.
+		method = ""
+	}
+
+	return 0, EnableAutoMerge(ctx, client, p.GetNodeID(), method)
+}
+
+// MergeState is the state of a PR handed over to GitHub to merge.
+type MergeState struct {
+	// State is OPEN, CLOSED or MERGED.
+	State     string
+	InQueue   bool
+	Position  int
+	AutoMerge bool
+	Checks    Checks
+}
+
+// GetMergeState returns the merge state of the PR owner/repo#number.
+func GetMergeState(ctx context.Context, client *github.Client, owner, repo string, number int) (MergeState, error) {
+	const query = `query($owner: String!, $repo: String!, $number: Int!) {
+  repository(owner: $owner, name: $repo) {
+    pullRequest(number: $number) {
+      state
+      isInMergeQueue
+      mergeQueueEntry { position }
+      autoMergeRequest { enabledAt }
+      commits(last: 1) { nodes { commit { statusCheckRollup { state } } } }
+    }
+  }
+}`
+
+	var data struct {
+		Repository struct {
+			PullRequest struct {
+				State           string `json:"state"`
+				IsInMergeQueue  bool   `json:"isInMergeQueue"`
+				MergeQueueEntry *struct {
+					Position int `json:"position"`
+				} `json:"mergeQueueEntry"`
+				AutoMergeRequest *struct {
+					EnabledAt time.Time `json:"enabledAt"`
+				} `json:"autoMergeRequest"`
+				Commits struct {
+					Nodes []struct {
+						Commit struct {
+							StatusCheckRollup *struct {
+								State string `json:"state"`
+							} `json:"statusCheckRollup"`
+						} `json:"commit"`
+					} `json:"nodes"`
+				} `json:"commits"`
+			} `json:"pullRequest"`
+		} `json:"repository"`
+	}
+	err := graphQL(ctx, client, query, map[string]any{"owner": owner, "repo": repo, "number": number}, &data)
+	if err != nil {
+		return MergeState{}, fmt.Errorf("failed to get the merge state of %s/%s#%d: %w", owner, repo, number, err)
+	}
+
+	p := data.Repository.PullRequest
+	state := MergeState{
+		State:     p.State,
+		InQueue:   p.IsInMergeQueue,
+		AutoMerge: p.AutoMergeRequest != nil,
+		Checks:    ChecksPassing,
+	}
+	if p.MergeQueueEntry != nil {
+		state.Position = p.MergeQueueEntry.Position
+	}
+	if n := p.Commits.Nodes; len(n) > 0 && n[0].Commit.StatusCheckRollup != nil {
+		switch n[0].Commit.StatusCheckRollup.State {
+		case "FAILURE", "ERROR":
+			state.Checks = ChecksFailing
+		case "PENDING", "EXPECTED":
+			state.Checks = ChecksPending
+		}
+	}
+
+	return state, nil
+}
+
+// WaitForMerge follows the PR of ps, handed over to GitHub to merge, until it
+// merges, fails, or timeout passes, polling every interval. It keeps the
+// status of ps current and returns the action to record with its reason:
+// ActionMerged, ActionFailed, or ActionMergeQueued when the PR is still
+// queued.
+func WaitForMerge(ctx context.Context, client *github.Client, ps *PRStatus, timeout, interval time.Duration) (Action, string) {
+	deadline := time.Now().Add(timeout)
+	via := "auto-merge"
+	left := false
+
+	for {
+		state, err := GetMergeState(ctx, client, ps.Owner, ps.Repo, ps.Number)
+		if err == nil {
+			if state.InQueue {
+				via = "merge queue"
+			}
+
+			switch {
+			case state.State == "MERGED":
+				ps.UpdateStatus(fmt.Sprintf("Merged (%s)", via))
+				return ActionMerged, ""
+			case state.State == "CLOSED":
+				ps.UpdateStatus("Closed without merging")
+				return ActionFailed, "Closed without merging"
+			case state.Checks == ChecksFailing && !state.InQueue:
+				ps.UpdateStatus("Failed checks")
+				return ActionFailed, "Failed checks"
+			case !state.InQueue && !state.AutoMerge && left:
+				// GitHub dequeues PRs whose merge group fails, and
+				// disables auto-merge when the base branch moves on
+				// with conflicts.
+				ps.UpdateStatus("Failed to merge (dequeued)")
+				return ActionFailed, "Failed to merge (dequeued)"
+			case !state.InQueue && !state.AutoMerge:
+				// A PR leaves the queue shortly before it shows as
+				// merged, so look once more.
+				left = true
+			case state.InQueue:
+				left = false
+				ps.UpdateStatus(fmt.Sprintf("Queued to merge (merge queue #%d)", state.Position))
+			default:
+				left = false
+				ps.UpdateStatus("Queued to merge (auto-merge)")
+			}
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			return ActionMergeQueued, ""
+		}
+		select {
+		case <-ctx.Done():
+			return ActionMergeQueued, ""
+		case <-time.After(interval):
+		}
+	}
+}
+
+// graphQL runs query with variables against the GraphQL API of client and
+// decodes the data of the response into data, unless it is nil.
+func graphQL(ctx context.Context, client *github.Client, query string, variables map[string]any, data any) error {
+	req, err := client.NewRequest(ctx, "POST", "graphql", map[string]any{"query": query, "variables": variables})
+	if err != nil {
+		return err
+	}
+
+	var resp struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	_, err = client.Do(req, &resp)
+	if err != nil {
+		return err
+	}
+
+	if len(resp.Errors) > 0 {
+		var messages []string
+		for _, e := range resp.Errors {
+			if !slices.Contains(messages, e.Message) {
+				messages = append(messages, e.Message)
+			}
+		}
+		return fmt.Errorf("%s", strings.Join(messages, "; "))
+	}
+	if len(resp.Data) == 0 || string(resp.Data) == "null" {
+		return fmt.Errorf("empty GraphQL response")
+	}
+	if data == nil {
+		return nil
+	}
+
+	return json.Unmarshal(resp.Data, data)
+}
@@ -0,0 +1,351 @@
+package pr
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v90/github"
+)
+
+// graphQLCall is a request the fake GraphQL API received.
+type graphQLCall struct {
+	Operation string
+	Variables map[string]any
+}
+
+// fakeGraphQL serves the GraphQL API of a github.Client. respond returns the
+// data of the response to the nth request of an operation, or an error
+// message.
+func fakeGraphQL(t *testing.T, respond func(operation string, n int) (string, string)) (*github.Client, func() []graphQLCall) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var calls []graphQLCall
+	counts := map[string]int{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/graphql" {
+			t.Errorf("unexpected request to %s", req.URL.Path)
+			http.NotFound(w, req)
+			return
+		}
+
+		var body struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		err := json.NewDecoder(req.Body).Decode(&body)
+		if err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+
+		var operation string
+		for _, op := range []string{"mergeQueue(", "enqueuePullRequest", "enablePullRequestAutoMerge", "pullRequest(number"} {
+			if strings.Contains(body.Query, op) {
+				operation = strings.Split(op, "(")[0]
+				break
+			}
+		}
+
+		mu.Lock()
+		calls = append(calls, graphQLCall{Operation: operation, Variables: body.Variables})
+		n := counts[operation]
+		counts[operation]++
+		mu.Unlock()
+
+		data, message := respond(operation, n)
+		w.Header().Set("Content-Type", "application/json")
+		if message != "" {
+			_, _ = w.Write([]byte(`{"data":null,"errors":[{"message":"` + message + `"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":` + data + `}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := github.NewClient(github.WithURLs(github.Ptr(server.URL+"/"), github.Ptr(server.URL+"/")))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	return client, func() []graphQLCall {
+		mu.Lock()
+		defer mu.Unlock()
+		return calls
+	}
+}
+
+func TestMergeMethod(t *testing.T) {
+	repo := &github.Repository{
+		FullName:         github.Ptr("giantswarm/devctl"),
+		AllowSquashMerge: github.Ptr(false),
+		AllowMergeCommit: github.Ptr(true),
+		AllowRebaseMerge: github.Ptr(true),
+	}
+
+	tests := []struct {
+		name      string
+		repo      *github.Repository
+		preferred string
+		expected  string
+		expectErr bool
+	}{
+		{name: "first allowed", repo: repo, expected: MergeMethodMerge},
+		{name: "preferred", repo: repo, preferred: MergeMethodRebase, expected: MergeMethodRebase},
+		{name: "preferred not allowed", repo: repo, preferred: MergeMethodSquash, expectErr: true},
+		{name: "nothing allowed", repo: &github.Repository{}, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MergeMethod(tt.repo, tt.preferred)
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("MergeMethod() = %q, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("MergeMethod() returned unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("MergeMethod() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestResolveMergeMode(t *testing.T) {
+	tests := []struct {
+		name           string
+		queue          string
+		allowAutoMerge bool
+		autoMerge      bool
+		expected       MergeMode
+	}{
+		{name: "merge queue", queue: `{"id":"MQ_1"}`, allowAutoMerge: true, expected: MergeModeQueue},
+		{name: "auto-merge allowed", queue: "null", allowAutoMerge: true, expected: MergeModeAutoMerge},
+		{name: "auto-merge enabled on the PR", queue: "null", autoMerge: true, expected: MergeModeAutoMerge},
+		{name: "direct", queue: "null", expected: MergeModeDirect},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, calls := fakeGraphQL(t, func(string, int) (string, string) {
+				return `{"repository":{"mergeQueue":` + tt.queue + `}}`, ""
+			})
+			repo := &github.Repository{
+				Owner:          &github.User{Login: github.Ptr("giantswarm")},
+				Name:           github.Ptr("devctl"),
+				AllowAutoMerge: github.Ptr(tt.allowAutoMerge),
+			}
+
+			got, err := ResolveMergeMode(context.Background(), client, repo, "main", tt.autoMerge)
+			if err != nil {
+				t.Fatalf("ResolveMergeMode() returned unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("ResolveMergeMode() = %q, want %q", got, tt.expected)
+			}
+
+			expected := []graphQLCall{{Operation: "mergeQueue", Variables: map[string]any{"owner": "giantswarm", "repo": "devctl", "branch": "main"}}}
+			if diff := cmp.Diff(expected, calls()); diff != "" {
+				t.Errorf("GraphQL calls mismatch (-expected +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestQueueForMerge(t *testing.T) {
+	// JSON numbers decode as float64.
+	stateCall := graphQLCall{Operation: "pullRequest", Variables: map[string]any{"owner": "giantswarm", "repo": "devctl", "number": float64(1)}}
+
+	tests := []struct {
+		name             string
+		autoMerge        bool
+		inQueue          bool
+		mode             MergeMode
+		checks           Checks
+		enqueueError     string
+		expectedPosition int
+		expectedCalls    []graphQLCall
+	}{
+		{
+			name:             "enqueue with passing checks",
+			mode:             MergeModeQueue,
+			checks:           ChecksPassing,
+			expectedPosition: 3,
+			expectedCalls: []graphQLCall{
+				stateCall,
+				{Operation: "enqueuePullRequest", Variables: map[string]any{"id": "PR_1"}},
+			},
+		},
+		{
+			name:             "in the queue already",
+			inQueue:          true,
+			mode:             MergeModeQueue,
+			checks:           ChecksPassing,
+			expectedPosition: 5,
+			expectedCalls:    []graphQLCall{stateCall},
+		},
+		{
+			name:   "queue once pending checks pass",
+			mode:   MergeModeQueue,
+			checks: ChecksPending,
+			expectedCalls: []graphQLCall{
+				stateCall,
+				{Operation: "enablePullRequestAutoMerge", Variables: map[string]any{"id": "PR_1", "method": nil}},
+			},
+		},
+		{
+			name:         "queue once enqueueing fails",
+			mode:         MergeModeQueue,
+			checks:       ChecksPassing,
+			enqueueError: "Pull request is not mergeable",
+			expectedCalls: []graphQLCall{
+				stateCall,
+				{Operation: "enqueuePullRequest", Variables: map[string]any{"id": "PR_1"}},
+				{Operation: "enablePullRequestAutoMerge", Variables: map[string]any{"id": "PR_1", "method": nil}},
+			},
+		},
+		{
+			name:   "auto-merge with the method",
+			mode:   MergeModeAutoMerge,
+			checks: ChecksPending,
+			expectedCalls: []graphQLCall{
+				{Operation: "enablePullRequestAutoMerge", Variables: map[string]any{"id": "PR_1", "method": "SQUASH"}},
+			},
+		},
+		{
+			name:      "auto-merge enabled already",
+			autoMerge: true,
+			mode:      MergeModeAutoMerge,
+			checks:    ChecksPending,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, calls := fakeGraphQL(t, func(operation string, _ int) (string, string) {
+				if operation == "pullRequest" {
+					if tt.inQueue {
+						return `{"repository":{"pullRequest":{"state":"OPEN","isInMergeQueue":true,"mergeQueueEntry":{"position":5}}}}`, ""
+					}
+					return `{"repository":{"pullRequest":{"state":"OPEN","isInMergeQueue":false}}}`, ""
+				}
+				if operation == "enqueuePullRequest" {
+					if tt.enqueueError != "" {
+						return "", tt.enqueueError
+					}
+					return `{"enqueuePullRequest":{"mergeQueueEntry":{"position":3}}}`, ""
+				}
+				return `{"enablePullRequestAutoMerge":{"clientMutationId":null}}`, ""
+			})
+			p := &github.PullRequest{
+				Number: github.Ptr(1),
+				NodeID: github.Ptr("PR_1"),
+				Base: &github.PullRequestBranch{
+					Repo: &github.Repository{Owner: &github.User{Login: github.Ptr("giantswarm")}, Name: github.Ptr("devctl")},
+				},
+			}
+			if tt.autoMerge {
+				p.AutoMerge = &github.PullRequestAutoMerge{}
+			}
+
+			position, err := QueueForMerge(context.Background(), client, p, tt.mode, MergeMethodSquash, tt.checks)
+			if err != nil {
+				t.Fatalf("QueueForMerge() returned unexpected error: %v", err)
+			}
+			if position != tt.expectedPosition {
+				t.Errorf("QueueForMerge() = %d, want %d", position, tt.expectedPosition)
+			}
+			if diff := cmp.Diff(tt.expectedCalls, calls()); diff != "" {
+				t.Errorf("GraphQL calls mismatch (-expected +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestQueueForMergeError(t *testing.T) {
+	client, _ := fakeGraphQL(t, func(string, int) (string, string) {
+		return "", "Pull request is in clean status"
+	})
+
+	_, err := QueueForMerge(context.Background(), client, &github.PullRequest{NodeID: github.Ptr("PR_1")}, MergeModeAutoMerge, MergeMethodSquash, ChecksPassing)
+	if err == nil || !strings.Contains(err.Error(), "clean status") {
+		t.Errorf("QueueForMerge() error = %v, want the GraphQL error", err)
+	}
+}
+
+func TestWaitForMerge(t *testing.T) {
+	const (
+		inQueue = `{"repository":{"pullRequest":{"state":"OPEN","isInMergeQueue":true,"mergeQueueEntry":{"position":2},"autoMergeRequest":null,"commits":{"nodes":[{"commit":{"statusCheckRollup":{"state":"SUCCESS"}}}]}}}}`
+		left    = `{"repository":{"pullRequest":{"state":"OPEN","isInMergeQueue":false,"mergeQueueEntry":null,"autoMergeRequest":null,"commits":{"nodes":[{"commit":{"statusCheckRollup":{"state":"SUCCESS"}}}]}}}}`
+		merged  = `{"repository":{"pullRequest":{"state":"MERGED","isInMergeQueue":false,"mergeQueueEntry":null,"autoMergeRequest":null,"commits":{"nodes":[]}}}}`
+		pending = `{"repository":{"pullRequest":{"state":"OPEN","isInMergeQueue":false,"mergeQueueEntry":null,"autoMergeRequest":{"enabledAt":"2026-10-19T12:00:00Z"},"commits":{"nodes":[{"commit":{"statusCheckRollup":{"state":"PENDING"}}}]}}}}`
+		failing = `{"repository":{"pullRequest":{"state":"OPEN","isInMergeQueue":false,"mergeQueueEntry":null,"autoMergeRequest":{"enabledAt":"2026-10-19T12:00:00Z"},"commits":{"nodes":[{"commit":{"statusCheckRollup":{"state":"FAILURE"}}}]}}}}`
+	)
+
+	tests := []struct {
+		name           string
+		states         []string
+		timeout        time.Duration
+		expectedAction Action
+		expectedReason string
+		expectedStatus string
+	}{
+		{
+			name:           "merged through the queue",
+			states:         []string{inQueue, left, merged},
+			timeout:        time.Minute,
+			expectedAction: ActionMerged,
+			expectedStatus: "Merged (merge queue)",
+		},
+		{
+			name:           "dequeued",
+			states:         []string{inQueue, left, left},
+			timeout:        time.Minute,
+			expectedAction: ActionFailed,
+			expectedReason: "Failed to merge (dequeued)",
+			expectedStatus: "Failed to merge (dequeued)",
+		},
+		{
+			name:           "auto-merge with failing checks",
+			states:         []string{pending, failing},
+			timeout:        time.Minute,
+			expectedAction: ActionFailed,
+			expectedReason: "Failed checks",
+			expectedStatus: "Failed checks",
+		},
+		{
+			name:           "still queued at the timeout",
+			states:         []string{pending},
+			expectedAction: ActionMergeQueued,
+			expectedStatus: "Queued to merge (auto-merge)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := fakeGraphQL(t, func(_ string, n int) (string, string) {
+				return tt.states[min(n, len(tt.states)-1)], ""
+			})
+			ps := &PRStatus{Owner: "giantswarm", Repo: "devctl", Number: 1}
+
+			action, reason := WaitForMerge(context.Background(), client, ps, tt.timeout, time.Millisecond)
+			if action != tt.expectedAction || reason != tt.expectedReason {
+				t.Errorf("WaitForMerge() = %q, %q, want %q, %q", action, reason, tt.expectedAction, tt.expectedReason)
+			}
+			if got := ps.GetStatus(); got != tt.expectedStatus {
+				t.Errorf("status = %q, want %q", got, tt.expectedStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package pr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProfilesFile is the file in the devctl config dir that holds the named
+// search profiles of the approval commands.
+const ProfilesFile = "pr-profiles.yaml"
+
+// profileNameRE matches the names profiles can be saved under.
+var profileNameRE = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// profiles is the layout of ProfilesFile:
+//
+//	profiles:
+//	  honeybadger:
+//	    bots: [renovate, dependabot]
+//	    reviewers: [giantswarm/team-honeybadger]
+//	    excludeLabels: [do-not-merge/hold]
+//	    minAge: 24h
+type profiles struct {
+	Profiles map[string]Search `yaml:"profiles"`
+}
+
+// ReadProfiles reads the named searches saved in the profiles file at path.
+// A missing file holds no profiles.
+func ReadProfiles(path string) (map[string]Search, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is in the devctl config dir
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[string]Search{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var p profiles
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	err = dec.Decode(&p)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if p.Profiles == nil {
+		p.Profiles = map[string]Search{}
+	}
+
+	for name, s := range p.Profiles {
+		if err := s.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: profile %q: %w", path, name, err)
+		}
+	}
+
+	return p.Profiles, nil
+}
+
+// ReadProfile returns the search saved under name in the profiles file at
+// path.
+func ReadProfile(path, name string) (Search, error) {
+	all, err := ReadProfiles(path)
+	if err != nil {
+		return Search{}, err
+	}
+
+	s, ok := all[name]
+	if !ok {
+		names := make([]string, 0, len(all))
+		for n := range all {
+			names = append(names, n)
+		}
+		slices.Sort(names)
+		return Search{}, fmt.Errorf("profile %q not found in %s, saved profiles: <%s>", name, path, strings.Join(names, "|"))
+	}
+
+	return s, nil
+}
+
+// SaveProfile saves s under name in the profiles file at path, replacing a
+// profile of the same name and keeping the others.
+func SaveProfile(path, name string, s Search) error {
+	if !profileNameRE.MatchString(name) {
+		return fmt.Errorf("profile name %q must match %s", name, profileNameRE)
+	}
+	if err := s.Validate(); err != nil {
+		return err
+	}
+
+	all, err := ReadProfiles(path)
+	if err != nil {
+		return err
+	}
+	all[name] = s
+
+	var data bytes.Buffer
+	enc := yaml.NewEncoder(&data)
+	enc.SetIndent(2)
+	err = enc.Encode(profiles{Profiles: all})
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data.Bytes(), 0600)
+}
@@ -0,0 +1,235 @@
+package pr
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+// Output formats of the approval commands.
+const (
+	// OutputTable is the live terminal table, or a static table for
+	// --report-only.
+	OutputTable = "table"
+	// OutputJSON is a Report as JSON.
+	OutputJSON = "json"
+	// OutputMarkdown is a Report as Markdown, e.g. for a daily digest.
+	OutputMarkdown = "markdown"
+)
+
+// AllOutputs returns the formats WriteReport and the --output flag accept.
+func AllOutputs() []string {
+	return []string{OutputTable, OutputJSON, OutputMarkdown}
+}
+
+// Report lists the PRs of one run of an approval command.
+type Report struct {
+	Command   string    `json:"command"`
+	Generated time.Time `json:"generated"`
+	// ReportOnly is set when the run took no action, its entries carry the
+	// Action the run would take instead of the Status it ended with.
+	ReportOnly bool          `json:"reportOnly"`
+	PRs        []ReportEntry `json:"prs"`
+}
+
+// ReportEntry is one PR of a Report.
+type ReportEntry struct {
+	// Group is the dependency or repository group of the PR, see
+	// GroupRenovatePRs and GroupRenovatePRsByRepo.
+	Group  string `json:"group"`
+	Owner  string `json:"owner"`
+	Repo   string `json:"repo"`
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	URL    string `json:"url"`
+	Branch string `json:"branch,omitempty"`
+
+	Checks Checks `json:"checks,omitempty"`
+	// Mergeable is the mergeable state GitHub reports, e.g. clean, behind,
+	// blocked or dirty.
+	Mergeable string `json:"mergeable,omitempty"`
+	Approved  bool   `json:"approved"`
+	AutoMerge bool   `json:"autoMerge"`
+	// Merge is how the PR gets merged once approved.
+	Merge MergeMode `json:"merge,omitempty"`
+
+	Update  UpdateType `json:"update,omitempty"`
+	Risk    string     `json:"risk,omitempty"`
+	Reasons []string   `json:"reasons,omitempty"`
+
+	// Action is what the command would do with the PR, see PlannedAction.
+	Action string `json:"action,omitempty"`
+	// Status is the status the PR ended with.
+	Status string `json:"status,omitempty"`
+}
+
+// SetAssessment sets the update type, risk and reasons of e from a.
+func (e *ReportEntry) SetAssessment(a Assessment) {
+	e.Update = a.Update
+	e.Risk = a.Risk.String()
+	e.Reasons = a.Reasons
+}
+
+// PlannedAction returns what an approval command would do with the PR of e,
+// given the decision of its risk policy.
+func PlannedAction(e ReportEntry, d Decision) string {
+	switch {
+	case d == DecisionSkip:
+		return "skip: " + strings.Join(e.Reasons, ", ")
+	case e.Checks == ChecksFailing:
+		return "skip: failed checks"
+	case e.Mergeable == "dirty":
+		return "skip: merge conflicts"
+	}
+
+	merge := "merge"
+	switch e.Merge {
+	case MergeModeQueue:
+		merge = "enqueue"
+	case MergeModeAutoMerge:
+		merge = "enable auto-merge"
+	case MergeModeNone:
+		merge = ""
+	}
+
+	var action string
+	switch {
+	case e.Approved && e.AutoMerge:
+		return "none: queued to merge"
+	case e.Approved && merge == "":
+		return "none: already approved"
+	case e.Approved:
+		action = merge
+	case e.AutoMerge:
+		action = "approve (auto-merge)"
+	case merge == "":
+		action = "approve"
+	default:
+		action = "approve and " + merge
+	}
+	if d == DecisionConfirm {
+		action = "ask, then " + action
+	}
+	// GitHub waits for the checks of queued PRs, the others wait in the
+	// command.
+	if e.Checks == ChecksPending && e.Merge != MergeModeQueue && e.Merge != MergeModeAutoMerge {
+		action = "wait for checks, then " + action
+	}
+
+	return action
+}
+
+// WriteReport writes r to w in the given format, one of AllOutputs. Entries
+// are listed by group, groups in the order their first entry appears.
+func WriteReport(w io.Writer, r Report, format string) error {
+	switch format {
+	case OutputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	case OutputMarkdown:
+		return writeMarkdown(w, r)
+	case OutputTable:
+		writeTable(w, r)
+		return nil
+	}
+
+	return fmt.Errorf("output %q is not one of <%s>", format, strings.Join(AllOutputs(), "|"))
+}
+
+// groupEntries returns the groups of entries in first-seen order.
+func groupEntries(entries []ReportEntry) ([]string, map[string][]ReportEntry) {
+	var names []string
+	groups := map[string][]ReportEntry{}
+	for _, e := range entries {
+		if !slices.Contains(names, e.Group) {
+			names = append(names, e.Group)
+		}
+		groups[e.Group] = append(groups[e.Group], e)
+	}
+
+	return names, groups
+}
+
+// outcome is the Action or Status of e, whichever the report carries.
+func (r Report) outcome(e ReportEntry) string {
+	if r.ReportOnly {
+		return e.Action
+	}
+	return e.Status
+}
+
+func (r Report) outcomeHeader() string {
+	if r.ReportOnly {
+		return "Would"
+	}
+	return "Status"
+}
+
+func writeMarkdown(w io.Writer, r Report) error {
+	names, groups := groupEntries(r.PRs)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s report\n\n", r.Command)
+	fmt.Fprintf(&b, "Generated %s: %d PRs in %d groups", r.Generated.UTC().Format("2006-01-02 15:04 MST"), len(r.PRs), len(names))
+	if r.ReportOnly {
+		b.WriteString(", no action taken")
+	}
+	b.WriteString(".\n")
+
+	cell := strings.NewReplacer("|", `\|`, "\n", " ")
+	for _, name := range names {
+		fmt.Fprintf(&b, "\n## %s (%d)\n\n", cell.Replace(name), len(groups[name]))
+		fmt.Fprintf(&b, "| PR | Title | Checks | Mergeable | Risk | %s |\n", r.outcomeHeader())
+		b.WriteString("|---|---|---|---|---|---|\n")
+		for _, e := range groups[name] {
+			fmt.Fprintf(&b, "| [%s/%s#%d](%s) | %s | %s | %s | %s | %s |\n",
+				e.Owner, e.Repo, e.Number, e.URL, cell.Replace(e.Title), orDash(string(e.Checks)), orDash(e.Mergeable), orDash(riskCell(e)), cell.Replace(r.outcome(e)))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeTable(w io.Writer, r Report) {
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.SetStyle(table.StyleDefault)
+	t.AppendHeader(table.Row{"GROUP", "PR", "CHECKS", "MERGEABLE", "RISK", strings.ToUpper(r.outcomeHeader())})
+
+	names, groups := groupEntries(r.PRs)
+	for _, name := range names {
+		for _, e := range groups[name] {
+			t.AppendRow(table.Row{
+				name,
+				fmt.Sprintf("%s/%s#%d", e.Owner, e.Repo, e.Number),
+				orDash(string(e.Checks)),
+				orDash(e.Mergeable),
+				orDash(riskCell(e)),
+				r.outcome(e),
+			})
+		}
+	}
+
+	t.Render()
+}
+
+func riskCell(e ReportEntry) string {
+	if e.Risk == "" {
+		return ""
+	}
+	return e.Risk + ": " + strings.Join(e.Reasons, ", ")
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,155 @@
+package pr
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPlannedAction(t *testing.T) {
+	tests := []struct {
+		name     string
+		entry    ReportEntry
+		decision Decision
+		expected string
+	}{
+		{
+			name:     "policy skip wins",
+			entry:    ReportEntry{Checks: ChecksPassing, Reasons: []string{"major update"}},
+			decision: DecisionSkip,
+			expected: "skip: major update",
+		},
+		{
+			name:     "failing checks",
+			entry:    ReportEntry{Checks: ChecksFailing},
+			decision: DecisionApprove,
+			expected: "skip: failed checks",
+		},
+		{
+			name:     "conflicts",
+			entry:    ReportEntry{Checks: ChecksPassing, Mergeable: "dirty"},
+			decision: DecisionApprove,
+			expected: "skip: merge conflicts",
+		},
+		{
+			name:     "approve and merge",
+			entry:    ReportEntry{Checks: ChecksPassing, Mergeable: "clean"},
+			decision: DecisionApprove,
+			expected: "approve and merge",
+		},
+		{
+			name:     "merge queue",
+			entry:    ReportEntry{Checks: ChecksPassing, Merge: MergeModeQueue},
+			decision: DecisionApprove,
+			expected: "approve and enqueue",
+		},
+		{
+			name:     "auto-merge does not wait for checks",
+			entry:    ReportEntry{Checks: ChecksPending, Merge: MergeModeAutoMerge},
+			decision: DecisionApprove,
+			expected: "approve and enable auto-merge",
+		},
+		{
+			name:     "approve only",
+			entry:    ReportEntry{Checks: ChecksPending, Merge: MergeModeNone},
+			decision: DecisionApprove,
+			expected: "wait for checks, then approve",
+		},
+		{
+			name:     "already approved with auto-merge",
+			entry:    ReportEntry{Checks: ChecksPassing, Approved: true, AutoMerge: true},
+			decision: DecisionApprove,
+			expected: "none: queued to merge",
+		},
+		{
+			name:     "already approved",
+			entry:    ReportEntry{Checks: ChecksPassing, Approved: true},
+			decision: DecisionApprove,
+			expected: "merge",
+		},
+		{
+			name:     "pending checks and confirmation",
+			entry:    ReportEntry{Checks: ChecksPending, AutoMerge: true},
+			decision: DecisionConfirm,
+			expected: "wait for checks, then ask, then approve (auto-merge)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := PlannedAction(tt.entry, tt.decision)
+			if got != tt.expected {
+				t.Errorf("PlannedAction() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func testReport() Report {
+	return Report{
+		Command:    "approve-merge-renovate",
+		Generated:  time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+		ReportOnly: true,
+		PRs: []ReportEntry{
+			{Group: "go-github", Owner: "giantswarm", Repo: "devctl", Number: 1, Title: "Update module github.com/google/go-github to v81", URL: "https://github.com/giantswarm/devctl/pull/1", Checks: ChecksPassing, Mergeable: "clean", Update: UpdateMajor, Risk: "high", Reasons: []string{"major update"}, Action: "skip: major update"},
+			{Group: "alpine", Owner: "giantswarm", Repo: "happa", Number: 2, Title: "Update alpine | docker tag", URL: "https://github.com/giantswarm/happa/pull/2", Checks: ChecksPending, Action: "wait for checks, then approve and merge"},
+			{Group: "go-github", Owner: "giantswarm", Repo: "happa", Number: 3, Title: "Update module github.com/google/go-github to v81", URL: "https://github.com/giantswarm/happa/pull/3", Checks: ChecksFailing, Action: "skip: failed checks"},
+		},
+	}
+}
+
+func TestWriteReportMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteReport(&buf, testReport(), OutputMarkdown)
+	if err != nil {
+		t.Fatalf("WriteReport() returned unexpected error: %v", err)
+	}
+
+	expected := `# approve-merge-renovate report
+
+Generated 2026-10-19 12:00 UTC: 3 PRs in 2 groups, no action taken.
+
+## go-github (2)
+
+| PR | Title | Checks | Mergeable | Risk | Would |
+|---|---|---|---|---|---|
+| [giantswarm/devctl#1](https://github.com/giantswarm/devctl/pull/1) | Update module github.com/google/go-github to v81 | passing | clean | high: major update | skip: major update |
+| [giantswarm/happa#3](https://github.com/giantswarm/happa/pull/3) | Update module github.com/google/go-github to v81 | failing | - | - | skip: failed checks |
+
+## alpine (1)
+
+| PR | Title | Checks | Mergeable | Risk | Would |
+|---|---|---|---|---|---|
+| [giantswarm/happa#2](https://github.com/giantswarm/happa/pull/2) | Update alpine \| docker tag | pending | - | - | wait for checks, then approve and merge |
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Errorf("WriteReport() mismatch (-expected +got):\n%s", diff)
+	}
+}
+
+func TestWriteReportJSON(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteReport(&buf, testReport(), OutputJSON)
+	if err != nil {
+		t.Fatalf("WriteReport() returned unexpected error: %v", err)
+	}
+
+	var got Report
+	err = json.Unmarshal(buf.Bytes(), &got)
+	if err != nil {
+		t.Fatalf("report is not valid JSON: %v", err)
+	}
+	if diff := cmp.Diff(testReport(), got); diff != "" {
+		t.Errorf("JSON round trip mismatch (-expected +got):\n%s", diff)
+	}
+}
+
+func TestWriteReportUnknownFormat(t *testing.T) {
+	err := WriteReport(&bytes.Buffer{}, testReport(), "yaml")
+	if err == nil {
+		t.Errorf("WriteReport() returned no error for an unknown format")
+	}
+}
@@ -0,0 +1,326 @@
+package pr
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// UpdateType is the kind of dependency update a PR makes.
+type UpdateType string
+
+// Update types, as Renovate names them in the Update column of its PR body.
+const (
+	UpdateMajor    UpdateType = "major"
+	UpdateMinor    UpdateType = "minor"
+	UpdatePatch    UpdateType = "patch"
+	UpdateDigest   UpdateType = "digest"
+	UpdatePin      UpdateType = "pin"
+	UpdateLockfile UpdateType = "lockfile"
+	UpdateUnknown  UpdateType = "unknown"
+)
+
+// Risk is how likely merging a PR unattended breaks something.
+type Risk int
+
+const (
+	RiskLow Risk = iota
+	RiskMedium
+	RiskHigh
+)
+
+// AllRisks returns the names ParseRisk accepts, lowest first.
+func AllRisks() []string {
+	return []string{RiskLow.String(), RiskMedium.String(), RiskHigh.String()}
+}
+
+// ParseRisk returns the risk of the given name, see AllRisks.
+func ParseRisk(s string) (Risk, error) {
+	i := slices.Index(AllRisks(), s)
+	if i < 0 {
+		return 0, fmt.Errorf("risk %q is not one of <%s>", s, strings.Join(AllRisks(), "|"))
+	}
+	return Risk(i), nil
+}
+
+func (r Risk) String() string {
+	switch r {
+	case RiskLow:
+		return "low"
+	case RiskMedium:
+		return "medium"
+	default:
+		return "high"
+	}
+}
+
+// updateRisks is the risk of each update type. Lockfile maintenance, digest
+// and pin updates keep the declared versions, patch updates are expected to
+// be compatible, and minor updates often are. Updates that cannot be
+// classified are treated like minor ones.
+var updateRisks = map[UpdateType]Risk{
+	UpdateLockfile: RiskLow,
+	UpdatePin:      RiskLow,
+	UpdateDigest:   RiskLow,
+	UpdatePatch:    RiskLow,
+	UpdateMinor:    RiskMedium,
+	UpdateUnknown:  RiskMedium,
+	UpdateMajor:    RiskHigh,
+}
+
+// updateRank orders update types by severity, for PRs updating several
+// dependencies at once.
+var updateRank = []UpdateType{UpdateLockfile, UpdatePin, UpdateDigest, UpdatePatch, UpdateMinor, UpdateUnknown, UpdateMajor}
+
+// renovateUpdates maps the values of the Update column of the Renovate PR
+// body to update types.
+var renovateUpdates = map[string]UpdateType{
+	"major":               UpdateMajor,
+	"minor":               UpdateMinor,
+	"patch":               UpdatePatch,
+	"digest":              UpdateDigest,
+	"pindigest":           UpdatePin,
+	"pin":                 UpdatePin,
+	"lockfilemaintenance": UpdateLockfile,
+	"lockfileupdate":      UpdateLockfile,
+}
+
+var (
+	// changeRE matches the Change column of the Renovate PR body, e.g.
+	// "`v1.2.3` -> `v1.2.4`", possibly wrapped in a compare link.
+	changeRE = regexp.MustCompile("`([^`]+)`\\s*(?:->|→)\\s*`([^`]+)`")
+	// bumpRE matches Dependabot titles, e.g. "Bump x from 1.2.3 to 1.2.4".
+	bumpRE = regexp.MustCompile(`(?i)\bfrom v?(\d[\w.+-]*) to v?(\d[\w.+-]*)`)
+	// majorOnlyRE matches Renovate titles of major updates, which name the
+	// new major only, e.g. "Update module x to v2".
+	majorOnlyRE = regexp.MustCompile(`(?i)\bto v?\d+(?: \[SECURITY\])?$`)
+)
+
+// ClassifyUpdate returns the update type of a bot PR. The dependency table
+// of the Renovate PR body is preferred, the title is the fallback. A PR
+// updating several dependencies gets the most severe of their types.
+func ClassifyUpdate(title, body string) UpdateType {
+	if t, ok := classifyRenovateBody(body); ok {
+		return t
+	}
+
+	lower := strings.ToLower(title)
+	switch {
+	case strings.Contains(lower, "lock file maintenance"):
+		return UpdateLockfile
+	case strings.Contains(lower, " digest to "):
+		return UpdateDigest
+	case strings.HasPrefix(lower, "pin ") || strings.Contains(lower, "): pin "):
+		return UpdatePin
+	}
+
+	if m := bumpRE.FindStringSubmatch(title); m != nil {
+		return compareVersions(m[1], m[2])
+	}
+	if majorOnlyRE.MatchString(title) {
+		return UpdateMajor
+	}
+
+	return UpdateUnknown
+}
+
+// classifyRenovateBody returns the most severe update type listed in the
+// dependency table of a Renovate PR body, from its Update column or else by
+// comparing the versions in its Change column.
+func classifyRenovateBody(body string) (UpdateType, bool) {
+	var found []UpdateType
+	for _, row := range renovateTable(body) {
+		if t, ok := rowUpdate(row); ok {
+			found = append(found, t)
+			continue
+		}
+		if m := changeRE.FindStringSubmatch(row["Change"]); m != nil {
+			found = append(found, compareVersions(m[1], m[2]))
+		}
+	}
+
+	if len(found) == 0 {
+		return "", false
+	}
+
+	return slices.MaxFunc(found, func(a, b UpdateType) int {
+		return slices.Index(updateRank, a) - slices.Index(updateRank, b)
+	}), true
+}
+
+// renovateTable returns the rows of the dependency table of a Renovate PR
+// body, cells keyed by their column header, e.g. Package, Type, Update and
+// Change. Only the first table with one of these columns is the dependency
+// table, later ones are part of release notes.
+func renovateTable(body string) []map[string]string {
+	var header []string
+	var rows []map[string]string
+
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "|") {
+			if len(rows) > 0 {
+				break
+			}
+			header = nil
+			continue
+		}
+		cells := strings.Split(strings.Trim(line, "|"), "|")
+		for i := range cells {
+			cells[i] = strings.TrimSpace(cells[i])
+		}
+
+		if header == nil {
+			header = cells
+			continue
+		}
+		if !slices.ContainsFunc(header, func(h string) bool { return h == "Package" || h == "Update" || h == "Change" }) {
+			continue
+		}
+		if strings.HasPrefix(cells[0], "---") || strings.HasPrefix(cells[0], ":-") {
+			continue
+		}
+
+		row := map[string]string{}
+		for i, h := range header {
+			if i < len(cells) {
+				row[h] = cells[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+// rowUpdate returns the update type in the Update column of a row of
+// renovateTable.
+func rowUpdate(row map[string]string) (UpdateType, bool) {
+	v := strings.ToLower(strings.Trim(row["Update"], "`* "))
+	t, ok := renovateUpdates[strings.ReplaceAll(v, " ", "")]
+	return t, ok
+}
+
+// compareVersions returns the update type of an update from one version to
+// another. Versions that are not semver, like digests, are unknown.
+func compareVersions(from, to string) UpdateType {
+	f, err := semver.NewVersion(from)
+	if err != nil {
+		return UpdateUnknown
+	}
+	t, err := semver.NewVersion(to)
+	if err != nil {
+		return UpdateUnknown
+	}
+
+	switch {
+	case f.Major() != t.Major():
+		return UpdateMajor
+	case f.Minor() != t.Minor():
+		return UpdateMinor
+	default:
+		return UpdatePatch
+	}
+}
+
+// ChangedFile is a file a PR changes.
+type ChangedFile struct {
+	Path string
+	// Patch is the unified diff of the file, empty for binary or very large
+	// files.
+	Patch string
+}
+
+// sensitivePath returns the risk of a PR changing file, and why, when file
+// is one that build or deployment depend on.
+func sensitivePath(file ChangedFile) (Risk, string, bool) {
+	base := path.Base(file.Path)
+
+	switch {
+	case file.Path == "go.mod" || strings.HasSuffix(file.Path, "/go.mod"):
+		for _, l := range strings.Split(file.Patch, "\n") {
+			if strings.HasPrefix(l, "+") && strings.Contains(l, "=>") {
+				return RiskHigh, "adds replace directive to " + file.Path, true
+			}
+		}
+	case strings.HasPrefix(file.Path, "helm/"):
+		return RiskMedium, "changes helm/", true
+	case base == "Dockerfile" || strings.HasPrefix(base, "Dockerfile.") || strings.HasSuffix(base, ".Dockerfile"):
+		return RiskMedium, "changes " + file.Path, true
+	}
+
+	return 0, "", false
+}
+
+// Assessment is the risk of merging a PR unattended.
+type Assessment struct {
+	Update UpdateType
+	Risk   Risk
+	// Reasons name what raised the risk, e.g. "major update" or "changes
+	// helm/".
+	Reasons []string
+}
+
+// Assess returns the risk of merging the PR with the given title and body,
+// which changes files: the risk of its update type, raised by changes to
+// sensitive paths.
+func Assess(title, body string, files []ChangedFile) Assessment {
+	update := ClassifyUpdate(title, body)
+	a := Assessment{
+		Update:  update,
+		Risk:    updateRisks[update],
+		Reasons: []string{string(update) + " update"},
+	}
+
+	for _, f := range files {
+		risk, reason, ok := sensitivePath(f)
+		if !ok || slices.Contains(a.Reasons, reason) {
+			continue
+		}
+		a.Reasons = append(a.Reasons, reason)
+		a.Risk = max(a.Risk, risk)
+	}
+
+	return a
+}
+
+// String formats the assessment as e.g. "high: major update, changes helm/".
+func (a Assessment) String() string {
+	return a.Risk.String() + ": " + strings.Join(a.Reasons, ", ")
+}
+
+// Decision is what a Policy does with a PR.
+type Decision int
+
+const (
+	// DecisionApprove approves the PR without asking.
+	DecisionApprove Decision = iota
+	// DecisionConfirm approves the PR once the user confirms it.
+	DecisionConfirm
+	// DecisionSkip leaves the PR alone.
+	DecisionSkip
+)
+
+// Policy decides which PRs are approved by their risk.
+type Policy struct {
+	// AutoApprove is the highest risk approved without asking.
+	AutoApprove Risk
+	// Max is the highest risk approved at all, after confirmation.
+	Max Risk
+}
+
+// Decide returns what p does with a PR of the given assessment.
+func (p Policy) Decide(a Assessment) Decision {
+	switch {
+	case a.Risk <= p.AutoApprove:
+		return DecisionApprove
+	case a.Risk <= p.Max:
+		return DecisionConfirm
+	default:
+		return DecisionSkip
+	}
+}
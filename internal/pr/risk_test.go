@@ -0,0 +1,170 @@
+package pr
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const renovateBodyChange = `This PR contains the following updates:
+
+| Package | Change | Age | Confidence |
+|---|---|---|---|
+| [github.com/google/go-github/v80](https://redirect.github.com/google/go-github) | ` + "`v80.0.0` -> `v81.0.0`" + ` | [![age](https://developer.mend.io/api/mc/badges/age/go/github.com%2fgoogle%2fgo-github%2fv80/v81.0.0?slim=true)](https://docs.renovatebot.com/merge-confidence/) | [![confidence](https://developer.mend.io/api/mc/badges/confidence/go/github.com%2fgoogle%2fgo-github%2fv80/v80.0.0/v81.0.0?slim=true)](https://docs.renovatebot.com/merge-confidence/) |
+
+---
+
+### Release Notes
+
+| Change | Note |
+|---|---|
+| ` + "`v1.0.0` -> `v9.0.0`" + ` | unrelated table |
+`
+
+const renovateBodyUpdate = `This PR contains the following updates:
+
+| Package | Type | Update | Change |
+|---|---|---|---|
+| alpine | final | patch | ` + "`3.19.0` -> `3.19.1`" + ` |
+| [golang](https://redirect.github.com/docker-library/golang) | stage | minor | ` + "`1.24.3` -> `1.25.0`" + ` |
+`
+
+const renovateBodyLockfile = `This PR contains the following updates:
+
+| Update | Change |
+|---|---|
+| lockFileMaintenance | All locks refreshed |
+`
+
+func TestClassifyUpdate(t *testing.T) {
+	tests := []struct {
+		name     string
+		title    string
+		body     string
+		expected UpdateType
+	}{
+		{
+			name:     "renovate change column",
+			title:    "Update module github.com/google/go-github/v80 to v81",
+			body:     renovateBodyChange,
+			expected: UpdateMajor,
+		},
+		{
+			name:     "renovate update column takes the most severe",
+			title:    "Update docker images",
+			body:     renovateBodyUpdate,
+			expected: UpdateMinor,
+		},
+		{
+			name:     "renovate lock file maintenance",
+			title:    "Lock file maintenance",
+			body:     renovateBodyLockfile,
+			expected: UpdateLockfile,
+		},
+		{
+			name:     "digest title",
+			title:    "Update k8s.io/utils digest to 0fe9cd7",
+			expected: UpdateDigest,
+		},
+		{
+			name:     "pin title",
+			title:    "chore(deps): pin dependencies",
+			expected: UpdatePin,
+		},
+		{
+			name:     "dependabot patch",
+			title:    "Bump golang.org/x/net from 0.38.0 to 0.38.1",
+			expected: UpdatePatch,
+		},
+		{
+			name:     "renovate major title",
+			title:    "Update giantswarm/install-binary-action action to v4",
+			expected: UpdateMajor,
+		},
+		{
+			name:     "renovate title without the old version",
+			title:    "Update dependency storybook to v7.6.21 [SECURITY]",
+			expected: UpdateUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ClassifyUpdate(tt.title, tt.body)
+			if got != tt.expected {
+				t.Errorf("ClassifyUpdate(%q) = %q, want %q", tt.title, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAssess(t *testing.T) {
+	tests := []struct {
+		name     string
+		title    string
+		files    []ChangedFile
+		expected Assessment
+	}{
+		{
+			name:     "patch update",
+			title:    "Bump golang.org/x/net from 0.38.0 to 0.38.1",
+			files:    []ChangedFile{{Path: "go.mod", Patch: "-\tgolang.org/x/net v0.38.0\n+\tgolang.org/x/net v0.38.1"}, {Path: "go.sum"}},
+			expected: Assessment{Update: UpdatePatch, Risk: RiskLow, Reasons: []string{"patch update"}},
+		},
+		{
+			name:  "digest update of the chart and Dockerfile",
+			title: "Update gsoci.azurecr.io/giantswarm/alpine digest to 1a2b3c4",
+			files: []ChangedFile{{Path: "helm/app/values.yaml"}, {Path: "helm/app/Chart.yaml"}, {Path: "Dockerfile"}},
+			expected: Assessment{
+				Update:  UpdateDigest,
+				Risk:    RiskMedium,
+				Reasons: []string{"digest update", "changes helm/", "changes Dockerfile"},
+			},
+		},
+		{
+			name:  "replace directive",
+			title: "Bump k8s.io/client-go from 0.33.1 to 0.33.2",
+			files: []ChangedFile{{Path: "go.mod", Patch: "+replace k8s.io/client-go => k8s.io/client-go v0.33.2"}},
+			expected: Assessment{
+				Update:  UpdatePatch,
+				Risk:    RiskHigh,
+				Reasons: []string{"patch update", "adds replace directive to go.mod"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Assess(tt.title, "", tt.files)
+			if diff := cmp.Diff(tt.expected, got); diff != "" {
+				t.Errorf("Assess() mismatch (-expected +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPolicyDecide(t *testing.T) {
+	policy := Policy{AutoApprove: RiskLow, Max: RiskMedium}
+
+	tests := []struct {
+		risk     Risk
+		expected Decision
+	}{
+		{risk: RiskLow, expected: DecisionApprove},
+		{risk: RiskMedium, expected: DecisionConfirm},
+		{risk: RiskHigh, expected: DecisionSkip},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.risk.String(), func(t *testing.T) {
+			got := policy.Decide(Assessment{Risk: tt.risk})
+			if got != tt.expected {
+				t.Errorf("Decide(%s) = %d, want %d", tt.risk, got, tt.expected)
+			}
+		})
+	}
+
+	if _, err := ParseRisk("critical"); err == nil {
+		t.Errorf("expected an error for an unknown risk")
+	}
+}
@@ -0,0 +1,187 @@
+package pr
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Bots whose PRs the approval commands can search for, by the name the
+// --bot flag takes.
+const (
+	BotRenovate   = "renovate"
+	BotDependabot = "dependabot"
+	BotAlignFiles = "align-files"
+)
+
+// AlignFilesTitle is the title of the PRs the align-files workflow opens.
+const AlignFilesTitle = "chore: align files according to platform standards"
+
+// ReviewerMe is the reviewer matching PRs requesting review from the
+// authenticated user.
+const ReviewerMe = "@me"
+
+// botQualifiers are the search qualifiers selecting the PRs of each bot. The
+// align-files PRs are opened with a personal token, so they are found by
+// their title.
+var botQualifiers = map[string]string{
+	BotRenovate:   "author:app/renovate",
+	BotDependabot: "author:app/dependabot",
+	BotAlignFiles: fmt.Sprintf("in:title %q", AlignFilesTitle),
+}
+
+// AllBots returns the bots Search.Bots accepts.
+func AllBots() []string {
+	return []string{BotRenovate, BotDependabot, BotAlignFiles}
+}
+
+// Search selects the open PRs the approval commands process. Empty Bots and
+// Reviewers default to Renovate PRs requesting review from the user.
+type Search struct {
+	// Bots are the bots whose PRs to match, see AllBots.
+	Bots []string `json:"bots,omitempty" yaml:"bots,omitempty"`
+	// Reviewers are the reviewers the PRs must request: ReviewerMe, a user
+	// or an org/team.
+	Reviewers []string `json:"reviewers,omitempty" yaml:"reviewers,omitempty"`
+	// Labels must all be set on the PRs.
+	Labels []string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	// ExcludeLabels must not be set on the PRs.
+	ExcludeLabels []string `json:"excludeLabels,omitempty" yaml:"excludeLabels,omitempty"`
+	// Repos, as owner/name, limit the search to these repositories.
+	Repos []string `json:"repos,omitempty" yaml:"repos,omitempty"`
+	// ExcludeRepos, as owner/name, are left out of the search.
+	ExcludeRepos []string `json:"excludeRepos,omitempty" yaml:"excludeRepos,omitempty"`
+	// MinAge skips PRs opened less than MinAge ago, e.g. to let a release
+	// settle before merging it.
+	MinAge time.Duration `json:"minAge,omitempty" yaml:"minAge,omitempty"`
+}
+
+// Validate returns an error naming the first invalid field of s.
+func (s Search) Validate() error {
+	for _, b := range s.Bots {
+		if !slices.Contains(AllBots(), b) {
+			return fmt.Errorf("bot %q is not one of <%s>", b, strings.Join(AllBots(), "|"))
+		}
+	}
+	for _, r := range s.Reviewers {
+		if r == "" || strings.Count(r, "/") > 1 || strings.HasPrefix(r, "/") || strings.HasSuffix(r, "/") {
+			return fmt.Errorf("reviewer %q must be %s, a user or an org/team", r, ReviewerMe)
+		}
+	}
+	for _, r := range append(slices.Clone(s.Repos), s.ExcludeRepos...) {
+		owner, name, ok := strings.Cut(r, "/")
+		if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+			return fmt.Errorf("repository %q must be owner/name", r)
+		}
+	}
+	if s.MinAge < 0 {
+		return fmt.Errorf("minimum age %s must not be negative", s.MinAge)
+	}
+
+	return nil
+}
+
+// Queries returns the GitHub search queries matching s, prefixed with the
+// given free-text terms. GitHub ANDs qualifiers of different kinds, so there
+// is one query per bot and reviewer; callers merge their results. now is the
+// time MinAge is counted back from.
+func (s Search) Queries(terms string, now time.Time) []string {
+	bots := s.Bots
+	if len(bots) == 0 {
+		bots = []string{BotRenovate}
+	}
+	reviewers := s.Reviewers
+	if len(reviewers) == 0 {
+		reviewers = []string{ReviewerMe}
+	}
+
+	var common []string
+	if terms != "" {
+		common = append(common, terms)
+	}
+	common = append(common, "is:pr", "is:open", "archived:false")
+	for _, l := range s.Labels {
+		common = append(common, "label:"+quoteQualifier(l))
+	}
+	for _, l := range s.ExcludeLabels {
+		common = append(common, "-label:"+quoteQualifier(l))
+	}
+	// Repeated repo qualifiers are ORed, which is what an allow list means.
+	for _, r := range s.Repos {
+		common = append(common, "repo:"+r)
+	}
+	for _, r := range s.ExcludeRepos {
+		common = append(common, "-repo:"+r)
+	}
+	if s.MinAge > 0 {
+		common = append(common, "created:<="+now.Add(-s.MinAge).UTC().Format(time.RFC3339))
+	}
+
+	var queries []string
+	for _, b := range bots {
+		for _, r := range reviewers {
+			q := append(slices.Clone(common), reviewerQualifier(r), botQualifiers[b])
+			queries = append(queries, strings.Join(q, " "))
+		}
+	}
+
+	return queries
+}
+
+// AllowsRepo reports whether the repository owner/name passes the Repos and
+// ExcludeRepos lists. Search results are checked with it too, since the
+// terms of a query can carry repo qualifiers of their own.
+func (s Search) AllowsRepo(owner, name string) bool {
+	repo := owner + "/" + name
+	match := func(r string) bool { return strings.EqualFold(r, repo) }
+
+	if slices.ContainsFunc(s.ExcludeRepos, match) {
+		return false
+	}
+
+	return len(s.Repos) == 0 || slices.ContainsFunc(s.Repos, match)
+}
+
+// Merge returns s with the fields set in other replacing its own.
+func (s Search) Merge(other Search) Search {
+	if len(other.Bots) > 0 {
+		s.Bots = other.Bots
+	}
+	if len(other.Reviewers) > 0 {
+		s.Reviewers = other.Reviewers
+	}
+	if len(other.Labels) > 0 {
+		s.Labels = other.Labels
+	}
+	if len(other.ExcludeLabels) > 0 {
+		s.ExcludeLabels = other.ExcludeLabels
+	}
+	if len(other.Repos) > 0 {
+		s.Repos = other.Repos
+	}
+	if len(other.ExcludeRepos) > 0 {
+		s.ExcludeRepos = other.ExcludeRepos
+	}
+	if other.MinAge > 0 {
+		s.MinAge = other.MinAge
+	}
+
+	return s
+}
+
+func reviewerQualifier(reviewer string) string {
+	if strings.Contains(reviewer, "/") {
+		return "team-review-requested:" + reviewer
+	}
+	return "review-requested:" + reviewer
+}
+
+// quoteQualifier quotes qualifier values with spaces, like the label
+// "dependencies :robot:".
+func quoteQualifier(v string) string {
+	if strings.ContainsAny(v, " \t") {
+		return fmt.Sprintf("%q", v)
+	}
+	return v
+}
@@ -0,0 +1,147 @@
+package pr
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSearchQueries(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		search   Search
+		terms    string
+		expected []string
+	}{
+		{
+			name:  "defaults to renovate PRs requesting review from me",
+			terms: `"architect v1.2.3"`,
+			expected: []string{
+				`"architect v1.2.3" is:pr is:open archived:false review-requested:@me author:app/renovate`,
+			},
+		},
+		{
+			name: "one query per bot and team reviewer",
+			search: Search{
+				Bots:      []string{BotRenovate, BotAlignFiles},
+				Reviewers: []string{"giantswarm/team-honeybadger"},
+			},
+			expected: []string{
+				`is:pr is:open archived:false team-review-requested:giantswarm/team-honeybadger author:app/renovate`,
+				`is:pr is:open archived:false team-review-requested:giantswarm/team-honeybadger in:title "chore: align files according to platform standards"`,
+			},
+		},
+		{
+			name: "labels, repos and age",
+			search: Search{
+				Bots:          []string{BotDependabot},
+				Labels:        []string{"dependencies"},
+				ExcludeLabels: []string{"do-not-merge/hold", "needs review"},
+				Repos:         []string{"giantswarm/devctl", "giantswarm/happa"},
+				ExcludeRepos:  []string{"giantswarm/backstage"},
+				MinAge:        24 * time.Hour,
+			},
+			expected: []string{
+				`is:pr is:open archived:false label:dependencies -label:do-not-merge/hold -label:"needs review" repo:giantswarm/devctl repo:giantswarm/happa -repo:giantswarm/backstage created:<=2026-10-18T12:00:00Z review-requested:@me author:app/dependabot`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.search.Queries(tt.terms, now)
+			if diff := cmp.Diff(tt.expected, result); diff != "" {
+				t.Errorf("Queries() mismatch (-expected +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSearchValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		search  Search
+		wantErr bool
+	}{
+		{name: "empty", search: Search{}},
+		{name: "team and user reviewers", search: Search{Reviewers: []string{ReviewerMe, "octocat", "giantswarm/team-honeybadger"}}},
+		{name: "unknown bot", search: Search{Bots: []string{"snyk"}}, wantErr: true},
+		{name: "reviewer with two slashes", search: Search{Reviewers: []string{"giantswarm/teams/honeybadger"}}, wantErr: true},
+		{name: "repo without owner", search: Search{Repos: []string{"devctl"}}, wantErr: true},
+		{name: "negative age", search: Search{MinAge: -time.Hour}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.search.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSearchAllowsRepo(t *testing.T) {
+	s := Search{
+		Repos:        []string{"giantswarm/devctl", "giantswarm/happa"},
+		ExcludeRepos: []string{"giantswarm/happa"},
+	}
+
+	if !s.AllowsRepo("giantswarm", "DevCtl") {
+		t.Errorf("expected giantswarm/DevCtl to be allowed")
+	}
+	if s.AllowsRepo("giantswarm", "happa") {
+		t.Errorf("expected excluded giantswarm/happa to be denied")
+	}
+	if s.AllowsRepo("giantswarm", "backstage") {
+		t.Errorf("expected giantswarm/backstage outside the allow list to be denied")
+	}
+	if !(Search{}).AllowsRepo("giantswarm", "backstage") {
+		t.Errorf("expected an empty allow list to allow every repository")
+	}
+}
+
+func TestProfiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "devctl", ProfilesFile)
+
+	honeybadger := Search{
+		Bots:          []string{BotRenovate, BotDependabot},
+		Reviewers:     []string{"giantswarm/team-honeybadger"},
+		ExcludeLabels: []string{"do-not-merge/hold"},
+		MinAge:        24 * time.Hour,
+	}
+	if err := SaveProfile(path, "honeybadger", honeybadger); err != nil {
+		t.Fatalf("SaveProfile() returned unexpected error: %v", err)
+	}
+	if err := SaveProfile(path, "tenet", Search{Repos: []string{"giantswarm/cluster-aws"}}); err != nil {
+		t.Fatalf("SaveProfile() returned unexpected error: %v", err)
+	}
+
+	result, err := ReadProfile(path, "honeybadger")
+	if err != nil {
+		t.Fatalf("ReadProfile() returned unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(honeybadger, result); diff != "" {
+		t.Errorf("ReadProfile() mismatch (-expected +got):\n%s", diff)
+	}
+
+	// Flags given on the command line replace the profile's fields.
+	merged := result.Merge(Search{Reviewers: []string{ReviewerMe}})
+	if diff := cmp.Diff([]string{ReviewerMe}, merged.Reviewers); diff != "" {
+		t.Errorf("Merge() reviewers mismatch (-expected +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(honeybadger.Bots, merged.Bots); diff != "" {
+		t.Errorf("Merge() bots mismatch (-expected +got):\n%s", diff)
+	}
+
+	if _, err := ReadProfile(path, "rocket"); err == nil {
+		t.Errorf("expected an error for a profile that was not saved")
+	}
+	if err := SaveProfile(path, "../rocket", Search{}); err == nil {
+		t.Errorf("expected an error for an invalid profile name")
+	}
+}
@@ -0,0 +1,289 @@
+package pr
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StateFile is the file in the devctl config dir the approval commands log
+// their actions to, one JSON record per line.
+const StateFile = "pr-state.jsonl"
+
+// Action is what an approval command did with a PR.
+type Action string
+
+const (
+	// ActionStarted opens a run. Its record carries the query and search of
+	// the run instead of a PR.
+	ActionStarted Action = "started"
+	// ActionApproved is an approval review.
+	ActionApproved Action = "approved"
+	// ActionMergeQueued is an approved PR left to auto-merge or a merge
+	// queue.
+	ActionMergeQueued Action = "merge-queued"
+	// ActionMerged is a merged PR.
+	ActionMerged Action = "merged"
+	// ActionSkipped is a PR left alone, with the reason.
+	ActionSkipped Action = "skipped"
+	// ActionFailed is a PR that could not be approved or merged, with the
+	// reason.
+	ActionFailed Action = "failed"
+)
+
+// AllActions returns the actions of PR records, without ActionStarted.
+func AllActions() []Action {
+	return []Action{ActionApproved, ActionMergeQueued, ActionMerged, ActionSkipped, ActionFailed}
+}
+
+// Done reports whether a PR with a as its last action needs no more work
+// from the run that recorded it. Failed PRs are retried, their checks may
+// have been re-run since. PRs skipped for their risk are taken up again by a
+// run whose policy lets them through, see Record.Reconsider.
+func (a Action) Done() bool {
+	switch a {
+	case ActionMerged, ActionSkipped:
+		return true
+	}
+	return false
+}
+
+// Record is one line of the state file.
+type Record struct {
+	Time    time.Time `json:"time"`
+	Run     string    `json:"run"`
+	Command string    `json:"command"`
+	Action  Action    `json:"action"`
+
+	// Query, Group and Search are set on ActionStarted records. Group is the
+	// dependency group picked interactively, whose PRs the run is limited to.
+	Query  string  `json:"query,omitempty"`
+	Group  string  `json:"group,omitempty"`
+	Search *Search `json:"search,omitempty"`
+
+	Owner  string `json:"owner,omitempty"`
+	Repo   string `json:"repo,omitempty"`
+	Number int    `json:"number,omitempty"`
+	Title  string `json:"title,omitempty"`
+	URL    string `json:"url,omitempty"`
+	Reason string `json:"reason,omitempty"`
+	// Risk is set on ActionSkipped records of PRs the risk policy skipped.
+	Risk string `json:"risk,omitempty"`
+}
+
+// Reconsider reports whether a resumed run under policy takes up the PR of r
+// again although its action is done: it was skipped for a risk policy now
+// lets through, e.g. after raising --max-risk.
+func (r Record) Reconsider(policy Policy) bool {
+	if r.Action != ActionSkipped || r.Risk == "" {
+		return false
+	}
+	risk, err := ParseRisk(r.Risk)
+	if err != nil {
+		return false
+	}
+	return policy.Decide(Assessment{Risk: risk}) != DecisionSkip
+}
+
+// StateLog appends the actions of one run of an approval command to the
+// state file. It is safe for concurrent use.
+type StateLog struct {
+	path    string
+	command string
+	run     string
+
+	mu sync.Mutex
+}
+
+// NewStateLog returns the log of a new run of command, continuing run when
+// it is not empty, e.g. for --resume.
+func NewStateLog(path, command, run string) *StateLog {
+	if run == "" {
+		run = time.Now().UTC().Format("20060102T150405.000Z")
+	}
+
+	return &StateLog{
+		path:    path,
+		command: command,
+		run:     run,
+	}
+}
+
+// Run returns the ID of the run the log records.
+func (l *StateLog) Run() string {
+	return l.run
+}
+
+// Start records the start of the run with the query, group and search it
+// uses.
+func (l *StateLog) Start(query, group string, search Search) error {
+	return l.append(Record{Action: ActionStarted, Query: query, Group: group, Search: &search})
+}
+
+// Record records action on the PR of ps, with the reason for skipped and
+// failed PRs.
+func (l *StateLog) Record(ps *PRStatus, action Action, reason string) error {
+	return l.append(Record{
+		Action: action,
+		Owner:  ps.Owner,
+		Repo:   ps.Repo,
+		Number: ps.Number,
+		Title:  ps.Title,
+		URL:    ps.URL,
+		Reason: reason,
+	})
+}
+
+// RecordRiskSkip records that the risk policy skipped the PR of ps for the
+// assessment a.
+func (l *StateLog) RecordRiskSkip(ps *PRStatus, a Assessment) error {
+	return l.append(Record{
+		Action: ActionSkipped,
+		Owner:  ps.Owner,
+		Repo:   ps.Repo,
+		Number: ps.Number,
+		Title:  ps.Title,
+		URL:    ps.URL,
+		Reason: a.String(),
+		Risk:   a.Risk.String(),
+	})
+}
+
+func (l *StateLog) append(r Record) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	r.Time = time.Now().UTC()
+	r.Run = l.run
+	r.Command = l.command
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(l.path), 0700)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600) // #nosec G304 -- path is in the devctl config dir
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(data, '\n'))
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// ReadState returns the records of the state file at path, oldest first. A
+// missing file holds no records.
+func ReadState(path string) ([]Record, error) {
+	f, err := os.Open(path) // #nosec G304 -- path is in the devctl config dir
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var r Record
+		err := json.Unmarshal(scanner.Bytes(), &r)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		records = append(records, r)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// RunState is what a previous run of an approval command did.
+type RunState struct {
+	Run    string
+	Query  string
+	Group  string
+	Search Search
+	// Last is the last action recorded per PR URL.
+	Last map[string]Record
+}
+
+// LastRun returns the state of the latest run of command in records, and
+// false if command has not run.
+func LastRun(records []Record, command string) (RunState, bool) {
+	var state RunState
+	found := false
+
+	for _, r := range records {
+		if r.Command != command {
+			continue
+		}
+		if r.Action == ActionStarted {
+			// A resumed run starts again under the same ID and keeps the
+			// actions recorded before.
+			if r.Run != state.Run {
+				state = RunState{Run: r.Run, Last: map[string]Record{}}
+			}
+			state.Query = r.Query
+			state.Group = r.Group
+			if r.Search != nil {
+				state.Search = *r.Search
+			}
+			found = true
+			continue
+		}
+		if found && r.Run == state.Run {
+			state.Last[r.URL] = r
+		}
+	}
+
+	return state, found
+}
+
+// HistoryFilter selects the PR records `pr history` shows. Zero fields match
+// every record.
+type HistoryFilter struct {
+	Since   time.Time
+	Command string
+	Repo    string
+	Actions []Action
+}
+
+// Match reports whether r is a PR record passing f.
+func (f HistoryFilter) Match(r Record) bool {
+	switch {
+	case r.Action == ActionStarted:
+		return false
+	case !f.Since.IsZero() && r.Time.Before(f.Since):
+		return false
+	case f.Command != "" && r.Command != f.Command:
+		return false
+	case f.Repo != "" && !strings.EqualFold(f.Repo, r.Owner+"/"+r.Repo):
+		return false
+	case len(f.Actions) > 0 && !slices.Contains(f.Actions, r.Action):
+		return false
+	}
+	return true
+}
@@ -0,0 +1,147 @@
+package pr
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStateLogResume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "devctl", StateFile)
+
+	prA := &PRStatus{Owner: "giantswarm", Repo: "devctl", Number: 1, Title: "Update module x to v1.2.4", URL: "https://github.com/giantswarm/devctl/pull/1"}
+	prB := &PRStatus{Owner: "giantswarm", Repo: "happa", Number: 2, Title: "Update module x to v2", URL: "https://github.com/giantswarm/happa/pull/2"}
+	search := Search{Reviewers: []string{"giantswarm/team-honeybadger"}}
+
+	// An older run, and a run of another command, must not leak in.
+	old := NewStateLog(path, "approve-merge-renovate", "run-0")
+	mustNoErr(t, old.Start("", "", Search{}))
+	mustNoErr(t, old.Record(prA, ActionFailed, "Failed checks"))
+	align := NewStateLog(path, "approve-align-files", "run-a")
+	mustNoErr(t, align.Start("", "", Search{}))
+
+	first := NewStateLog(path, "approve-merge-renovate", "run-1")
+	mustNoErr(t, first.Start(`"x"`, "x", search))
+	mustNoErr(t, first.Record(prA, ActionApproved, ""))
+	mustNoErr(t, first.Record(prB, ActionSkipped, "high: major update"))
+
+	// The resumed run continues run-1 and keeps what it recorded.
+	resumed := NewStateLog(path, "approve-merge-renovate", "run-1")
+	mustNoErr(t, resumed.Start(`"x"`, "x", search))
+	mustNoErr(t, resumed.Record(prA, ActionMerged, ""))
+
+	records, err := ReadState(path)
+	if err != nil {
+		t.Fatalf("ReadState() returned unexpected error: %v", err)
+	}
+
+	state, ok := LastRun(records, "approve-merge-renovate")
+	if !ok {
+		t.Fatalf("LastRun() found no run")
+	}
+	if state.Run != "run-1" || state.Query != `"x"` || state.Group != "x" {
+		t.Errorf("LastRun() = run %q query %q group %q, want run-1 \"x\" x", state.Run, state.Query, state.Group)
+	}
+	if diff := cmp.Diff(search, state.Search); diff != "" {
+		t.Errorf("LastRun() search mismatch (-expected +got):\n%s", diff)
+	}
+
+	last := map[string]Action{}
+	for url, r := range state.Last {
+		last[url] = r.Action
+	}
+	expected := map[string]Action{prA.URL: ActionMerged, prB.URL: ActionSkipped}
+	if diff := cmp.Diff(expected, last); diff != "" {
+		t.Errorf("LastRun() actions mismatch (-expected +got):\n%s", diff)
+	}
+
+	if _, ok := LastRun(records, "approve-nothing"); ok {
+		t.Errorf("LastRun() found a run of a command that never ran")
+	}
+}
+
+func TestRecordReconsider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), StateFile)
+	prA := &PRStatus{Owner: "giantswarm", Repo: "devctl", Number: 1, URL: "https://github.com/giantswarm/devctl/pull/1"}
+	prB := &PRStatus{Owner: "giantswarm", Repo: "happa", Number: 2, URL: "https://github.com/giantswarm/happa/pull/2"}
+
+	log := NewStateLog(path, "approve-merge-renovate", "run-1")
+	mustNoErr(t, log.Start("", "", Search{}))
+	mustNoErr(t, log.RecordRiskSkip(prA, Assessment{Risk: RiskHigh, Reasons: []string{"major update"}}))
+	mustNoErr(t, log.Record(prB, ActionSkipped, "not confirmed"))
+
+	records, err := ReadState(path)
+	if err != nil {
+		t.Fatalf("ReadState() returned unexpected error: %v", err)
+	}
+	state, _ := LastRun(records, "approve-merge-renovate")
+	riskSkip, declined := state.Last[prA.URL], state.Last[prB.URL]
+
+	if riskSkip.Reason != "high: major update" {
+		t.Errorf("RecordRiskSkip() reason = %q, want %q", riskSkip.Reason, "high: major update")
+	}
+
+	testCases := []struct {
+		name     string
+		record   Record
+		policy   Policy
+		expected bool
+	}{
+		{name: "risk skip under the same policy", record: riskSkip, policy: Policy{AutoApprove: RiskLow, Max: RiskMedium}, expected: false},
+		{name: "risk skip under a higher max risk", record: riskSkip, policy: Policy{AutoApprove: RiskLow, Max: RiskHigh}, expected: true},
+		{name: "declined confirmation", record: declined, policy: Policy{AutoApprove: RiskHigh, Max: RiskHigh}, expected: false},
+		{name: "merged", record: Record{Action: ActionMerged}, policy: Policy{AutoApprove: RiskHigh, Max: RiskHigh}, expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.record.Reconsider(tc.policy); got != tc.expected {
+				t.Errorf("Reconsider() = %v, want %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestHistoryFilter(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	records := []Record{
+		{Time: now.Add(-48 * time.Hour), Command: "approve-merge-renovate", Action: ActionStarted},
+		{Time: now.Add(-48 * time.Hour), Command: "approve-merge-renovate", Action: ActionMerged, Owner: "giantswarm", Repo: "devctl", Number: 1},
+		{Time: now.Add(-time.Hour), Command: "approve-merge-renovate", Action: ActionApproved, Owner: "giantswarm", Repo: "devctl", Number: 2},
+		{Time: now.Add(-time.Hour), Command: "approve-align-files", Action: ActionMerged, Owner: "giantswarm", Repo: "happa", Number: 3},
+	}
+
+	tests := []struct {
+		name     string
+		filter   HistoryFilter
+		expected []int
+	}{
+		{name: "everything but run starts", filter: HistoryFilter{}, expected: []int{1, 2, 3}},
+		{name: "since", filter: HistoryFilter{Since: now.Add(-24 * time.Hour)}, expected: []int{2, 3}},
+		{name: "repo", filter: HistoryFilter{Repo: "giantswarm/DEVCTL"}, expected: []int{1, 2}},
+		{name: "action and command", filter: HistoryFilter{Command: "approve-merge-renovate", Actions: []Action{ActionMerged}}, expected: []int{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int
+			for _, r := range records {
+				if tt.filter.Match(r) {
+					got = append(got, r.Number)
+				}
+			}
+			if diff := cmp.Diff(tt.expected, got); diff != "" {
+				t.Errorf("Match() mismatch (-expected +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func mustNoErr(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
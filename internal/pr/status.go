@@ -12,6 +12,7 @@ type PRStatus struct {
 	Repo         string
 	Title        string
 	URL          string
+	Body         string
 	Status       string
 	DisplayLabel string
 	LastUpdate   time.Time
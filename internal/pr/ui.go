@@ -21,7 +21,8 @@ func PrintTableHeader(w io.Writer, columnHeader string) {
 }
 
 // UpdateTable redraws the PR status table.
-// Each PRStatus.DisplayLabel is shown in the second column.
+// Each PRStatus.DisplayLabel is shown in the second column. Rows without a PR
+// number (e.g. a repository a PR has not been opened for) show a dash.
 func UpdateTable(w io.Writer, prStatuses []*PRStatus) {
 	if len(prStatuses) > 0 {
 		fmt.Fprintf(w, "\033[%dA", len(prStatuses))
@@ -29,6 +30,9 @@ func UpdateTable(w io.Writer, prStatuses []*PRStatus) {
 
 	for _, ps := range prStatuses {
 		prText := fmt.Sprintf("#%-5d", ps.Number)
+		if ps.Number == 0 {
+			prText = fmt.Sprintf("%-6s", "-")
+		}
 		prLink := MakeHyperlink(ps.URL, prText)
 		status := ps.GetStatus()
 
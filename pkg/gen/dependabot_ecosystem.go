@@ -7,20 +7,36 @@ import (
 )
 
 const (
+	EcosystemBundler       Ecosystem = "bundler"
+	EcosystemCargo         Ecosystem = "cargo"
+	EcosystemDevcontainers Ecosystem = "devcontainers"
 	EcosystemDocker        Ecosystem = "docker"
 	EcosystemGithubActions Ecosystem = "github-actions"
 	EcosystemGomod         Ecosystem = "gomod"
+	EcosystemGradle        Ecosystem = "gradle"
+	EcosystemHelm          Ecosystem = "helm"
+	EcosystemMaven         Ecosystem = "maven"
 	EcosystemNPM           Ecosystem = "npm"
 	EcosystemPIP           Ecosystem = "pip"
+	EcosystemPub           Ecosystem = "pub"
+	EcosystemTerraform     Ecosystem = "terraform"
 )
 
 func AllowedEcosystems() []string {
 	return []string{
+		EcosystemBundler.String(),
+		EcosystemCargo.String(),
+		EcosystemDevcontainers.String(),
 		EcosystemDocker.String(),
 		EcosystemGithubActions.String(),
 		EcosystemGomod.String(),
+		EcosystemGradle.String(),
+		EcosystemHelm.String(),
+		EcosystemMaven.String(),
 		EcosystemNPM.String(),
 		EcosystemPIP.String(),
+		EcosystemPub.String(),
+		EcosystemTerraform.String(),
 	}
 }
 
@@ -28,16 +44,32 @@ type Ecosystem string
 
 func NewEcosystem(s string) (Ecosystem, error) {
 	switch s {
+	case EcosystemBundler.String():
+		return EcosystemBundler, nil
+	case EcosystemCargo.String():
+		return EcosystemCargo, nil
+	case EcosystemDevcontainers.String():
+		return EcosystemDevcontainers, nil
 	case EcosystemDocker.String():
 		return EcosystemDocker, nil
 	case EcosystemGithubActions.String():
 		return EcosystemGithubActions, nil
 	case EcosystemGomod.String():
 		return EcosystemGomod, nil
+	case EcosystemGradle.String():
+		return EcosystemGradle, nil
+	case EcosystemHelm.String():
+		return EcosystemHelm, nil
+	case EcosystemMaven.String():
+		return EcosystemMaven, nil
 	case EcosystemNPM.String():
 		return EcosystemNPM, nil
 	case EcosystemPIP.String():
 		return EcosystemPIP, nil
+	case EcosystemPub.String():
+		return EcosystemPub, nil
+	case EcosystemTerraform.String():
+		return EcosystemTerraform, nil
 	}
 
 	return Ecosystem("unknown"), microerror.Maskf(invalidConfigError, "ecosystem must be one of %s", strings.Join(AllowedEcosystems(), "|"))
@@ -19,3 +19,21 @@ var filePathError = &microerror.Error{
 func IsFilePath(err error) bool {
 	return microerror.Cause(err) == filePathError
 }
+
+var validationFailedError = &microerror.Error{
+	Kind: "validationFailedError",
+}
+
+// IsValidationFailed asserts validationFailedError.
+func IsValidationFailed(err error) bool {
+	return microerror.Cause(err) == validationFailedError
+}
+
+var unownedFileError = &microerror.Error{
+	Kind: "unownedFileError",
+}
+
+// IsUnownedFile asserts unownedFileError.
+func IsUnownedFile(err error) bool {
+	return microerror.Cause(err) == unownedFileError
+}
@@ -1,6 +1,7 @@
 package gen
 
 import (
+	"bytes"
 	"context"
 	"io/fs"
 	"os"
@@ -53,7 +54,15 @@ func execute(ctx context.Context, file input.Input) error {
 		}
 	}
 
-	if !file.SkipRegenCheck && !isRegenerable(file.Path) && fileExists {
+	if file.OwnerMarker != "" && fileExists {
+		existing, err := os.ReadFile(file.Path)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		if !bytes.Contains(existing, []byte(file.OwnerMarker)) {
+			return microerror.Maskf(unownedFileError, "file %#q exists and was not generated by devctl, move its content elsewhere and delete it to generate it", file.Path)
+		}
+	} else if !file.SkipRegenCheck && !isRegenerable(file.Path) && fileExists {
 		return nil
 	}
 
@@ -62,13 +71,12 @@ func execute(ctx context.Context, file input.Input) error {
 		permissions = file.Permissions
 	}
 
-	w, err := os.OpenFile(file.Path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, permissions)
+	content, err := Render(ctx, file)
 	if err != nil {
 		return microerror.Mask(err)
 	}
-	defer func() { _ = w.Close() }()
 
-	err = internal.Execute(ctx, w, file)
+	err = os.WriteFile(file.Path, stampContentHash(content), permissions)
 	if err != nil {
 		return microerror.Mask(err)
 	}
@@ -76,6 +84,24 @@ func execute(ctx context.Context, file input.Input) error {
 	return nil
 }
 
+// Render renders the template of the given input and validates the result,
+// see Validate. It returns the content that Execute writes to disk, minus the
+// content hash stamped into the provenance header.
+func Render(ctx context.Context, file input.Input) ([]byte, error) {
+	var buf bytes.Buffer
+	err := internal.Execute(ctx, &buf, file)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	err = Validate(file, buf.Bytes())
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return buf.Bytes(), nil
+}
+
 // isRegenerable returns true if the file should be overridden with the
 // regenerated content. All files with "zz_generated." prefix qualify for that
 // but there are also some exceptions usually when the name is conventional.
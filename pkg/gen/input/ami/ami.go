@@ -2,7 +2,10 @@ package ami
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/giantswarm/microerror"
 
@@ -31,9 +34,23 @@ func New(config Config) (*AMI, error) {
 	return f, nil
 }
 
-func (a *AMI) AMIFile() input.Input {
+// Files returns the inputs of the configured formats.
+func (a *AMI) Files() []input.Input {
 	a.mustBooted()
-	return file.NewAMIInput(a.params)
+
+	var files []input.Input
+	for _, f := range a.config.Formats {
+		switch f {
+		case FormatLegacy:
+			files = append(files, file.NewAMIInput(a.params))
+		case FormatJSON:
+			files = append(files, file.NewDocumentInput(a.params))
+		case FormatGo:
+			files = append(files, file.NewGoInput(a.params))
+		}
+	}
+
+	return files
 }
 
 func (a *AMI) Boot(ctx context.Context) error {
@@ -47,14 +64,58 @@ func (a *AMI) Boot(ctx context.Context) error {
 }
 
 func (a *AMI) initParams(ctx context.Context) error {
-	amiInfoString, err := getAMIInfoString(a.config)
+	doc, err := scrape(ctx, a.config)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = a.setDocument(doc)
 	if err != nil {
 		return microerror.Mask(err)
 	}
 
+	return nil
+}
+
+// setDocument verifies the document when configured and renders it into
+// the params of the configured formats.
+func (a *AMI) setDocument(doc Document) error {
+	if a.config.Verify {
+		problems := Verify(doc, a.config.VerifyRegions)
+		if len(problems) > 0 {
+			return microerror.Maskf(verificationFailedError, "%d problems found:\n%s", len(problems), strings.Join(problems, "\n"))
+		}
+	}
+
 	a.params = params.Params{
-		AMIInfoString: amiInfoString,
 		Dir:           a.config.Dir,
+		SchemaVersion: SchemaVersion,
+	}
+
+	if slices.Contains(a.config.Formats, FormatLegacy) {
+		releases := doc.releases(a.config.Channels[0], a.config.Archs[0])
+		if releases == nil {
+			releases = Releases{}
+		}
+		b, err := json.MarshalIndent(releases, "", "  ")
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		a.params.AMIInfoString = string(b)
+	}
+	if slices.Contains(a.config.Formats, FormatJSON) {
+		b, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		a.params.Document = string(b) + "\n"
+	}
+	if slices.Contains(a.config.Formats, FormatGo) {
+		var err error
+		a.params.GoAMIs, err = goSource(doc)
+		if err != nil {
+			return microerror.Mask(err)
+		}
 	}
 
 	return nil
@@ -0,0 +1,130 @@
+package ami
+
+import (
+	"context"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/giantswarm/devctl/v8/pkg/gen"
+)
+
+func Test_Files(t *testing.T) {
+	doc := Document{
+		SchemaVersion: SchemaVersion,
+		Channels: map[string]map[string]Releases{
+			"stable": {"amd64-usr": {"3000.0.0": {"eu-west-1": "ami-0123456789abcdef0"}}},
+			"beta":   {"arm64-usr": {"3100.0.0": {"eu-west-1": "ami-01234567"}}},
+		},
+	}
+
+	a := &AMI{
+		config: Config{
+			Dir:      "amis",
+			Channels: []string{"stable", "beta"},
+			Archs:    []string{"amd64-usr", "arm64-usr"},
+			Formats:  []string{FormatJSON, FormatGo},
+		},
+		booted: true,
+	}
+	err := a.setDocument(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := a.Files()
+	if len(files) != 2 {
+		t.Fatalf("got %d files, want 2", len(files))
+	}
+
+	out, err := gen.Render(context.Background(), files[0])
+	if err != nil {
+		t.Fatalf("render %s: %v", files[0].Path, err)
+	}
+	if files[0].Path != "amis/amis.json" || !strings.HasPrefix(string(out), "{\n  \"schemaVersion\": 1,\n  \"channels\": {\n    \"beta\": {") {
+		t.Errorf("unexpected %s:\n%s", files[0].Path, out)
+	}
+
+	out, err = gen.Render(context.Background(), files[1])
+	if err != nil {
+		t.Fatalf("render %s: %v", files[1].Path, err)
+	}
+	if files[1].Path != "amis/zz_generated.amis.go" {
+		t.Errorf("unexpected Go file path %s", files[1].Path)
+	}
+	f, err := parser.ParseFile(token.NewFileSet(), files[1].Path, out, 0)
+	if err != nil {
+		t.Fatalf("generated Go file does not parse: %v\n%s", err, out)
+	}
+	if f.Name.Name != "amis" {
+		t.Errorf("package = %s, want amis", f.Name.Name)
+	}
+}
+
+func Test_FilesRegenerateDocument(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "amis")
+	err := os.MkdirAll(dir, 0750)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(dir, "amis.json"), []byte(`{"3000.0.0": {"eu-west-1": "ami-0123456789abcdef0"}}`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := &AMI{
+		config: Config{
+			Dir:      dir,
+			Channels: []string{"stable"},
+			Archs:    []string{"amd64-usr"},
+			Formats:  []string{FormatJSON},
+		},
+		booted: true,
+	}
+	err = a.setDocument(Document{
+		SchemaVersion: SchemaVersion,
+		Channels: map[string]map[string]Releases{
+			"stable": {"amd64-usr": {"3100.0.0": {"eu-west-1": "ami-01234567"}}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = gen.Execute(context.Background(), a.Files()...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, "amis.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"3100.0.0"`) || !strings.Contains(string(b), `"schemaVersion": 1`) {
+		t.Errorf("existing amis.json was not regenerated:\n%s", b)
+	}
+}
+
+func Test_FilesVerify(t *testing.T) {
+	a := &AMI{
+		config: Config{
+			Channels: []string{"stable"},
+			Archs:    []string{"amd64-usr"},
+			Formats:  []string{FormatLegacy},
+			Verify:   true,
+		},
+	}
+
+	err := a.setDocument(Document{
+		SchemaVersion: SchemaVersion,
+		Channels: map[string]map[string]Releases{
+			"stable": {"amd64-usr": {"3000.0.0": {"eu-west-1": "ami-nope"}}},
+		},
+	})
+	if !IsVerificationFailed(err) {
+		t.Fatalf("error = %v, want verification failed error", err)
+	}
+}
@@ -11,7 +11,7 @@ import (
 	"github.com/giantswarm/microerror"
 )
 
-func getChinaFlatcarRelease(config Config, version string) (map[string]string, error) {
+func getChinaFlatcarRelease(config Config, channel, arch, version string) (map[string]string, error) {
 	sess, err := session.NewSession(&aws.Config{
 		Credentials: credentials.NewStaticCredentials(config.ChinaAWSAccessKeyID, config.ChinaAWSSecretAccessKey, ""),
 		Region:      aws.String(config.ChinaBucketRegion),
@@ -22,7 +22,7 @@ func getChinaFlatcarRelease(config Config, version string) (map[string]string, e
 	svc := s3.New(sess)
 	input := &s3.GetObjectInput{
 		Bucket: aws.String(config.ChinaBucketName),
-		Key:    aws.String(fmt.Sprintf("%s/%s/%s.json", config.Channel, config.Arch, version)),
+		Key:    aws.String(fmt.Sprintf("%s/%s/%s.json", channel, arch, version)),
 	}
 
 	result, err := svc.GetObject(input)
@@ -1,12 +1,34 @@
 package ami
 
 import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
 	"github.com/giantswarm/microerror"
 )
 
+const (
+	// FormatLegacy is the flat release to region to AMI JSON map of a
+	// single channel and architecture consumed by aws-operator.
+	FormatLegacy = "legacy"
+	// FormatJSON is the Document of all channels and architectures.
+	FormatJSON = "json"
+	// FormatGo is a Go file declaring the Document as a map.
+	FormatGo = "go"
+)
+
+// AllFormats returns the supported output formats.
+func AllFormats() []string {
+	return []string{FormatLegacy, FormatJSON, FormatGo}
+}
+
 type Config struct {
-	Arch                    string
-	Channel                 string
+	// Archs are the architectures to generate AMIs for, e.g. "amd64-usr".
+	Archs []string
+	// Channels are the Flatcar channels to generate AMIs for, e.g. "stable".
+	Channels                []string
 	ChinaBucketName         string
 	ChinaBucketRegion       string
 	ChinaAWSAccessKeyID     string
@@ -18,14 +40,42 @@ type Config struct {
 	PrimaryDomain  string
 	// If KeepExisting is not empty, releases find in the file won't be overridden.
 	KeepExisting string
+	// Incremental only scrapes releases newer than the newest release in
+	// KeepExisting.
+	Incremental bool
+	// HTTPTimeout is the timeout of a single request to the release mirror.
+	HTTPTimeout time.Duration
+	// Retries is the number of times a failed request is retried.
+	Retries int
+	// Workers is the number of releases scraped concurrently.
+	Workers int
+	// Formats are the output formats to generate, see AllFormats.
+	Formats []string
+	// Verify checks the generated AMIs with Verify before anything is
+	// written.
+	Verify bool
+	// VerifyRegions are the regions every release must have an AMI in. See
+	// Verify for the default.
+	VerifyRegions []string
 }
 
 func (c *Config) Validate() error {
-	if c.Arch == "" {
-		return microerror.Maskf(invalidConfigError, "%T.Arch must not be empty", c)
+	if len(c.Archs) == 0 || slices.Contains(c.Archs, "") {
+		return microerror.Maskf(invalidConfigError, "%T.Archs must not be empty", c)
+	}
+	if len(c.Channels) == 0 || slices.Contains(c.Channels, "") {
+		return microerror.Maskf(invalidConfigError, "%T.Channels must not be empty", c)
+	}
+	if len(c.Formats) == 0 {
+		return microerror.Maskf(invalidConfigError, "%T.Formats must not be empty", c)
 	}
-	if c.Channel == "" {
-		return microerror.Maskf(invalidConfigError, "%T.Channel must not be empty", c)
+	for _, f := range c.Formats {
+		if !slices.Contains(AllFormats(), f) {
+			return microerror.Maskf(invalidConfigError, "%T.Formats must contain only <%s>, got %#q", c, strings.Join(AllFormats(), "|"), f)
+		}
+	}
+	if slices.Contains(c.Formats, FormatLegacy) && (len(c.Archs) != 1 || len(c.Channels) != 1) {
+		return microerror.Maskf(invalidConfigError, "%T.Formats must not contain %#q for more than one channel or architecture", c, FormatLegacy)
 	}
 	if c.ChinaBucketName == "" {
 		return microerror.Maskf(invalidConfigError, "%T.ChinaBucketName must not be empty", c)
@@ -45,9 +95,24 @@ func (c *Config) Validate() error {
 	if c.MinimumVersion == "" {
 		return microerror.Maskf(invalidConfigError, "%T.MinimumVersion must not be empty", c)
 	}
+	if _, err := semver.NewVersion(c.MinimumVersion); err != nil {
+		return microerror.Maskf(invalidConfigError, "%T.MinimumVersion must be a valid version, got %#q", c, c.MinimumVersion)
+	}
 	if c.PrimaryDomain == "" {
 		return microerror.Maskf(invalidConfigError, "%T.PrimaryDomain must not be empty", c)
 	}
+	if c.Incremental && c.KeepExisting == "" {
+		return microerror.Maskf(invalidConfigError, "%T.KeepExisting must not be empty when %T.Incremental is set", c, c)
+	}
+	if c.HTTPTimeout <= 0 {
+		return microerror.Maskf(invalidConfigError, "%T.HTTPTimeout must be positive", c)
+	}
+	if c.Retries < 0 {
+		return microerror.Maskf(invalidConfigError, "%T.Retries must not be negative", c)
+	}
+	if c.Workers < 1 {
+		return microerror.Maskf(invalidConfigError, "%T.Workers must be at least 1", c)
+	}
 
 	return nil
 }
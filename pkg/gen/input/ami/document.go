@@ -0,0 +1,204 @@
+package ami
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/giantswarm/microerror"
+)
+
+// SchemaVersion is the version of the Document schema. It is increased with
+// every incompatible change of the document layout.
+const SchemaVersion = 1
+
+var amiIDRegexp = regexp.MustCompile(`^ami-([0-9a-f]{8}|[0-9a-f]{17})$`)
+
+// Releases maps Flatcar releases to the AMI IDs of the release keyed by
+// region, e.g. {"3815.2.0": {"eu-west-1": "ami-0123456789abcdef0"}}.
+type Releases map[string]map[string]string
+
+// Document holds the AMIs of several channels and architectures. It is the
+// JSON form of the generated AMIs:
+//
+//	{
+//	  "schemaVersion": 1,
+//	  "channels": {
+//	    "stable": {
+//	      "amd64-usr": {
+//	        "3815.2.0": {"eu-west-1": "ami-0123456789abcdef0"}
+//	      }
+//	    }
+//	  }
+//	}
+type Document struct {
+	SchemaVersion int `json:"schemaVersion"`
+	// Channels maps channels and architectures to their releases.
+	Channels map[string]map[string]Releases `json:"channels"`
+}
+
+func (d Document) releases(channel, arch string) Releases {
+	return d.Channels[channel][arch]
+}
+
+func (d *Document) setReleases(channel, arch string, r Releases) {
+	if d.Channels == nil {
+		d.Channels = map[string]map[string]Releases{}
+	}
+	if d.Channels[channel] == nil {
+		d.Channels[channel] = map[string]Releases{}
+	}
+	d.Channels[channel][arch] = r
+}
+
+// readExisting reads the releases already generated. The file is either a
+// Document or the flat releases map of a single channel and architecture
+// written by the legacy format.
+func readExisting(config Config) (Document, error) {
+	doc := Document{SchemaVersion: SchemaVersion}
+	if config.KeepExisting == "" {
+		return doc, nil
+	}
+
+	data, err := os.ReadFile(config.KeepExisting)
+	if err != nil {
+		return Document{}, microerror.Mask(err)
+	}
+
+	var probe map[string]json.RawMessage
+	err = json.Unmarshal(data, &probe)
+	if err != nil {
+		return Document{}, microerror.Maskf(invalidConfigError, "%s: %s", config.KeepExisting, err)
+	}
+
+	if _, ok := probe["schemaVersion"]; ok {
+		err = json.Unmarshal(data, &doc)
+		if err != nil {
+			return Document{}, microerror.Maskf(invalidConfigError, "%s: %s", config.KeepExisting, err)
+		}
+		if doc.SchemaVersion != SchemaVersion {
+			return Document{}, microerror.Maskf(invalidConfigError, "%s: schemaVersion must be %d, got %d", config.KeepExisting, SchemaVersion, doc.SchemaVersion)
+		}
+		return doc, nil
+	}
+
+	if len(config.Channels) != 1 || len(config.Archs) != 1 {
+		return Document{}, microerror.Maskf(invalidConfigError, "%s holds the releases of a single channel and architecture, but %d channels and %d architectures are generated", config.KeepExisting, len(config.Channels), len(config.Archs))
+	}
+	var r Releases
+	err = json.Unmarshal(data, &r)
+	if err != nil {
+		return Document{}, microerror.Maskf(invalidConfigError, "%s: %s", config.KeepExisting, err)
+	}
+	doc.setReleases(config.Channels[0], config.Archs[0], r)
+
+	return doc, nil
+}
+
+// Verify checks the AMIs of the document and returns the problems found.
+// Every AMI ID must be well-formed and every release must have an AMI in
+// each of the given regions. Without regions, the regions of the newest
+// release of a channel and architecture are expected in all its releases.
+func Verify(doc Document, regions []string) []string {
+	var problems []string
+
+	for _, channel := range sortedKeys(doc.Channels) {
+		for _, arch := range sortedKeys(doc.Channels[channel]) {
+			releases := doc.Channels[channel][arch]
+			versions := sortedVersions(releases)
+			if len(versions) == 0 {
+				problems = append(problems, fmt.Sprintf("%s/%s: no releases", channel, arch))
+				continue
+			}
+
+			expected := regions
+			if len(expected) == 0 {
+				expected = sortedKeys(releases[versions[len(versions)-1]])
+			}
+
+			for _, version := range versions {
+				amis := releases[version]
+				for _, region := range sortedKeys(amis) {
+					if !amiIDRegexp.MatchString(amis[region]) {
+						problems = append(problems, fmt.Sprintf("%s/%s %s: region %s has malformed AMI ID %#q", channel, arch, version, region, amis[region]))
+					}
+				}
+				var missing []string
+				for _, region := range expected {
+					if _, ok := amis[region]; !ok {
+						missing = append(missing, region)
+					}
+				}
+				if len(missing) > 0 {
+					problems = append(problems, fmt.Sprintf("%s/%s %s: missing regions %s", channel, arch, version, strings.Join(missing, ", ")))
+				}
+			}
+		}
+	}
+
+	return problems
+}
+
+// goSource returns the Go composite literal of the document's channels,
+// formatted with gofmt.
+func goSource(doc Document) (string, error) {
+	var b bytes.Buffer
+	b.WriteString("package p\n\nvar v = map[string]map[string]map[string]map[string]string{\n")
+	for _, channel := range sortedKeys(doc.Channels) {
+		fmt.Fprintf(&b, "%q: {\n", channel)
+		for _, arch := range sortedKeys(doc.Channels[channel]) {
+			releases := doc.Channels[channel][arch]
+			fmt.Fprintf(&b, "%q: {\n", arch)
+			for _, version := range sortedVersions(releases) {
+				fmt.Fprintf(&b, "%q: {\n", version)
+				for _, region := range sortedKeys(releases[version]) {
+					fmt.Fprintf(&b, "%q: %q,\n", region, releases[version][region])
+				}
+				b.WriteString("},\n")
+			}
+			b.WriteString("},\n")
+		}
+		b.WriteString("},\n")
+	}
+	b.WriteString("}\n")
+
+	formatted, err := format.Source(b.Bytes())
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	_, literal, _ := strings.Cut(string(formatted), "var v = ")
+	return strings.TrimSuffix(literal, "\n"), nil
+}
+
+// sortedVersions returns the releases sorted by version, oldest first.
+func sortedVersions(r Releases) []string {
+	versions := make([]string, 0, len(r))
+	for v := range r {
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		a, errA := semver.NewVersion(versions[i])
+		b, errB := semver.NewVersion(versions[j])
+		if errA != nil || errB != nil {
+			return versions[i] < versions[j]
+		}
+		return a.LessThan(b)
+	})
+	return versions
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,174 @@
+package ami
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_readExisting(t *testing.T) {
+	testCases := []struct {
+		name         string
+		content      string
+		channels     []string
+		archs        []string
+		expected     Document
+		errorMatcher func(error) bool
+	}{
+		{
+			name:     "case 0: legacy map of a single channel and architecture",
+			content:  `{"3000.0.0": {"eu-west-1": "ami-0123456789abcdef0"}}`,
+			channels: []string{"beta"},
+			archs:    []string{"arm64-usr"},
+			expected: Document{
+				SchemaVersion: SchemaVersion,
+				Channels: map[string]map[string]Releases{
+					"beta": {"arm64-usr": {"3000.0.0": {"eu-west-1": "ami-0123456789abcdef0"}}},
+				},
+			},
+		},
+		{
+			name:         "case 1: legacy map with several channels",
+			content:      `{"3000.0.0": {"eu-west-1": "ami-0123456789abcdef0"}}`,
+			channels:     []string{"stable", "beta"},
+			archs:        []string{"amd64-usr"},
+			errorMatcher: IsInvalidConfig,
+		},
+		{
+			name:     "case 2: document",
+			content:  `{"schemaVersion": 1, "channels": {"stable": {"amd64-usr": {"3000.0.0": {"eu-west-1": "ami-01234567"}}}}}`,
+			channels: []string{"stable", "beta"},
+			archs:    []string{"amd64-usr", "arm64-usr"},
+			expected: Document{
+				SchemaVersion: SchemaVersion,
+				Channels: map[string]map[string]Releases{
+					"stable": {"amd64-usr": {"3000.0.0": {"eu-west-1": "ami-01234567"}}},
+				},
+			},
+		},
+		{
+			name:         "case 3: unsupported schema version",
+			content:      `{"schemaVersion": 2, "channels": {}}`,
+			channels:     []string{"stable"},
+			archs:        []string{"amd64-usr"},
+			errorMatcher: IsInvalidConfig,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := filepath.Join(t.TempDir(), "amis.json")
+			err := os.WriteFile(p, []byte(tc.content), 0600)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			doc, err := readExisting(Config{KeepExisting: p, Channels: tc.channels, Archs: tc.archs})
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+			if tc.errorMatcher != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(doc, tc.expected) {
+				t.Fatalf("document = %v, want %v", doc, tc.expected)
+			}
+		})
+	}
+}
+
+func Test_Verify(t *testing.T) {
+	doc := Document{
+		SchemaVersion: SchemaVersion,
+		Channels: map[string]map[string]Releases{
+			"stable": {
+				"amd64-usr": {
+					"3000.0.0": {"eu-west-1": "ami-0123456789abcdef0"},
+					"3100.0.0": {"eu-west-1": "ami-01234567", "us-east-1": "ami-7654321"},
+					"900.0.0":  {"eu-west-1": "ami-89abcdef", "us-east-1": "ami-89abcdef"},
+				},
+				"arm64-usr": {},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		regions  []string
+		expected []string
+	}{
+		{
+			name: "case 0: regions of the newest release",
+			expected: []string{
+				`stable/amd64-usr 3000.0.0: missing regions us-east-1`,
+				"stable/amd64-usr 3100.0.0: region us-east-1 has malformed AMI ID `ami-7654321`",
+				`stable/arm64-usr: no releases`,
+			},
+		},
+		{
+			name:    "case 1: explicit regions",
+			regions: []string{"eu-west-1", "cn-north-1"},
+			expected: []string{
+				`stable/amd64-usr 900.0.0: missing regions cn-north-1`,
+				`stable/amd64-usr 3000.0.0: missing regions cn-north-1`,
+				"stable/amd64-usr 3100.0.0: region us-east-1 has malformed AMI ID `ami-7654321`",
+				`stable/amd64-usr 3100.0.0: missing regions cn-north-1`,
+				`stable/arm64-usr: no releases`,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			problems := Verify(doc, tc.regions)
+			if !reflect.DeepEqual(problems, tc.expected) {
+				t.Fatalf("problems = %q, want %q", problems, tc.expected)
+			}
+		})
+	}
+}
+
+func Test_goSource(t *testing.T) {
+	doc := Document{
+		SchemaVersion: SchemaVersion,
+		Channels: map[string]map[string]Releases{
+			"stable": {
+				"amd64-usr": {
+					"3100.0.0": {"us-east-1": "ami-01234567", "eu-west-1": "ami-89abcdef"},
+					"900.0.0":  {"eu-west-1": "ami-00000000"},
+				},
+			},
+		},
+	}
+
+	expected := `map[string]map[string]map[string]map[string]string{
+	"stable": {
+		"amd64-usr": {
+			"900.0.0": {
+				"eu-west-1": "ami-00000000",
+			},
+			"3100.0.0": {
+				"eu-west-1": "ami-89abcdef",
+				"us-east-1": "ami-01234567",
+			},
+		},
+	},
+}`
+
+	source, err := goSource(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if source != expected {
+		t.Fatalf("source =\n%s\nwant\n%s", source, expected)
+	}
+}
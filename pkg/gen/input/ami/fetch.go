@@ -0,0 +1,202 @@
+package ami
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/giantswarm/microerror"
+)
+
+// fetcher scrapes the AMIs of Flatcar releases from the release mirror.
+type fetcher struct {
+	client *http.Client
+	// baseURL is the URL of the release listing of a channel and
+	// architecture, e.g. https://stable.release.flatcar-linux.net/amd64-usr.
+	baseURL string
+	// china returns the AMIs of the given release in the china regions, or
+	// nil when the release is not available there.
+	china func(version string) (map[string]string, error)
+	// workers is the number of releases scraped concurrently.
+	workers int
+	// retries is the number of times a request failing with a network error
+	// or a retryable status is retried.
+	retries int
+	// backoff is the delay before the first retry. It doubles with every
+	// following retry.
+	backoff time.Duration
+}
+
+// versions returns the releases listed on the mirror.
+func (f *fetcher) versions(ctx context.Context) ([]string, error) {
+	url := f.baseURL + "/"
+	fmt.Println("getting list of releases from", url)
+
+	body, err := f.get(ctx, url)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	if body == nil {
+		return nil, microerror.Maskf(executionFailedError, "release listing %#q not found", url)
+	}
+	defer body.Close()
+
+	versions, err := scrapeVersions(body)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return versions, nil
+}
+
+// amis scrapes the AMIs of the given releases with a pool of f.workers
+// workers. Releases which are not published on the mirror are missing from
+// the result. The first error cancels the remaining work.
+func (f *fetcher) amis(ctx context.Context, versions []string) (Releases, error) {
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan string)
+	result := Releases{}
+	var (
+		mu       sync.Mutex
+		firstErr error
+		wg       sync.WaitGroup
+	)
+
+	workers := min(f.workers, len(versions))
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for version := range jobs {
+				amis, err := f.ami(ctx, version)
+
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				if err == nil && amis != nil {
+					result[version] = amis
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+dispatch:
+	for _, version := range versions {
+		select {
+		case jobs <- version:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, microerror.Mask(firstErr)
+	}
+	if err := parent.Err(); err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return result, nil
+}
+
+// ami returns the AMIs of the given release, including the china regions,
+// or nil when the release is not published on the mirror.
+func (f *fetcher) ami(ctx context.Context, version string) (map[string]string, error) {
+	url := fmt.Sprintf("%s/%s/flatcar_production_ami_all.json", f.baseURL, version)
+	fmt.Println("scraping release", version)
+
+	body, err := f.get(ctx, url)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	if body == nil {
+		fmt.Printf("Release %s has no AMIs, skipping it\n", version)
+		return nil, nil
+	}
+	defer body.Close()
+
+	amis, err := scrapeVersionAMI(body)
+	if err != nil {
+		return nil, microerror.Maskf(executionFailedError, "release %s: %s", version, err)
+	}
+
+	if f.china != nil {
+		chinaAMIs, err := f.china(version)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		for region, image := range chinaAMIs {
+			amis[region] = image
+		}
+	}
+
+	return amis, nil
+}
+
+// get requests the given URL and returns the response body, which the
+// caller must close. The returned body is nil when the mirror does not have
+// the URL. The mirror answers 403 rather than 404 for missing objects.
+// Network errors, 429 and 5xx responses are retried with exponential
+// backoff.
+func (f *fetcher) get(ctx context.Context, url string) (io.ReadCloser, error) {
+	backoff := f.backoff
+
+	var lastErr error
+	for attempt := 0; attempt <= f.retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return nil, microerror.Mask(ctx.Err())
+			}
+			backoff *= 2
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		resp, err := f.client.Do(req)
+		if err != nil && ctx.Err() != nil {
+			return nil, microerror.Mask(ctx.Err())
+		} else if err != nil {
+			lastErr = err
+			continue
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusOK:
+			return resp.Body, nil
+		case resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound:
+			drain(resp.Body)
+			return nil, nil
+		case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+			drain(resp.Body)
+			lastErr = microerror.Maskf(executionFailedError, "GET %s: %s", url, resp.Status)
+			continue
+		default:
+			drain(resp.Body)
+			return nil, microerror.Maskf(executionFailedError, "GET %s: %s", url, resp.Status)
+		}
+	}
+
+	return nil, microerror.Maskf(executionFailedError, "GET %s failed after %d attempts: %s", url, f.retries+1, lastErr)
+}
+
+// drain reads and closes the body, so the connection can be reused.
+func drain(body io.ReadCloser) {
+	_, _ = io.Copy(io.Discard, body)
+	_ = body.Close()
+}
@@ -0,0 +1,244 @@
+package ami
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// mirror is an httptest stand-in for the Flatcar release mirror of a single
+// channel and architecture.
+type mirror struct {
+	// releases maps versions to the AMI of their only region. Versions
+	// without AMIs answer 403 like the real mirror.
+	releases map[string]string
+	// failures maps versions to the number of 503 responses before the
+	// release is served.
+	failures map[string]int
+	// status, when set, is returned for every release instead.
+	status int
+
+	mu       sync.Mutex
+	requests map[string]int
+	inFlight atomic.Int32
+	maxSeen  atomic.Int32
+}
+
+func (m *mirror) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n := m.inFlight.Add(1)
+	defer m.inFlight.Add(-1)
+	for {
+		seen := m.maxSeen.Load()
+		if n <= seen || m.maxSeen.CompareAndSwap(seen, n) {
+			break
+		}
+	}
+	// Give the other workers a chance to overlap.
+	time.Sleep(5 * time.Millisecond)
+
+	path := strings.TrimPrefix(r.URL.Path, "/amd64-usr/")
+	if path == "" {
+		for v := range m.releases {
+			fmt.Fprintf(w, "<a href=\"./%s/\">%s</a>\n", v, v)
+		}
+		fmt.Fprintf(w, "<a href=\"./3400.0.0/\">3400.0.0</a>\n<a href=\"./current/\">current</a>\n")
+		return
+	}
+
+	version := strings.TrimSuffix(path, "/flatcar_production_ami_all.json")
+	m.mu.Lock()
+	m.requests[version]++
+	attempt := m.requests[version]
+	m.mu.Unlock()
+
+	if m.status != 0 {
+		w.WriteHeader(m.status)
+		return
+	}
+	if attempt <= m.failures[version] {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	ami, ok := m.releases[version]
+	if !ok {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	fmt.Fprintf(w, `{"amis": [{"name": "eu-west-1", "hvm": %q}]}`, ami)
+}
+
+func newTestFetcher(t *testing.T, m *mirror) *fetcher {
+	t.Helper()
+
+	m.requests = map[string]int{}
+	s := httptest.NewServer(m)
+	t.Cleanup(s.Close)
+
+	return &fetcher{
+		client:  s.Client(),
+		baseURL: s.URL + "/amd64-usr",
+		china: func(version string) (map[string]string, error) {
+			return map[string]string{"cn-north-1": "ami-cn-" + version}, nil
+		},
+		workers: 2,
+		retries: 2,
+		backoff: time.Millisecond,
+	}
+}
+
+func Test_mergeAMIs(t *testing.T) {
+	releases := map[string]string{
+		"2000.0.0": "ami-2000",
+		"3000.0.0": "ami-3000",
+		"3100.0.0": "ami-3100",
+		"3200.1.0": "ami-3200",
+		"3300.0.0": "ami-3300",
+	}
+
+	testCases := []struct {
+		name        string
+		incremental bool
+		existing    Releases
+		failures    map[string]int
+		expected    Releases
+		scraped     []string
+	}{
+		{
+			name: "case 0: scrape all releases since the minimum version",
+			// 3400.0.0 is listed, but answers 403.
+			failures: map[string]int{"3100.0.0": 2},
+			expected: Releases{
+				"3000.0.0": {"eu-west-1": "ami-3000", "cn-north-1": "ami-cn-3000.0.0"},
+				"3100.0.0": {"eu-west-1": "ami-3100", "cn-north-1": "ami-cn-3100.0.0"},
+				"3200.1.0": {"eu-west-1": "ami-3200", "cn-north-1": "ami-cn-3200.1.0"},
+				"3300.0.0": {"eu-west-1": "ami-3300", "cn-north-1": "ami-cn-3300.0.0"},
+			},
+			scraped: []string{"3000.0.0", "3100.0.0", "3200.1.0", "3300.0.0", "3400.0.0"},
+		},
+		{
+			name: "case 1: keep existing releases",
+			existing: Releases{
+				"2000.0.0": {"eu-west-1": "ami-old"},
+				"3100.0.0": {"eu-west-1": "ami-kept"},
+			},
+			expected: Releases{
+				"2000.0.0": {"eu-west-1": "ami-old"},
+				"3000.0.0": {"eu-west-1": "ami-3000", "cn-north-1": "ami-cn-3000.0.0"},
+				"3100.0.0": {"eu-west-1": "ami-kept"},
+				"3200.1.0": {"eu-west-1": "ami-3200", "cn-north-1": "ami-cn-3200.1.0"},
+				"3300.0.0": {"eu-west-1": "ami-3300", "cn-north-1": "ami-cn-3300.0.0"},
+			},
+			scraped: []string{"3000.0.0", "3200.1.0", "3300.0.0", "3400.0.0"},
+		},
+		{
+			name:        "case 2: incremental only scrapes newer releases",
+			incremental: true,
+			existing: Releases{
+				"3200.0.0": {"eu-west-1": "ami-kept"},
+			},
+			expected: Releases{
+				"3200.0.0": {"eu-west-1": "ami-kept"},
+				"3200.1.0": {"eu-west-1": "ami-3200", "cn-north-1": "ami-cn-3200.1.0"},
+				"3300.0.0": {"eu-west-1": "ami-3300", "cn-north-1": "ami-cn-3300.0.0"},
+			},
+			scraped: []string{"3200.1.0", "3300.0.0", "3400.0.0"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &mirror{releases: releases, failures: tc.failures}
+			f := newTestFetcher(t, m)
+
+			config := Config{
+				MinimumVersion: "2500.0.0",
+				KeepExisting:   "amis.json",
+				Incremental:    tc.incremental,
+			}
+			existing := Releases{}
+			for k, v := range tc.existing {
+				existing[k] = v
+			}
+
+			merged, err := mergeAMIs(context.Background(), f, config, existing)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(merged, tc.expected) {
+				t.Fatalf("merged AMIs = %v, want %v", merged, tc.expected)
+			}
+
+			for _, v := range tc.scraped {
+				if m.requests[v] != tc.failures[v]+1 {
+					t.Errorf("release %s requested %d times, want %d", v, m.requests[v], tc.failures[v]+1)
+				}
+			}
+			if len(m.requests) != len(tc.scraped) {
+				t.Errorf("requested releases %v, want %v", m.requests, tc.scraped)
+			}
+			if n := m.maxSeen.Load(); n > int32(f.workers) {
+				t.Errorf("%d concurrent requests, want at most %d workers", n, f.workers)
+			}
+		})
+	}
+}
+
+func Test_fetcherErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		status   int
+		failures map[string]int
+		requests int
+	}{
+		{
+			name:     "case 0: retries are exhausted",
+			failures: map[string]int{"3000.0.0": 10},
+			requests: 3,
+		},
+		{
+			name:     "case 1: unexpected status is not retried",
+			status:   http.StatusBadRequest,
+			requests: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &mirror{
+				releases: map[string]string{"3000.0.0": "ami-3000"},
+				failures: tc.failures,
+				status:   tc.status,
+			}
+			f := newTestFetcher(t, m)
+
+			_, err := f.amis(context.Background(), []string{"3000.0.0"})
+			if !IsExecutionFailed(err) {
+				t.Fatalf("error = %v, want execution failed error", err)
+			}
+			if m.requests["3000.0.0"] != tc.requests {
+				t.Errorf("requested %d times, want %d", m.requests["3000.0.0"], tc.requests)
+			}
+		})
+	}
+}
+
+func Test_fetcherCancel(t *testing.T) {
+	m := &mirror{failures: map[string]int{"3000.0.0": 10}}
+	f := newTestFetcher(t, m)
+	f.backoff = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := f.amis(ctx, []string{"3000.0.0", "3100.0.0", "3200.0.0"})
+	if err == nil {
+		t.Fatal("expected an error for the cancelled context")
+	}
+}
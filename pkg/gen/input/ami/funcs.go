@@ -1,94 +1,108 @@
 package ami
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
-	"os"
 	"strings"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/giantswarm/microerror"
 	"golang.org/x/net/html"
 )
 
-func getAMIInfoString(config Config) (string, error) {
-	existing := map[string]map[string]string{}
-	if config.KeepExisting != "" {
-		// Read versions already defined in file.
-		data, err := os.ReadFile(config.KeepExisting)
-		if err != nil {
-			return "", microerror.Mask(err)
-		}
-		err = json.Unmarshal(data, &existing)
-		if err != nil {
-			return "", microerror.Mask(err)
-		}
+// scrape returns the AMIs of the configured channels and architectures,
+// merged with the existing ones.
+func scrape(ctx context.Context, config Config) (Document, error) {
+	doc, err := readExisting(config)
+	if err != nil {
+		return Document{}, microerror.Mask(err)
 	}
 
-	var versions []string
-	{
-		url := fmt.Sprintf("https://%s.release.%s/%s/", config.Channel, config.PrimaryDomain, config.Arch)
-		fmt.Println("getting list of releases from", url)
-		response, err := http.Get(url) //nolint:gosec
-		if err != nil {
-			return "", microerror.Mask(err)
+	client := &http.Client{Timeout: config.HTTPTimeout}
+	for _, channel := range config.Channels {
+		for _, arch := range config.Archs {
+			f := &fetcher{
+				client:  client,
+				baseURL: fmt.Sprintf("https://%s.release.%s/%s", channel, config.PrimaryDomain, arch),
+				china: func(version string) (map[string]string, error) {
+					return getChinaFlatcarRelease(config, channel, arch, version)
+				},
+				workers: config.Workers,
+				retries: config.Retries,
+				backoff: time.Second,
+			}
+
+			existing := Releases{}
+			for version, amis := range doc.releases(channel, arch) {
+				existing[version] = amis
+			}
+
+			merged, err := mergeAMIs(ctx, f, config, existing)
+			if err != nil {
+				return Document{}, microerror.Mask(err)
+			}
+			doc.setReleases(channel, arch, merged)
 		}
+	}
+
+	return doc, nil
+}
 
-		versions, err = scrapeVersions(response.Body)
-		if err != nil {
-			return "", microerror.Mask(err)
+// mergeAMIs scrapes the releases since config.MinimumVersion which are not
+// in existing and merges them with the existing ones.
+func mergeAMIs(ctx context.Context, f *fetcher, config Config, existing Releases) (Releases, error) {
+	minimum, err := semver.NewVersion(config.MinimumVersion)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	// In incremental mode only releases newer than the newest existing one
+	// are scraped.
+	if config.Incremental {
+		for version := range existing {
+			v, err := semver.NewVersion(version)
+			if err != nil {
+				return nil, microerror.Maskf(invalidConfigError, "release %#q in %s is not a valid version", version, config.KeepExisting)
+			}
+			if !v.LessThan(minimum) {
+				minimum = semver.New(v.Major(), v.Minor(), v.Patch()+1, "", "")
+			}
 		}
 	}
 
-	mergedAMI := map[string]map[string]string{}
+	versions, err := f.versions(ctx)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var scrape []string
 	for _, version := range versions {
-		if semver.MustParse(version).LessThan(semver.MustParse(config.MinimumVersion)) {
+		if semver.MustParse(version).LessThan(minimum) {
 			continue
 		}
-		if val, found := existing[version]; found {
+		if _, found := existing[version]; found {
 			fmt.Printf("Release %s already present in %s, not scraping it\n", version, config.KeepExisting)
-			mergedAMI[version] = val
-			delete(existing, version)
 			continue
 		}
-
-		url := fmt.Sprintf("https://%s.release.%s/%s/%s/flatcar_production_ami_all.json", config.Channel, config.PrimaryDomain, config.Arch, version)
-		fmt.Println("scraping release", version)
-		response, err := http.Get(url) //nolint:gosec
-		if err != nil {
-			return "", microerror.Mask(err)
-		}
-		if response.StatusCode == 403 {
-			continue // not found, keep going
-		}
-		mergedAMI[version], err = scrapeVersionAMI(response.Body)
-		if err != nil {
-			return "", microerror.Mask(err)
-		}
-
-		chinaVersionAMI, err := getChinaFlatcarRelease(config, version)
-		if err != nil {
-			return "", microerror.Mask(err)
-		}
-
-		for region, image := range chinaVersionAMI {
-			mergedAMI[version][region] = image
-		}
+		scrape = append(scrape, version)
 	}
 
-	// Releases defined in the existing file but not scraped successfully for some reason.
-	for version, val := range existing {
-		mergedAMI[version] = val
+	merged, err := f.amis(ctx, scrape)
+	if err != nil {
+		return nil, microerror.Mask(err)
 	}
 
-	result, err := json.MarshalIndent(mergedAMI, "", "  ")
-	if err != nil {
-		return "", microerror.Mask(err)
+	// Releases defined in the existing file are kept, including the ones
+	// which are not scraped successfully for some reason.
+	for version, val := range existing {
+		merged[version] = val
 	}
 
-	return string(result), nil
+	return merged, nil
 }
 
 func scrapeVersions(source io.Reader) ([]string, error) {
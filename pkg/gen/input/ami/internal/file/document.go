@@ -0,0 +1,25 @@
+package file
+
+import (
+	"path"
+
+	"github.com/giantswarm/devctl/v8/pkg/gen/input"
+	"github.com/giantswarm/devctl/v8/pkg/gen/input/ami/internal/params"
+)
+
+func NewDocumentInput(p params.Params) input.Input {
+	i := input.Input{
+		Path:         path.Join(p.Dir, "amis.json"),
+		TemplateBody: documentTemplate,
+		TemplateData: map[string]interface{}{
+			"Document": params.Document(p),
+		},
+		// The document is read back and updated on every run, see
+		// --incremental.
+		SkipRegenCheck: true,
+	}
+
+	return i
+}
+
+var documentTemplate = `{{ .Document }}`
@@ -0,0 +1,34 @@
+package file
+
+import (
+	"github.com/giantswarm/devctl/v8/pkg/gen/input"
+	"github.com/giantswarm/devctl/v8/pkg/gen/input/ami/internal/params"
+)
+
+func NewGoInput(p params.Params) input.Input {
+	i := input.Input{
+		Path:         params.RegenerableFileName(p, "amis.go"),
+		TemplateBody: goTemplate,
+		TemplateData: map[string]interface{}{
+			"Header":        params.Header("//"),
+			"Package":       params.Package(p),
+			"GoAMIs":        params.GoAMIs(p),
+			"SchemaVersion": params.SchemaVersion(p),
+		},
+	}
+
+	return i
+}
+
+var goTemplate = `{{ .Header }}
+
+package {{ .Package }}
+
+// SchemaVersion is the version of the AMIs schema the file was generated
+// with.
+const SchemaVersion = {{ .SchemaVersion }}
+
+// AMIs maps channels, architectures, Flatcar releases and regions to AMI
+// IDs, e.g. AMIs["stable"]["amd64-usr"]["3815.2.0"]["eu-west-1"].
+var AMIs = {{ .GoAMIs }}
+`
@@ -3,3 +3,15 @@ package params
 func AMIInfoString(p Params) string {
 	return p.AMIInfoString
 }
+
+func Document(p Params) string {
+	return p.Document
+}
+
+func GoAMIs(p Params) string {
+	return p.GoAMIs
+}
+
+func SchemaVersion(p Params) int {
+	return p.SchemaVersion
+}
@@ -1,8 +1,15 @@
 package params
 
 type Params struct {
+	// AMIInfoString is the legacy JSON map of a single channel and
+	// architecture.
 	AMIInfoString string
 	// Dir is the name of the directory where the files of the resource
 	// should be generated.
 	Dir string
+	// Document is the JSON document of all channels and architectures.
+	Document string
+	// GoAMIs is the Go map literal of all channels and architectures.
+	GoAMIs        string
+	SchemaVersion int
 }
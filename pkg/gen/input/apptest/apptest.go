@@ -1,15 +1,51 @@
 package apptest
 
 import (
+	"slices"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+	"gopkg.in/yaml.v3"
+
 	"github.com/giantswarm/devctl/v8/pkg/gen/input"
 	"github.com/giantswarm/devctl/v8/pkg/gen/input/apptest/internal/file"
 	"github.com/giantswarm/devctl/v8/pkg/gen/input/apptest/internal/params"
 )
 
+const (
+	// DefaultInstallNamespace is the namespace the app is installed into
+	// when Config.InstallNamespace is empty.
+	DefaultInstallNamespace = "kube-system"
+	// DefaultProvider is the provider tested against when
+	// Config.Providers is empty.
+	DefaultProvider = "capa"
+)
+
+// AllProviders returns the CAPI providers supported by apptest-framework.
+func AllProviders() []string {
+	return []string{"capa", "capv", "capvcd", "capz", "eks"}
+}
+
 type Config struct {
 	AppName  string
 	RepoName string
 	Catalog  string
+	// Providers are the CAPI providers to test against when the suites are
+	// triggered from a PR. Defaults to DefaultProvider.
+	Providers []string
+	// InstallNamespace is the workload cluster namespace the app is
+	// installed into. Defaults to DefaultInstallNamespace.
+	InstallNamespace string
+	// Upgrade additionally generates an upgrade suite, which installs the
+	// latest released version of the app before upgrading it to the tested
+	// one.
+	Upgrade bool
+	// Workloads are waited for to become ready by the generated tests,
+	// see ParseWorkloads.
+	Workloads []Workload
+	// Values are the values the suites install the app with, e.g. the
+	// SchemaDefaults of the chart.
+	Values map[string]interface{}
 }
 
 type Apptest struct {
@@ -17,6 +53,35 @@ type Apptest struct {
 }
 
 func New(config Config) (*Apptest, error) {
+	if len(config.Providers) == 0 {
+		config.Providers = []string{DefaultProvider}
+	}
+	for _, p := range config.Providers {
+		if !slices.Contains(AllProviders(), p) {
+			return nil, microerror.Maskf(invalidConfigError, "%T.Providers must contain only <%s>, got %#q", config, strings.Join(AllProviders(), "|"), p)
+		}
+	}
+	if config.InstallNamespace == "" {
+		config.InstallNamespace = DefaultInstallNamespace
+	}
+
+	var values string
+	if len(config.Values) > 0 {
+		b, err := yaml.Marshal(config.Values)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		values = string(b)
+	}
+
+	var workloads []params.Workload
+	for _, w := range config.Workloads {
+		if w.Kind != KindDeployment && w.Kind != KindDaemonSet {
+			return nil, microerror.Maskf(invalidConfigError, "%T.Workloads kind must be one of <%s|%s>, got %#q", config, KindDaemonSet, KindDeployment, w.Kind)
+		}
+		workloads = append(workloads, params.Workload{Kind: w.Kind, Name: w.Name, Namespace: w.Namespace})
+	}
+
 	a := &Apptest{
 		params: params.Params{
 			Dir: "tests/e2e/",
@@ -24,6 +89,12 @@ func New(config Config) (*Apptest, error) {
 			AppName:  config.AppName,
 			RepoName: config.RepoName,
 			Catalog:  config.Catalog,
+
+			Providers:        config.Providers,
+			InstallNamespace: config.InstallNamespace,
+			Upgrade:          config.Upgrade,
+			Workloads:        workloads,
+			Values:           values,
 		},
 	}
 
@@ -31,10 +102,18 @@ func New(config Config) (*Apptest, error) {
 }
 
 func (a *Apptest) CreateApptest() []input.Input {
-	return []input.Input{
+	inputs := []input.Input{
 		file.NewCreateConfigInput(a.params),
-		file.NewCreateBasicSuiteTestInput(a.params),
-		file.NewCreateValuesInput(a.params),
-		file.NewCreateGoModInput(a.params),
+		file.NewCreateSuiteTestInput(a.params, params.SuiteBasic),
+		file.NewCreateValuesInput(a.params, params.SuiteBasic),
 	}
+	if a.params.Upgrade {
+		inputs = append(inputs,
+			file.NewCreateSuiteTestInput(a.params, params.SuiteUpgrade),
+			file.NewCreateValuesInput(a.params, params.SuiteUpgrade),
+		)
+	}
+	inputs = append(inputs, file.NewCreateGoModInput(a.params))
+
+	return inputs
 }
@@ -0,0 +1,189 @@
+package apptest
+
+import (
+	"context"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/giantswarm/devctl/v8/pkg/gen"
+)
+
+func Test_ChartProviders(t *testing.T) {
+	chart := `apiVersion: v2
+name: my-app
+restrictions:
+  compatibleProviders:
+    - vsphere
+    - aws
+    - openstack
+    - aws
+`
+	providers, err := ChartProviders([]byte(chart))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"capa", "capv"}; !reflect.DeepEqual(providers, want) {
+		t.Errorf("ChartProviders() = %v, want %v", providers, want)
+	}
+}
+
+func Test_ParseWorkloads(t *testing.T) {
+	manifests := `---
+# Source: my-app/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: my-app
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: my-app
+---
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: my-app-agent
+  namespace: monitoring
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: another
+`
+	workloads, err := ParseWorkloads([]byte(manifests), "kube-system")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Workload{
+		{Kind: KindDaemonSet, Name: "my-app-agent", Namespace: "monitoring"},
+		{Kind: KindDeployment, Name: "another", Namespace: "kube-system"},
+		{Kind: KindDeployment, Name: "my-app", Namespace: "kube-system"},
+	}
+	if !reflect.DeepEqual(workloads, want) {
+		t.Errorf("ParseWorkloads() = %v, want %v", workloads, want)
+	}
+}
+
+func Test_SchemaDefaults(t *testing.T) {
+	schema := `{
+  "$schema": "http://json-schema.org/schema#",
+  "type": "object",
+  "properties": {
+    "replicas": {"type": "integer", "default": 2},
+    "image": {
+      "type": "object",
+      "properties": {
+        "registry": {"type": "string", "default": "gsoci.azurecr.io"},
+        "tag": {"type": "string"}
+      }
+    },
+    "resources": {"type": "object", "properties": {"limits": {"type": "object"}}}
+  }
+}`
+	values, err := SchemaDefaults([]byte(schema))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]interface{}{
+		"replicas": float64(2),
+		"image":    map[string]interface{}{"registry": "gsoci.azurecr.io"},
+	}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("SchemaDefaults() = %v, want %v", values, want)
+	}
+}
+
+func Test_CreateApptest(t *testing.T) {
+	a, err := New(Config{
+		AppName:          "my-app",
+		Providers:        []string{"capa", "capz"},
+		InstallNamespace: "monitoring",
+		Upgrade:          true,
+		Workloads: []Workload{
+			{Kind: KindDaemonSet, Name: "agent", Namespace: "monitoring"},
+			{Kind: KindDeployment, Name: "my-app", Namespace: "monitoring"},
+		},
+		Values: map[string]interface{}{"replicas": 2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rendered := map[string]string{}
+	for _, i := range a.CreateApptest() {
+		out, err := gen.Render(context.Background(), i)
+		if err != nil {
+			t.Fatalf("render %s: %v", i.Path, err)
+		}
+		rendered[i.Path] = string(out)
+	}
+
+	if !strings.Contains(rendered["tests/e2e/config.yaml"], "providers:\n- capa\n- capz\n") {
+		t.Errorf("expected providers in config.yaml:\n%s", rendered["tests/e2e/config.yaml"])
+	}
+	for _, suite := range []string{"basic", "upgrade"} {
+		if got := rendered["tests/e2e/suites/"+suite+"/values.yaml"]; got != "replicas: 2\n" {
+			t.Errorf("%s values.yaml = %q, want the seeded values", suite, got)
+		}
+
+		p := "tests/e2e/suites/" + suite + "/" + suite + "_suite_test.go"
+		src := rendered[p]
+		f, err := parser.ParseFile(token.NewFileSet(), p, src, 0)
+		if err != nil {
+			t.Fatalf("%s does not parse: %v\n%s", p, err, src)
+		}
+		if f.Name.Name != suite {
+			t.Errorf("%s package = %s, want %s", p, f.Name.Name, suite)
+		}
+		for _, want := range []string{
+			`WithInstallNamespace("monitoring")`,
+			`daemonSetReady(wcClient, "monitoring", "agent")`,
+			`deploymentReady(wcClient, "monitoring", "my-app")`,
+			"func deploymentReady(",
+			"func daemonSetReady(",
+		} {
+			if !strings.Contains(src, want) {
+				t.Errorf("expected %q in %s:\n%s", want, p, src)
+			}
+		}
+	}
+	if !strings.Contains(rendered["tests/e2e/suites/upgrade/upgrade_suite_test.go"], "isUpgrade = true") {
+		t.Errorf("expected the upgrade suite to upgrade")
+	}
+}
+
+func Test_CreateApptestWithoutChart(t *testing.T) {
+	a, err := New(Config{AppName: "my-app"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inputs := a.CreateApptest()
+	if len(inputs) != 4 {
+		t.Fatalf("got %d inputs, want config, basic suite, values and go.mod", len(inputs))
+	}
+
+	out, err := gen.Render(context.Background(), inputs[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), inputs[1].Path, out, 0); err != nil {
+		t.Fatalf("basic suite does not parse: %v\n%s", err, out)
+	}
+	if !strings.Contains(string(out), "// Include calls to app tests here") || strings.Contains(string(out), "appsv1") {
+		t.Errorf("expected the skeleton basic suite:\n%s", out)
+	}
+}
+
+func Test_NewInvalidProvider(t *testing.T) {
+	_, err := New(Config{Providers: []string{"openstack"}})
+	if !IsInvalidConfig(err) {
+		t.Errorf("New() error = %v, want invalid config error", err)
+	}
+}
@@ -0,0 +1,152 @@
+package apptest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+
+	"github.com/giantswarm/microerror"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	KindDaemonSet  = "DaemonSet"
+	KindDeployment = "Deployment"
+)
+
+// chartProviders maps the providers of the compatibleProviders chart
+// restriction to the CAPI providers apptest-framework tests against.
+var chartProviders = map[string]string{
+	"aws":            "capa",
+	"azure":          "capz",
+	"cloud-director": "capvcd",
+	"eks":            "eks",
+	"vsphere":        "capv",
+}
+
+// Workload is a workload rendered by the chart which the generated tests
+// wait for to become ready.
+type Workload struct {
+	// Kind is KindDeployment or KindDaemonSet.
+	Kind      string
+	Name      string
+	Namespace string
+}
+
+// ChartProviders returns the CAPI providers of the providers listed in the
+// restrictions.compatibleProviders field of the given Chart.yaml, sorted.
+// Providers unknown to apptest-framework are left out.
+func ChartProviders(chartYAML []byte) ([]string, error) {
+	var chart struct {
+		Restrictions struct {
+			CompatibleProviders []string `yaml:"compatibleProviders"`
+		} `yaml:"restrictions"`
+	}
+	err := yaml.Unmarshal(chartYAML, &chart)
+	if err != nil {
+		return nil, microerror.Maskf(invalidChartError, "Chart.yaml: %s", err)
+	}
+
+	set := map[string]bool{}
+	for _, p := range chart.Restrictions.CompatibleProviders {
+		if capi, ok := chartProviders[p]; ok {
+			set[capi] = true
+		}
+	}
+
+	var providers []string
+	for p := range set {
+		providers = append(providers, p)
+	}
+	sort.Strings(providers)
+
+	return providers, nil
+}
+
+// ParseWorkloads returns the Deployments and DaemonSets of the given
+// manifests, as rendered by helm template, sorted by kind, namespace and
+// name. Workloads without namespace are installed into namespace.
+func ParseWorkloads(manifests []byte, namespace string) ([]Workload, error) {
+	type object struct {
+		Kind     string `yaml:"kind"`
+		Metadata struct {
+			Name      string `yaml:"name"`
+			Namespace string `yaml:"namespace"`
+		} `yaml:"metadata"`
+	}
+
+	seen := map[Workload]bool{}
+	var workloads []Workload
+
+	dec := yaml.NewDecoder(bytes.NewReader(manifests))
+	for {
+		var o object
+		err := dec.Decode(&o)
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, microerror.Maskf(invalidChartError, "rendered manifests: %s", err)
+		}
+
+		if o.Kind != KindDeployment && o.Kind != KindDaemonSet {
+			continue
+		}
+		w := Workload{Kind: o.Kind, Name: o.Metadata.Name, Namespace: o.Metadata.Namespace}
+		if w.Namespace == "" {
+			w.Namespace = namespace
+		}
+		if w.Name == "" || seen[w] {
+			continue
+		}
+		seen[w] = true
+		workloads = append(workloads, w)
+	}
+
+	sort.Slice(workloads, func(i, j int) bool {
+		a, b := workloads[i], workloads[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	return workloads, nil
+}
+
+// SchemaDefaults returns the values holding the defaults declared in the
+// given values.schema.json. Objects without defaults are left out.
+func SchemaDefaults(schema []byte) (map[string]interface{}, error) {
+	var s map[string]interface{}
+	err := json.Unmarshal(schema, &s)
+	if err != nil {
+		return nil, microerror.Maskf(invalidChartError, "values.schema.json: %s", err)
+	}
+
+	return schemaDefaults(s), nil
+}
+
+func schemaDefaults(schema map[string]interface{}) map[string]interface{} {
+	properties, _ := schema["properties"].(map[string]interface{})
+
+	values := map[string]interface{}{}
+	for name, p := range properties {
+		property, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if d, ok := property["default"]; ok {
+			values[name] = d
+			continue
+		}
+		if nested := schemaDefaults(property); len(nested) > 0 {
+			values[name] = nested
+		}
+	}
+
+	return values
+}
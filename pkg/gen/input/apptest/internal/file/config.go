@@ -16,9 +16,10 @@ func NewCreateConfigInput(p params.Params) input.Input {
 		Path:         filepath.Join(p.Dir, "config.yaml"),
 		TemplateBody: createConfigTemplate,
 		TemplateData: map[string]interface{}{
-			"appName":  params.AppName(p),
-			"repoName": params.RepoName(p),
-			"catalog":  params.Catalog(p),
+			"appName":   params.AppName(p),
+			"repoName":  params.RepoName(p),
+			"catalog":   params.Catalog(p),
+			"providers": params.Providers(p),
 		},
 		SkipRegenCheck: true,
 	}
@@ -0,0 +1,32 @@
+package file
+
+import (
+	_ "embed"
+	"path/filepath"
+	"strings"
+
+	"github.com/giantswarm/devctl/v8/pkg/gen/input"
+	"github.com/giantswarm/devctl/v8/pkg/gen/input/apptest/internal/params"
+)
+
+//go:embed suite.go.template
+var createSuiteTestTemplate string
+
+func NewCreateSuiteTestInput(p params.Params, suite string) input.Input {
+	i := input.Input{
+		Path:         filepath.Join(p.Dir, "suites", suite, suite+"_suite_test.go"),
+		TemplateBody: createSuiteTestTemplate,
+		TemplateData: map[string]interface{}{
+			"Package":          suite,
+			"Name":             strings.ToUpper(suite[:1]) + suite[1:],
+			"IsUpgrade":        suite == params.SuiteUpgrade,
+			"InstallNamespace": params.InstallNamespace(p),
+			"Workloads":        params.Workloads(p),
+			"HasDeployments":   params.HasWorkloadKind(p, "Deployment"),
+			"HasDaemonSets":    params.HasWorkloadKind(p, "DaemonSet"),
+		},
+		SkipRegenCheck: true,
+	}
+
+	return i
+}
@@ -11,11 +11,13 @@ import (
 //go:embed values.yaml.template
 var createValuesTemplate string
 
-func NewCreateValuesInput(p params.Params) input.Input {
+func NewCreateValuesInput(p params.Params, suite string) input.Input {
 	i := input.Input{
-		Path:           filepath.Join(p.Dir, "suites/basic", "values.yaml"),
-		TemplateBody:   createValuesTemplate,
-		TemplateData:   map[string]interface{}{},
+		Path:         filepath.Join(p.Dir, "suites", suite, "values.yaml"),
+		TemplateBody: createValuesTemplate,
+		TemplateData: map[string]interface{}{
+			"values": params.Values(p),
+		},
 		SkipRegenCheck: true,
 	}
 
@@ -11,3 +11,30 @@ func RepoName(p Params) string {
 func Catalog(p Params) string {
 	return p.Catalog
 }
+
+func Providers(p Params) []string {
+	return p.Providers
+}
+
+func InstallNamespace(p Params) string {
+	return p.InstallNamespace
+}
+
+func Workloads(p Params) []Workload {
+	return p.Workloads
+}
+
+// HasWorkloadKind reports whether a workload of the given kind is waited
+// for, so the suite template only declares the helpers it uses.
+func HasWorkloadKind(p Params, kind string) bool {
+	for _, w := range p.Workloads {
+		if w.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func Values(p Params) string {
+	return p.Values
+}
@@ -1,5 +1,10 @@
 package params
 
+const (
+	SuiteBasic   = "basic"
+	SuiteUpgrade = "upgrade"
+)
+
 type Params struct {
 	// Dir is the name of the directory where the files of the resource
 	// should be generated.
@@ -8,4 +13,17 @@ type Params struct {
 	AppName  string
 	RepoName string
 	Catalog  string
+
+	Providers        []string
+	InstallNamespace string
+	Upgrade          bool
+	Workloads        []Workload
+	// Values is the YAML of the values the suites install the app with.
+	Values string
+}
+
+type Workload struct {
+	Kind      string
+	Name      string
+	Namespace string
 }
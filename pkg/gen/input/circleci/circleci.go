@@ -1,6 +1,8 @@
 package circleci
 
 import (
+	"slices"
+
 	"github.com/giantswarm/microerror"
 
 	"github.com/giantswarm/devctl/v8/pkg/gen"
@@ -11,11 +13,12 @@ import (
 )
 
 // OrbVersion is the aligned giantswarm/architect orb version every generated
-// CircleCI config pins. It is baked in next to the template -- not a flag and
-// not passed in by callers -- so that an orb bump (which can change the
-// template's required job/param shape, i.e. a cross-major compatibility
-// contract) forces a new devctl release rather than silently combining a stale
-// template with a newer orb at generation time.
+// CircleCI config pins by default. It is baked in next to the template so
+// that an orb bump (which can change the template's required job/param shape,
+// i.e. a cross-major compatibility contract) ships with a new devctl release
+// by default. Repos and fleet rollouts can pin another version, or "latest",
+// at generation time via Config.OrbVersion (--orb-version or the orb
+// manifest); `devctl gen circleci --check-orb` reports repos that lag.
 //
 // Renovate keeps this current; a major bump lands as a devctl PR, gets released,
 // and only then reaches repos via the align-files devctl pin.
@@ -32,8 +35,8 @@ const OrbVersion = "10.0.0"
 // ContinuationOrbVersion pins the circleci/continuation orb used by the
 // generated setup config (.circleci/config.yml) to merge the optional
 // repo-owned .circleci/custom.yml into .circleci/workflows.yml at pipeline
-// runtime. Baked in for the same reason as OrbVersion: a bump ships with a
-// devctl release and reaches repos via align-files.
+// runtime. Baked in for the same reason as OrbVersion, and overridable the
+// same way via Config.ContinuationOrbVersion.
 //
 // renovate: datasource=orb depName=circleci/continuation
 const ContinuationOrbVersion = "2.0.1"
@@ -98,16 +101,16 @@ const DefaultNodeTestTarget = "test"
 // cli go-build job uses.
 const DefaultNodeResourceClass = "large"
 
-// Package-manager values detected from the lockfile. Yarn Berry and Yarn
-// Classic are distinguished because their install commands and cache
-// directories differ (Berry: `--immutable` + .yarn/cache; Classic:
-// `--frozen-lockfile` + ~/.cache/yarn), and the two cannot be told apart from
-// the lockfile name alone.
+// Package-manager values detected from the lockfile, see
+// gen.DetectNodePackageManager. Yarn Berry and Yarn Classic are distinguished
+// because their install commands and cache directories differ (Berry:
+// `--immutable` + .yarn/cache; Classic: `--frozen-lockfile` + ~/.cache/yarn),
+// and the two cannot be told apart from the lockfile name alone.
 const (
-	PackageManagerNPM         = "npm"
-	PackageManagerYarn        = "yarn"
-	PackageManagerYarnClassic = "yarn-classic"
-	PackageManagerPNPM        = "pnpm"
+	PackageManagerNPM         = gen.PackageManagerNPM
+	PackageManagerYarn        = gen.PackageManagerYarn
+	PackageManagerYarnClassic = gen.PackageManagerYarnClassic
+	PackageManagerPNPM        = gen.PackageManagerPNPM
 )
 
 // Lockfile names the cache is keyed on, per package manager.
@@ -187,6 +190,84 @@ func nodeToolchainFor(packageManager string) nodeToolchain {
 	}
 }
 
+// DefaultPythonImageVersion is the cimg/python tag the generated Python job
+// runs on when a repo does not pin its own via .python-version. Baked in and
+// Renovate-managed for the same reason as DefaultNodeImageVersion. Unlike the
+// Node job, a major.minor tag is enough: CPython wheels and virtualenvs are
+// tied to the minor version, so the patch does not need to salt the cache.
+//
+// renovate: datasource=docker depName=cimg/python
+const DefaultPythonImageVersion = "3.13"
+
+// Python package-manager values detected from the lockfile, see
+// gen.DetectPythonPackageManager. pip is the fallback for repos with neither a
+// uv.lock nor a poetry.lock.
+const (
+	PackageManagerUV     = gen.PackageManagerUV
+	PackageManagerPoetry = gen.PackageManagerPoetry
+	PackageManagerPip    = gen.PackageManagerPip
+)
+
+// pythonTestJobName is the name of the generated Python job. It only tests; the
+// image builds from source in the Dockerfile, so there is no build output to
+// hand off.
+const pythonTestJobName = "python-test"
+
+// pythonToolchain is the per-package-manager setup, install, and test command
+// and cache location the Python job renders, the analogue of nodeToolchain.
+type pythonToolchain struct {
+	// setupCommand installs the package manager itself. Empty for the ones
+	// cimg/python bundles (pip, poetry).
+	setupCommand string
+	// actionsSetupCommand is setupCommand for the GitHub Actions job, whose
+	// plain python image only bundles pip.
+	actionsSetupCommand string
+	installCommand      string
+	testCommand         string
+	cachePath           string
+	lockfile            string
+}
+
+func pythonToolchainFor(packageManager string, pyproject bool) pythonToolchain {
+	switch packageManager {
+	case PackageManagerUV:
+		return pythonToolchain{
+			setupCommand:        "pip install uv",
+			actionsSetupCommand: "pip install uv",
+			installCommand:      "uv sync --frozen",
+			testCommand:         "uv run pytest",
+			cachePath:           "~/.cache/uv",
+			lockfile:            "uv.lock",
+		}
+	case PackageManagerPoetry:
+		return pythonToolchain{
+			actionsSetupCommand: "pip install poetry",
+			installCommand:      "poetry install --no-interaction",
+			testCommand:         "poetry run pytest",
+			cachePath:           "~/.cache/pypoetry",
+			lockfile:            "poetry.lock",
+		}
+	}
+
+	// PackageManagerPip is the default for an unset value.
+	if pyproject {
+		// pytest is installed explicitly for projects without a test
+		// extra; pip only warns about the missing extra.
+		return pythonToolchain{
+			installCommand: "pip install '.[test]' pytest",
+			testCommand:    "python -m pytest",
+			cachePath:      "~/.cache/pip",
+			lockfile:       "pyproject.toml",
+		}
+	}
+	return pythonToolchain{
+		installCommand: "pip install -r requirements.txt",
+		testCommand:    "python -m pytest",
+		cachePath:      "~/.cache/pip",
+		lockfile:       "requirements.txt",
+	}
+}
+
 // DefaultBuildConcurrency and DefaultResourceClass are the go-build knobs the
 // cli flavour applies when a repo does not override them. They match the
 // long-standing template hardcodes, so cli repos that set neither render the
@@ -202,8 +283,14 @@ type Config struct {
 	// RepoName is the repository name, used for the binary, chart, and job
 	// names.
 	RepoName string
-	// Language is the repo language. "go" selects the go-build job.
+	// Language is the repo language. "go" selects the go-build job, "node"
+	// the Node job, and "python" the Python job.
 	Language gen.Language
+	// AdditionalLanguages are languages built next to Language, each with its
+	// own build job, e.g. "node" for the web UI of a Go backend. The image and
+	// chart jobs wait on all build jobs. Only "go", "node", and "python" have
+	// a build job.
+	AdditionalLanguages []gen.Language
 	// Flavours are the devctl gen flavours. The "app" flavour selects the
 	// chart pipeline.
 	Flavours gen.FlavourSlice
@@ -241,8 +328,7 @@ type Config struct {
 	// ImagePreBuildJob names a repo-owned custom.yml job the image build must
 	// wait on (adds a `requires` entry to push-to-registries-release and the
 	// branch build-image / push-to-registries job). Used for workspace-handoff
-	// pre-steps the append-only custom.yml merge cannot inject into a generated
-	// job. Empty for the common case.
+	// pre-steps. Empty for the common case.
 	ImagePreBuildJob string
 	// ImageDockerfile overrides the Dockerfile path on the image jobs (the
 	// architect push-to-registries `dockerfile` param). A non-empty value also
@@ -305,6 +391,33 @@ type Config struct {
 	// "node-build" and emits persist_to_workspace; empty names it "node-test".
 	// Only applies to a Node repo.
 	NodeBuildOutput string
+	// PythonPackageManager selects the Python package manager the test job
+	// uses (one of "uv", "poetry", "pip"). The runner detects it from the
+	// lockfile; empty defaults to pip. Only applies to a Python repo.
+	PythonPackageManager string
+	// PythonImageVersion pins the cimg/python tag the test job runs on, and
+	// with it the cache-key salt. The runner detects it from the repo's
+	// .python-version; empty falls back to DefaultPythonImageVersion. Only
+	// applies to a Python repo.
+	PythonImageVersion string
+	// PythonPyproject marks a pip repo without a requirements.txt, which
+	// declares its dependencies in pyproject.toml only: the test job installs
+	// the project with its test extras and keys the cache on pyproject.toml.
+	// The runner detects it. Only applies with pip.
+	PythonPyproject bool
+	// OrbVersion pins the giantswarm/architect orb. Empty falls back to the
+	// baked-in OrbVersion. The runner takes it from --orb-version or the orb
+	// manifest, resolving "latest" with ResolveOrbVersions first.
+	OrbVersion string
+	// ContinuationOrbVersion pins the circleci/continuation orb of the setup
+	// config. Empty falls back to the baked-in ContinuationOrbVersion.
+	ContinuationOrbVersion string
+}
+
+// hasLanguage reports whether the repo builds the given language, as its
+// Language or one of its AdditionalLanguages.
+func (c Config) hasLanguage(l gen.Language) bool {
+	return c.Language == l || slices.Contains(c.AdditionalLanguages, l)
 }
 
 // shipsBinaries reports whether the repo distributes cross-platform Go binaries
@@ -312,10 +425,11 @@ type Config struct {
 // users download a binary, as opposed to a chart-wrapped service or operator.
 // Requires Go -- the binary comes from go-build.
 func (c Config) shipsBinaries() bool {
-	return c.Language == gen.LanguageGo && c.Flavours.Contains(gen.FlavourCLI)
+	return c.hasLanguage(gen.LanguageGo) && c.Flavours.Contains(gen.FlavourCLI)
 }
 
 type CircleCI struct {
+	config Config
 	params params.Params
 }
 
@@ -327,15 +441,44 @@ func New(config Config) (*CircleCI, error) {
 	// HasDockerfile from a root os.Stat that misses it, so the explicit path
 	// also turns the image pipeline on.
 	hasDockerfile := config.HasDockerfile || config.ImageDockerfile != ""
-	isNode := config.Language == gen.LanguageNode
-	if config.Language != gen.LanguageGo && !isNode && !hasDockerfile && !hasApp {
-		return nil, microerror.Maskf(invalidConfigError, "no jobs would be generated: set --language=go or --language=node, add a Dockerfile, or use the app flavour")
+	isGo := config.hasLanguage(gen.LanguageGo)
+	isNode := config.hasLanguage(gen.LanguageNode)
+	isPython := config.hasLanguage(gen.LanguagePython)
+	if !isGo && !isNode && !isPython && !hasDockerfile && !hasApp {
+		return nil, microerror.Maskf(invalidConfigError, "no jobs would be generated: set --language=go, --language=node or --language=python, add a Dockerfile, or use the app flavour")
+	}
+
+	for _, l := range config.AdditionalLanguages {
+		switch l {
+		case gen.LanguageGo, gen.LanguageNode, gen.LanguagePython:
+		default:
+			return nil, microerror.Maskf(invalidConfigError, "additional language %#q has no build job, must be one of go, node, python", l)
+		}
+		if l == config.Language {
+			return nil, microerror.Maskf(invalidConfigError, "additional language %#q is already the language", l)
+		}
 	}
 
 	if config.ForcePublic && config.ImagePrivateOnly {
 		return nil, microerror.Maskf(invalidConfigError, "ForcePublic and ImagePrivateOnly are mutually exclusive")
 	}
 
+	orbVersion := config.OrbVersion
+	if orbVersion == "" {
+		orbVersion = OrbVersion
+	}
+	continuationOrbVersion := config.ContinuationOrbVersion
+	if continuationOrbVersion == "" {
+		continuationOrbVersion = ContinuationOrbVersion
+	}
+	orbVersions := map[string]string{OrbArchitect: orbVersion, OrbContinuation: continuationOrbVersion}
+	for _, orb := range AllOrbs() {
+		version := orbVersions[orb]
+		if version == OrbVersionLatest || !ValidOrbVersion(version) {
+			return nil, microerror.Maskf(invalidConfigError, "version %#q of orb %#q must be an exact version like 10.1.0", version, orb)
+		}
+	}
+
 	appCatalog := config.AppCatalog
 	if appCatalog == "" {
 		appCatalog = DefaultAppCatalog
@@ -361,6 +504,7 @@ func New(config Config) (*CircleCI, error) {
 		nodeCachePath            string
 		nodeCacheKey             string
 		nodeCacheRestoreKey      string
+		nodeLockfile             string
 		nodeBuildCachePaths      []string
 		nodeBuildCacheKey        string
 		nodeBuildCacheRestoreKey string
@@ -376,6 +520,7 @@ func New(config Config) (*CircleCI, error) {
 		nodeInstallCommand = tc.installCommand
 		nodeRunPrefix = tc.runPrefix
 		nodeCachePath = tc.cachePath
+		nodeLockfile = tc.lockfile
 		nodeBuildCachePaths = tc.buildCachePaths
 		nodeCorepack = tc.corepack
 		// The repo's own pin (.nvmrc, detected by the runner) wins over the
@@ -437,14 +582,55 @@ func New(config Config) (*CircleCI, error) {
 		}
 	}
 
-	// BuildJobName unifies the language-derived `requires` wiring: the image
-	// and chart jobs gate on whichever build/test job the language emits.
-	buildJobName := ""
-	switch config.Language {
-	case gen.LanguageGo:
-		buildJobName = "go-build"
-	case gen.LanguageNode:
-		buildJobName = nodeJobName
+	// Python toolchain. Like the Node job, the test job is self-contained on
+	// a cimg/python executor. Its cache is salted with the Python version as
+	// well as the package manager, since wheels built for one minor version
+	// do not load on another.
+	var (
+		pythonJobName         string
+		pythonImageVersion    string
+		pythonSetupCommand    string
+		pythonActionsSetup    string
+		pythonInstallCommand  string
+		pythonTestCommand     string
+		pythonCachePath       string
+		pythonCacheKey        string
+		pythonCacheRestoreKey string
+		pythonLockfile        string
+	)
+	if isPython {
+		tc := pythonToolchainFor(config.PythonPackageManager, config.PythonPyproject)
+		pythonJobName = pythonTestJobName
+		pythonSetupCommand = tc.setupCommand
+		pythonActionsSetup = tc.actionsSetupCommand
+		pythonInstallCommand = tc.installCommand
+		pythonTestCommand = tc.testCommand
+		pythonCachePath = tc.cachePath
+		pythonLockfile = tc.lockfile
+		pythonImageVersion = config.PythonImageVersion
+		if pythonImageVersion == "" {
+			pythonImageVersion = DefaultPythonImageVersion
+		}
+		pm := config.PythonPackageManager
+		if pm == "" {
+			pm = PackageManagerPip
+		}
+		pythonCacheRestoreKey = "python-deps-" + pm + "-v1-" + pythonImageVersion + "-"
+		pythonCacheKey = pythonCacheRestoreKey + `{{ checksum "` + tc.lockfile + `" }}`
+	}
+
+	// BuildJobNames unify the language-derived `requires` wiring: the image
+	// and chart jobs gate on every build/test job the languages emit, so a Go
+	// backend with a Node web UI hands both outputs to the image build.
+	var buildJobNames []string
+	if isGo {
+		buildJobNames = append(buildJobNames, "go-build")
+	}
+	if isNode {
+		buildJobNames = append(buildJobNames, nodeJobName)
+	}
+	if isPython {
+		buildJobNames = append(buildJobNames, pythonJobName)
 	}
 
 	// The cli flavour emits build_concurrency + resource_class; default the
@@ -463,9 +649,13 @@ func New(config Config) (*CircleCI, error) {
 	}
 
 	c := &CircleCI{
+		config: config,
 		params: params.Params{
 			RepoName:                 config.RepoName,
 			Language:                 config.Language.String(),
+			HasGo:                    isGo,
+			HasNode:                  isNode,
+			HasPython:                isPython,
 			HasDockerfile:            hasDockerfile,
 			HasApp:                   hasApp,
 			SkipATS:                  config.SkipATS,
@@ -482,9 +672,9 @@ func New(config Config) (*CircleCI, error) {
 			ReleaseBinaries:          config.shipsBinaries(),
 			BuildConcurrency:         buildConcurrency,
 			ResourceClass:            resourceClass,
-			OrbVersion:               OrbVersion,
-			ContinuationOrbVersion:   ContinuationOrbVersion,
-			BuildJobName:             buildJobName,
+			OrbVersion:               orbVersion,
+			ContinuationOrbVersion:   continuationOrbVersion,
+			BuildJobNames:            buildJobNames,
 			NodeJobName:              nodeJobName,
 			NodeImageVersion:         nodeImageVersion,
 			NodeInstallCommand:       nodeInstallCommand,
@@ -492,6 +682,7 @@ func New(config Config) (*CircleCI, error) {
 			NodeCachePath:            nodeCachePath,
 			NodeCacheKey:             nodeCacheKey,
 			NodeCacheRestoreKey:      nodeCacheRestoreKey,
+			NodeLockfile:             nodeLockfile,
 			NodeBuildCachePaths:      nodeBuildCachePaths,
 			NodeBuildCacheKey:        nodeBuildCacheKey,
 			NodeBuildCacheRestoreKey: nodeBuildCacheRestoreKey,
@@ -500,6 +691,16 @@ func New(config Config) (*CircleCI, error) {
 			NodeTestTarget:           nodeTestTarget,
 			NodeBuildTarget:          nodeBuildTarget,
 			NodeBuildOutput:          nodeBuildOutput,
+			PythonJobName:            pythonJobName,
+			PythonImageVersion:       pythonImageVersion,
+			PythonSetupCommand:       pythonSetupCommand,
+			PythonActionsSetup:       pythonActionsSetup,
+			PythonInstallCommand:     pythonInstallCommand,
+			PythonTestCommand:        pythonTestCommand,
+			PythonCachePath:          pythonCachePath,
+			PythonCacheKey:           pythonCacheKey,
+			PythonCacheRestoreKey:    pythonCacheRestoreKey,
+			PythonLockfile:           pythonLockfile,
 		},
 	}
 
@@ -519,6 +720,25 @@ func (c *CircleCI) Workflows() input.Input {
 	return file.NewWorkflowsInput(c.params)
 }
 
+// GitHubActions is the GitHub Actions equivalent of the derived pipeline,
+// written to .github/workflows/zz_generated.ci.yaml. It is generated instead
+// of SetupConfig and Workflows for repos migrated off CircleCI.
+func (c *CircleCI) GitHubActions() input.Input {
+	return file.NewGitHubActionsInput(c.params)
+}
+
+// GitHubActionsDeletion deletes the file GitHubActions generates, so a repo
+// switched back to CircleCI does not run both pipelines.
+func (c *CircleCI) GitHubActionsDeletion() input.Input {
+	return file.NewGitHubActionsDeletionInput()
+}
+
+// CircleCIDeletions delete the files SetupConfig and Workflows generate, so a
+// repo migrated to GitHub Actions does not run both pipelines.
+func (c *CircleCI) CircleCIDeletions() []input.Input {
+	return file.NewCircleCIDeletionInputs()
+}
+
 // ATSInputs returns the canonical app-test-suite (ATS) Pipfile input for
 // chart/app (.HasApp) repos, and nil otherwise. ATS chart tests run only for
 // .HasApp -- the same signal that gates the run-tests-with-ats jobs -- so the
@@ -2,15 +2,16 @@ package circleci
 
 import (
 	"bytes"
+	"context"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
-	"text/template"
 
 	"github.com/giantswarm/devctl/v8/pkg/gen"
 	"github.com/giantswarm/devctl/v8/pkg/gen/input"
+	"github.com/giantswarm/devctl/v8/pkg/gen/input/circleci/internal/file"
 )
 
 const (
@@ -23,14 +24,18 @@ const (
 	goldenSetupPath        = "testdata/setup.config.yml"
 	goldenWorkflowsPath    = "testdata/mcp-kubernetes.workflows.yml"
 	goldenCLIWorkflowsPath = "testdata/mcp-kubernetes.cli.workflows.yml"
+	goldenActionsPath      = "testdata/mcp-kubernetes.actions.yaml"
 
 	goldenNodeNPMPath       = "testdata/node-npm.workflows.yml"
 	goldenNodeYarnBerryPath = "testdata/node-yarn-berry.workflows.yml"
+	goldenPythonUVPath      = "testdata/python-uv.workflows.yml"
 
 	repoMCPKubernetes = "mcp-kubernetes"
 	repoSitesearch    = "sitesearch"
 	repoK8sTypes      = "k8s-typescript-types"
 	repoBackstage     = "backstage"
+	repoHappa         = "happa"
+	repoPython        = "mcp-prometheus"
 
 	backstageDockerfile  = "packages/backend/Dockerfile"
 	backstageBuildOutput = "packages/*/dist/*"
@@ -39,32 +44,17 @@ const (
 	resourceClassXLarge = "xlarge"
 )
 
-// mergeExpression is the yq deep-merge the generated setup config runs to
-// fold .circleci/custom.yml into .circleci/workflows.yml: maps merge, lists
-// (workflow job lists) append. Test_SetupConfigCarriesMergeExpression pins
-// this copy to the template so the two cannot drift.
-const mergeExpression = `. as $item ireduce ({}; . *+ $item)`
-
-// renderInput executes an input.Input the same way pkg/gen/internal.Execute
-// does, returning the bytes that would be written to disk.
+// renderInput renders and validates an input.Input with gen.Render,
+// returning the bytes that would be written to disk.
 func renderInput(t *testing.T, file input.Input) string {
 	t.Helper()
 
-	tpl := template.New("config")
-	if file.TemplateDelims.Left != "" {
-		tpl = tpl.Delims(file.TemplateDelims.Left, file.TemplateDelims.Right)
-	}
-	tpl, err := tpl.Parse(file.TemplateBody)
+	rendered, err := gen.Render(context.Background(), file)
 	if err != nil {
-		t.Fatalf("parse template: %v", err)
+		t.Fatalf("render %s: %v", file.Path, err)
 	}
 
-	var rendered bytes.Buffer
-	if err := tpl.Execute(&rendered, file.TemplateData); err != nil {
-		t.Fatalf("execute template: %v", err)
-	}
-
-	return rendered.String()
+	return string(rendered)
 }
 
 func newCircleCI(t *testing.T, c Config) *CircleCI {
@@ -133,10 +123,10 @@ func Test_SetupConfigIsRepoAgnostic(t *testing.T) {
 	}
 }
 
-// Test_SetupConfigCarriesMergeExpression pins the test's copy of the yq merge
-// expression to the one in the template, so Test_CustomMerge* keep testing
-// what the setup config actually runs.
-func Test_SetupConfigCarriesMergeExpression(t *testing.T) {
+// Test_SetupConfigCarriesMergeProgram verifies the setup config inlines the
+// yq merge program Test_CustomMerge* run, so they test what the setup job
+// actually runs.
+func Test_SetupConfigCarriesMergeProgram(t *testing.T) {
 	got := renderInput(t, newCircleCI(t, Config{
 		RepoName:      repoMCPKubernetes,
 		Language:      gen.LanguageGo,
@@ -144,8 +134,10 @@ func Test_SetupConfigCarriesMergeExpression(t *testing.T) {
 		HasDockerfile: true,
 	}).SetupConfig())
 
-	if !contains(got, mergeExpression) {
-		t.Errorf("setup config does not contain the merge expression %q:\n%s", mergeExpression, got)
+	for _, line := range strings.Split(file.MergeProgram, "\n") {
+		if !contains(got, line) {
+			t.Errorf("setup config does not contain merge program line %q:\n%s", line, got)
+		}
 	}
 	if !contains(got, "continuation: circleci/continuation@"+ContinuationOrbVersion) {
 		t.Errorf("setup config does not pin continuation orb %s:\n%s", ContinuationOrbVersion, got)
@@ -153,7 +145,7 @@ func Test_SetupConfigCarriesMergeExpression(t *testing.T) {
 }
 
 // findYq locates a mikefarah yq v4 binary -- the variant cimg/base ships and
-// the setup config's merge expression is written for. Some distros package it
+// the setup config's merge program is written for. Some distros package it
 // as go-yq, and a plain `yq` may be the incompatible Python jq-wrapper, so
 // the version banner is checked.
 func findYq(t *testing.T) string {
@@ -174,9 +166,10 @@ func findYq(t *testing.T) string {
 	return ""
 }
 
-// yqMerge runs the setup config's merge expression over workflows.yml +
-// custom.yml the same way the setup job does, returning the merged config.
-func yqMerge(t *testing.T, workflows, custom string) string {
+// runMerge runs the setup config's merge program over workflows.yml +
+// custom.yml the same way the setup job does, returning the merged config
+// and yq's stderr.
+func runMerge(t *testing.T, workflows, custom string) (string, string, error) {
 	t.Helper()
 
 	yq := findYq(t)
@@ -192,14 +185,25 @@ func yqMerge(t *testing.T, workflows, custom string) string {
 	}
 
 	var out, stderr bytes.Buffer
-	cmd := exec.Command(yq, "eval-all", mergeExpression, workflowsPath, customPath) // #nosec G204 -- fixed args, test-only
+	cmd := exec.Command(yq, "eval", file.MergeProgram, workflowsPath) // #nosec G204 -- fixed args, test-only
+	cmd.Env = append(os.Environ(), "CUSTOM="+customPath)
 	cmd.Stdout = &out
 	cmd.Stderr = &stderr
-	if err := cmd.Run(); err != nil {
-		t.Fatalf("yq merge failed: %v\nstderr: %s", err, stderr.String())
+	err := cmd.Run()
+
+	return out.String(), stderr.String(), err
+}
+
+// yqMerge is runMerge for custom.yml content the merge must accept.
+func yqMerge(t *testing.T, workflows, custom string) string {
+	t.Helper()
+
+	merged, stderr, err := runMerge(t, workflows, custom)
+	if err != nil {
+		t.Fatalf("yq merge failed: %v\nstderr: %s", err, stderr)
 	}
 
-	return out.String()
+	return merged
 }
 
 // yqQuery evaluates a yq expression against a YAML document and returns the
@@ -243,7 +247,7 @@ workflows:
             only: /^v.*/
 `
 
-// Test_CustomMergeAppendsJobs runs the setup config's yq expression over the
+// Test_CustomMergeAppendsJobs runs the setup config's yq program over the
 // service golden + a custom.yml fixture and verifies the merge contract: the
 // custom job definition lands in .jobs (map merge), the custom workflow entry
 // is appended to the generated build workflow's job list (list append), and
@@ -313,6 +317,149 @@ func Test_CustomMergeOwnWorkflow(t *testing.T) {
 	}
 }
 
+// Test_CustomMergeOverrides verifies custom.yml overrides patch generated
+// workflow entries by name: params deep-merge into the entry, requires append
+// to its list (pointing at a custom job), and other entries stay untouched.
+func Test_CustomMergeOverrides(t *testing.T) {
+	workflows, err := os.ReadFile(goldenWorkflowsPath) // #nosec G304 -- fixed in-package testdata path
+	if err != nil {
+		t.Fatalf("read golden: %v", err)
+	}
+
+	custom := customFixture + `
+overrides:
+  build-chart:
+    chart: mcp-kubernetes-app
+    requires:
+    - e2e-smoke
+  push-to-registries-release:
+    force-public: true
+`
+
+	merged := yqMerge(t, string(workflows), custom)
+
+	buildChart := `.workflows.build.jobs[] | select(.[].name == "build-chart") | .[]`
+	if got := yqQuery(t, merged, buildChart+".chart"); got != "mcp-kubernetes-app" {
+		t.Errorf("override did not patch build-chart chart param, got %q", got)
+	}
+	if got := yqQuery(t, merged, buildChart+`.requires | join(",")`); got != "go-build,e2e-smoke" {
+		t.Errorf("override did not append to build-chart requires, got %q", got)
+	}
+	if got := yqQuery(t, merged, buildChart+".filters.tags.only"); got != "/^v.*/" {
+		t.Errorf("override damaged build-chart filters, got %q", got)
+	}
+	release := `.workflows.build.jobs[] | select(.[].name == "push-to-registries-release") | .[]`
+	if got := yqQuery(t, merged, release+".force-public"); got != "true" {
+		t.Errorf("override did not set force-public on push-to-registries-release, got %q", got)
+	}
+	if got := yqQuery(t, merged, release+`.requires | join(",")`); got != "go-build" {
+		t.Errorf("params-only override changed push-to-registries-release requires, got %q", got)
+	}
+	if got := yqQuery(t, merged, `.workflows.build.jobs[] | select(.[].name == "push-chart-release") | .[].chart`); got != "mcp-kubernetes" {
+		t.Errorf("override leaked into push-chart-release, got chart %q", got)
+	}
+	if got := yqQuery(t, merged, "has(\"overrides\")"); got != "false" {
+		t.Errorf("overrides block leaked into the merged config")
+	}
+}
+
+// Test_CustomMergeRejectsConflicts verifies the merge fails loudly, naming
+// the offending job, on custom.yml content that references jobs that do not
+// exist or would shadow generated ones.
+func Test_CustomMergeRejectsConflicts(t *testing.T) {
+	testCases := []struct {
+		name    string
+		golden  string
+		custom  string
+		wantErr string
+	}{
+		{
+			name: "case 0: override of an unknown job",
+			custom: `overrides:
+  build-chrat:
+    chart: foo
+`,
+			wantErr: "overrides name jobs the generated workflows do not define: build-chrat",
+		},
+		{
+			name: "case 1: override renaming a generated job",
+			custom: `overrides:
+  go-build:
+    name: go-build-v2
+`,
+			wantErr: "overrides cannot rename generated jobs: go-build",
+		},
+		{
+			name:   "case 2: job definition shadowing a generated one",
+			golden: goldenPythonUVPath,
+			custom: `jobs:
+  python-test:
+    machine: true
+    steps:
+    - checkout
+`,
+			wantErr: "jobs redefine generated jobs: python-test",
+		},
+		{
+			name: "case 3: workflow entry duplicating a generated one",
+			custom: `workflows:
+  build:
+    jobs:
+    - architect/go-build:
+        name: go-build
+        binary: mcp-kubernetes
+`,
+			wantErr: "workflow entries duplicate generated jobs, patch them with overrides instead: go-build",
+		},
+		{
+			name: "case 4: override requiring a missing job",
+			custom: `overrides:
+  build-chart:
+    requires:
+    - e2e-smoke
+`,
+			wantErr: "requires name jobs missing from the workflow: build-chart requires e2e-smoke",
+		},
+		{
+			name: "case 5: custom entry requiring a missing job",
+			custom: `jobs:
+  e2e-smoke:
+    machine: true
+    steps:
+    - checkout
+workflows:
+  build:
+    jobs:
+    - e2e-smoke:
+        requires:
+        - go-biuld
+`,
+			wantErr: "requires name jobs missing from the workflow: e2e-smoke requires go-biuld",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			golden := tc.golden
+			if golden == "" {
+				golden = goldenWorkflowsPath
+			}
+			workflows, err := os.ReadFile(golden) // #nosec G304 -- fixed in-package testdata path
+			if err != nil {
+				t.Fatalf("read golden: %v", err)
+			}
+
+			_, stderr, err := runMerge(t, string(workflows), tc.custom)
+			if err == nil {
+				t.Fatalf("expected merge to fail with %q", tc.wantErr)
+			}
+			if !strings.Contains(stderr, tc.wantErr) {
+				t.Errorf("expected error %q, got stderr: %s", tc.wantErr, stderr)
+			}
+		})
+	}
+}
+
 // Test_GoldenServiceWorkflows is the golden test: generating with
 // mcp-kubernetes's signals (language go, app flavour, a Dockerfile,
 // branch-publish off) must reproduce the aligned standard byte-for-byte. The
@@ -486,9 +633,8 @@ func Test_AppCatalogOverride(t *testing.T) {
 }
 
 // Test_ImagePreBuildJob verifies the release image build gains a requires
-// entry for the named repo-owned pre-build job (a workspace-handoff pre-step
-// the append-only custom.yml merge cannot inject into a generated job), and
-// that omitting it leaves the release job's requires untouched.
+// entry for the named repo-owned pre-build job (a workspace-handoff
+// pre-step), and that omitting it leaves the release job's requires untouched.
 func Test_ImagePreBuildJob(t *testing.T) {
 	got := render(t, Config{
 		RepoName:         "agentic-platform-ui",
@@ -1436,3 +1582,265 @@ func Test_GoUnaffectedByBuildJobName(t *testing.T) {
 		t.Errorf("Go repo should not reference node-build:\n%s", got)
 	}
 }
+
+// Test_GoldenGitHubActions is the golden test for the GitHub Actions provider:
+// mcp-kubernetes's signals must render the workflow equivalent to
+// Test_GoldenServiceWorkflows byte-for-byte.
+func Test_GoldenGitHubActions(t *testing.T) {
+	got := renderInput(t, newCircleCI(t, Config{
+		RepoName:      repoMCPKubernetes,
+		Language:      gen.LanguageGo,
+		Flavours:      gen.FlavourSlice{gen.FlavourApp},
+		HasDockerfile: true,
+	}).GitHubActions())
+
+	want, err := os.ReadFile(goldenActionsPath) // #nosec G304 -- fixed in-package testdata path
+	if err != nil {
+		t.Fatalf("read golden: %v", err)
+	}
+
+	if got != string(want) {
+		t.Errorf("generated workflow does not match golden %s\n--- got ---\n%s\n--- want ---\n%s", goldenActionsPath, got, string(want))
+	}
+}
+
+// Test_GitHubActionsNode verifies the Node job of the GitHub Actions provider
+// runs on the node image and keys its caches on the lockfile of the package
+// manager, and that the image jobs download its build output.
+func Test_GitHubActionsNode(t *testing.T) {
+	got := renderInput(t, newCircleCI(t, Config{
+		RepoName:        repoBackstage,
+		Language:        gen.LanguageNode,
+		Flavours:        gen.FlavourSlice{gen.FlavourApp},
+		PackageManager:  PackageManagerPNPM,
+		NodeBuildTarget: nodeBuildTarget,
+		NodeBuildOutput: backstageBuildOutput,
+		ImageDockerfile: backstageDockerfile,
+	}).GitHubActions())
+
+	for _, want := range []string{
+		"  node-build:\n",
+		"image: node:" + DefaultNodeImageVersion + "\n",
+		"run: corepack enable\n",
+		"key: node-deps-pnpm-v1-${{ hashFiles('pnpm-lock.yaml') }}\n",
+		"run: pnpm install --frozen-lockfile\n",
+		"run: pnpm run " + nodeBuildTarget + "\n",
+		"path: " + backstageBuildOutput + "\n",
+		"DOCKERFILE: " + backstageDockerfile + "\n",
+		"          name: node-build\n",
+	} {
+		if !contains(got, want) {
+			t.Errorf("expected %q in:\n%s", want, got)
+		}
+	}
+	// pnpm has no build-output cache.
+	if contains(got, "node-build-pnpm") {
+		t.Errorf("unexpected build-output cache for pnpm:\n%s", got)
+	}
+}
+
+// Test_GitHubActionsImagePrivateOnly verifies a private-only image skips the
+// Aliyun mirror on the GitHub Actions provider too.
+func Test_GitHubActionsImagePrivateOnly(t *testing.T) {
+	got := renderInput(t, newCircleCI(t, Config{
+		RepoName:         repoMCPKubernetes,
+		Language:         gen.LanguageGo,
+		HasDockerfile:    true,
+		ImagePrivateOnly: true,
+	}).GitHubActions())
+
+	if !contains(got, `PRIVATE_ONLY: "true"`) {
+		t.Errorf("expected the release image to be private only:\n%s", got)
+	}
+	if contains(got, "  sync-china-registry:\n") {
+		t.Errorf("unexpected sync-china-registry job:\n%s", got)
+	}
+}
+
+// Test_ProviderDeletions verifies each provider deletes exactly the files the
+// other generates, so switching providers leaves one pipeline.
+func Test_ProviderDeletions(t *testing.T) {
+	c := newCircleCI(t, Config{RepoName: repoMCPKubernetes, Language: gen.LanguageGo})
+
+	var circleciPaths []string
+	for _, i := range c.CircleCIDeletions() {
+		if !i.Delete {
+			t.Errorf("expected %s to be deleted", i.Path)
+		}
+		circleciPaths = append(circleciPaths, i.Path)
+	}
+	want := []string{c.SetupConfig().Path, c.Workflows().Path}
+	if strings.Join(circleciPaths, ",") != strings.Join(want, ",") {
+		t.Errorf("CircleCIDeletions() paths = %q, want %q", circleciPaths, want)
+	}
+
+	d := c.GitHubActionsDeletion()
+	if !d.Delete || d.Path != c.GitHubActions().Path {
+		t.Errorf("GitHubActionsDeletion() = %+v, want deletion of %s", d, c.GitHubActions().Path)
+	}
+}
+
+// Test_GoldenPythonUVWorkflows is the golden test for the Python job: a uv
+// repo with a Dockerfile tests on cimg/python and gates the image on it.
+func Test_GoldenPythonUVWorkflows(t *testing.T) {
+	got := render(t, Config{
+		RepoName:             repoPython,
+		Language:             gen.LanguagePython,
+		HasDockerfile:        true,
+		PythonPackageManager: PackageManagerUV,
+	})
+
+	want, err := os.ReadFile(goldenPythonUVPath) // #nosec G304 -- fixed in-package testdata path
+	if err != nil {
+		t.Fatalf("read golden: %v", err)
+	}
+
+	if got != string(want) {
+		t.Errorf("generated workflows do not match golden %s\n--- got ---\n%s\n--- want ---\n%s", goldenPythonUVPath, got, string(want))
+	}
+}
+
+// Test_PythonToolchains verifies each Python package manager renders its own
+// install and test commands and a cache salted with the Python version.
+func Test_PythonToolchains(t *testing.T) {
+	testCases := []struct {
+		packageManager string
+		version        string
+		pyproject      bool
+		want           []string
+		notWant        []string
+		actionsWant    []string
+	}{
+		{
+			packageManager: PackageManagerPoetry,
+			version:        "3.12",
+			want: []string{
+				"image: cimg/python:3.12\n",
+				"- python-deps-poetry-v1-3.12-{{ checksum \"poetry.lock\" }}\n",
+				"command: poetry install --no-interaction\n",
+				"command: poetry run pytest\n",
+			},
+			notWant: []string{"Install package manager"},
+			// The python image of the Actions job only bundles pip.
+			actionsWant: []string{
+				"run: pip install poetry\n",
+				"run: poetry install --no-interaction\n",
+			},
+		},
+		{
+			packageManager: "",
+			want: []string{
+				"image: cimg/python:" + DefaultPythonImageVersion + "\n",
+				"- python-deps-pip-v1-" + DefaultPythonImageVersion + "-{{ checksum \"requirements.txt\" }}\n",
+				"command: pip install -r requirements.txt\n",
+				"command: python -m pytest\n",
+			},
+			notWant: []string{"Install package manager"},
+		},
+		{
+			// pip without a requirements.txt installs the project and
+			// pytest, and keys the cache on pyproject.toml.
+			packageManager: PackageManagerPip,
+			pyproject:      true,
+			want: []string{
+				"- python-deps-pip-v1-" + DefaultPythonImageVersion + "-{{ checksum \"pyproject.toml\" }}\n",
+				"command: pip install '.[test]' pytest\n",
+				"command: python -m pytest\n",
+			},
+			notWant: []string{"Install package manager", "requirements.txt"},
+			actionsWant: []string{
+				"key: python-deps-pip-v1-" + DefaultPythonImageVersion + "-${{ hashFiles('pyproject.toml') }}\n",
+				"run: pip install '.[test]' pytest\n",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.packageManager, func(t *testing.T) {
+			c := Config{
+				RepoName:             repoPython,
+				Language:             gen.LanguagePython,
+				PythonPackageManager: tc.packageManager,
+				PythonImageVersion:   tc.version,
+				PythonPyproject:      tc.pyproject,
+			}
+			got := render(t, c)
+			for _, want := range tc.want {
+				if !contains(got, want) {
+					t.Errorf("expected %q in:\n%s", want, got)
+				}
+			}
+			for _, notWant := range tc.notWant {
+				if contains(got, notWant) {
+					t.Errorf("unexpected %q in:\n%s", notWant, got)
+				}
+			}
+
+			actions := renderInput(t, newCircleCI(t, c).GitHubActions())
+			for _, want := range tc.actionsWant {
+				if !contains(actions, want) {
+					t.Errorf("expected %q in:\n%s", want, actions)
+				}
+			}
+		})
+	}
+}
+
+// Test_GoAndNodeBuildJobs verifies a Go backend with a Node web UI gets both
+// build jobs, and that the image and chart jobs wait on both.
+func Test_GoAndNodeBuildJobs(t *testing.T) {
+	c := Config{
+		RepoName:            repoHappa,
+		Language:            gen.LanguageGo,
+		AdditionalLanguages: []gen.Language{gen.LanguageNode},
+		Flavours:            gen.FlavourSlice{gen.FlavourApp},
+		HasDockerfile:       true,
+		NodeBuildOutput:     "dist",
+	}
+	got := render(t, c)
+
+	for _, want := range []string{jobGoBuild, "  node-build:\n", "    - node-build:\n"} {
+		if !contains(got, want) {
+			t.Errorf("expected %q in:\n%s", want, got)
+		}
+	}
+	// build-image, push-to-registries-release, and build-chart gate on both.
+	if n := strings.Count(got, "        - go-build\n        - node-build\n"); n != 3 {
+		t.Errorf("expected 3 requires lists with both build jobs, found %d:\n%s", n, got)
+	}
+
+	actions := renderInput(t, newCircleCI(t, c).GitHubActions())
+	for _, want := range []string{"- name: Download go-build output\n", "- name: Download node-build output\n"} {
+		if n := strings.Count(actions, want); n != 2 {
+			t.Errorf("expected the image jobs to download %q twice, found %d:\n%s", want, n, actions)
+		}
+	}
+}
+
+func Test_AdditionalLanguageRejected(t *testing.T) {
+	testCases := []struct {
+		name       string
+		language   gen.Language
+		additional []gen.Language
+	}{
+		{
+			name:       "case 0: no build job",
+			language:   gen.LanguageGo,
+			additional: []gen.Language{gen.LanguageKyvernoPolicy},
+		},
+		{
+			name:       "case 1: same as the language",
+			language:   gen.LanguageGo,
+			additional: []gen.Language{gen.LanguageGo},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := New(Config{RepoName: repoHappa, Language: tc.language, AdditionalLanguages: tc.additional})
+			if !IsInvalidConfig(err) {
+				t.Errorf("New() error = %v, want invalid config error", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,343 @@
+package circleci
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+	"gopkg.in/yaml.v3"
+
+	"github.com/giantswarm/devctl/v8/pkg/gen"
+)
+
+// Formats Explain writes the job graph in.
+const (
+	// ExplainFormatText is a plain-text listing, one block per job.
+	ExplainFormatText = "text"
+	// ExplainFormatDOT is a Graphviz digraph, e.g. for `dot -Tsvg`.
+	ExplainFormatDOT = "dot"
+	// ExplainFormatMermaid is a Mermaid flowchart, which GitHub renders in
+	// Markdown.
+	ExplainFormatMermaid = "mermaid"
+)
+
+// AllExplainFormats returns the formats Explain supports.
+func AllExplainFormats() []string {
+	return []string{ExplainFormatText, ExplainFormatDOT, ExplainFormatMermaid}
+}
+
+// explainWorkflow is the one workflow the generated workflows.yml defines.
+const explainWorkflow = "build"
+
+// WorkflowJob is one entry of the generated build workflow: a node of the job
+// graph Explain prints.
+type WorkflowJob struct {
+	// Name is the entry name other entries require it by.
+	Name string
+	// Job is the job the entry runs, e.g. architect/go-build for an orb job or
+	// python-test for a job defined in workflows.yml.
+	Job string
+	// Requires are the entries this one waits on. A name that is not an entry
+	// of the workflow is a repo-owned custom.yml job.
+	Requires []string
+	// Branches describes the branches the entry runs on, e.g. "all" or "all
+	// except main". Empty when it runs on tags only.
+	Branches string
+	// Tags is the tag pattern the entry runs on. Empty when it runs on
+	// branches only.
+	Tags string
+	// Reasons are the flags and repo files that made the generator include
+	// the entry.
+	Reasons []string
+}
+
+// WorkflowJobs renders workflows.yml and returns the entries of its build
+// workflow in pipeline order, each with the filters it runs under and the
+// reasons it was generated.
+func (c *CircleCI) WorkflowJobs(ctx context.Context) ([]WorkflowJob, error) {
+	rendered, err := gen.Render(ctx, c.Workflows())
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var doc struct {
+		Workflows map[string]struct {
+			Jobs []map[string]struct {
+				Name     string   `yaml:"name"`
+				Requires []string `yaml:"requires"`
+				Filters  struct {
+					Branches map[string]yaml.Node `yaml:"branches"`
+					Tags     map[string]yaml.Node `yaml:"tags"`
+				} `yaml:"filters"`
+			} `yaml:"jobs"`
+		} `yaml:"workflows"`
+	}
+	err = yaml.Unmarshal(rendered, &doc)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var jobs []WorkflowJob
+	for _, entry := range doc.Workflows[explainWorkflow].Jobs {
+		for job, params := range entry {
+			name := params.Name
+			if name == "" {
+				name = job
+			}
+
+			jobs = append(jobs, WorkflowJob{
+				Name:     name,
+				Job:      job,
+				Requires: params.Requires,
+				Branches: describeBranches(params.Filters.Branches),
+				Tags:     strings.Join(filterValues(params.Filters.Tags["only"]), ", "),
+				Reasons:  c.reasons(name),
+			})
+		}
+	}
+
+	return jobs, nil
+}
+
+// describeBranches turns a CircleCI branches filter into prose. No filter
+// runs on every branch, and ignoring /.*/ is the convention for tag-only
+// entries.
+func describeBranches(filter map[string]yaml.Node) string {
+	if only := filterValues(filter["only"]); len(only) > 0 {
+		return "only " + strings.Join(only, ", ")
+	}
+
+	ignore := filterValues(filter["ignore"])
+	switch {
+	case len(ignore) == 0:
+		return "all"
+	case slices.Contains(ignore, "/.*/"):
+		return ""
+	default:
+		return "all except " + strings.Join(ignore, ", ")
+	}
+}
+
+// filterValues returns the patterns of a filter value, which CircleCI accepts
+// as a single string or a list.
+func filterValues(n yaml.Node) []string {
+	var values []string
+	switch n.Kind {
+	case yaml.ScalarNode:
+		values = append(values, n.Value)
+	case yaml.SequenceNode:
+		for _, v := range n.Content {
+			values = append(values, v.Value)
+		}
+	}
+
+	return values
+}
+
+// reasons names the flags and repo files that made the generator include
+// the named entry, mirroring the conditions in workflows.yml.template.
+func (c *CircleCI) reasons(name string) []string {
+	language := func(l gen.Language) string {
+		if c.config.Language == l {
+			return "--language " + l.String()
+		}
+		return "--additional-language " + l.String()
+	}
+	image := "Dockerfile present"
+	if c.config.ImageDockerfile != "" {
+		image = "--image-dockerfile " + c.config.ImageDockerfile
+	}
+
+	switch name {
+	case "go-build":
+		return []string{language(gen.LanguageGo)}
+	case "upload-release-assets":
+		return []string{language(gen.LanguageGo), "--flavour cli"}
+	case c.params.NodeJobName:
+		if c.params.NodeBuildOutput != "" {
+			return []string{language(gen.LanguageNode), "--node-build-output " + c.params.NodeBuildOutput}
+		}
+		return []string{language(gen.LanguageNode)}
+	case pythonTestJobName:
+		return []string{language(gen.LanguagePython)}
+	case "build-image":
+		return []string{image, "no --branch-publish"}
+	case "push-to-registries":
+		return []string{image, "--branch-publish"}
+	case "push-to-registries-release":
+		return []string{image}
+	case "sync-china-registry":
+		return []string{image, "no --image-private-only"}
+	case "build-chart", "push-chart-release":
+		return []string{"--flavour app"}
+	case "execute-chart-tests", "execute-chart-tests-release":
+		return []string{"--flavour app", "no --skip-ats"}
+	case "push-chart":
+		return []string{"--flavour app", "--branch-publish"}
+	}
+
+	return nil
+}
+
+// Explain writes the job graph of jobs to w in the given format, one of
+// AllExplainFormats. Requires naming no entry of jobs are drawn as custom.yml
+// jobs.
+func Explain(w io.Writer, jobs []WorkflowJob, format string) error {
+	var err error
+	switch format {
+	case ExplainFormatText:
+		err = explainText(w, jobs)
+	case ExplainFormatDOT:
+		err = explainDOT(w, jobs)
+	case ExplainFormatMermaid:
+		err = explainMermaid(w, jobs)
+	default:
+		return microerror.Maskf(invalidConfigError, "explain format must be one of <%s>, got %#q", strings.Join(AllExplainFormats(), "|"), format)
+	}
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func explainText(w io.Writer, jobs []WorkflowJob) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "workflow %s\n", explainWorkflow)
+
+	for _, j := range jobs {
+		requires := "-"
+		if len(j.Requires) > 0 {
+			var names []string
+			for _, r := range j.Requires {
+				if !isEntry(jobs, r) {
+					r += " (custom.yml)"
+				}
+				names = append(names, r)
+			}
+			requires = strings.Join(names, ", ")
+		}
+
+		fmt.Fprintf(&b, "\n%s (%s)\n", j.Name, j.Job)
+		fmt.Fprintf(&b, "  branches: %s\n", orNone(j.Branches))
+		fmt.Fprintf(&b, "  tags:     %s\n", orNone(j.Tags))
+		fmt.Fprintf(&b, "  requires: %s\n", requires)
+		fmt.Fprintf(&b, "  why:      %s\n", strings.Join(j.Reasons, ", "))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func explainDOT(w io.Writer, jobs []WorkflowJob) error {
+	quote := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %q {\n", explainWorkflow)
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+
+	for _, j := range jobs {
+		var lines []string
+		for _, l := range label(j) {
+			lines = append(lines, quote.Replace(l))
+		}
+		fmt.Fprintf(&b, "  \"%s\" [label=\"%s\"];\n", quote.Replace(j.Name), strings.Join(lines, `\n`))
+	}
+	for _, name := range customJobs(jobs) {
+		fmt.Fprintf(&b, "  \"%s\" [label=\"%s\\n(custom.yml)\", style=dashed];\n", quote.Replace(name), quote.Replace(name))
+	}
+	for _, j := range jobs {
+		for _, r := range j.Requires {
+			fmt.Fprintf(&b, "  \"%s\" -> \"%s\";\n", quote.Replace(r), quote.Replace(j.Name))
+		}
+	}
+
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// mermaidIDRE matches the characters Mermaid node IDs cannot carry.
+var mermaidIDRE = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+func explainMermaid(w io.Writer, jobs []WorkflowJob) error {
+	id := func(name string) string { return mermaidIDRE.ReplaceAllString(name, "_") }
+	quote := strings.NewReplacer(`"`, "#quot;")
+
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+
+	for _, j := range jobs {
+		fmt.Fprintf(&b, "  %s[\"%s\"]\n", id(j.Name), quote.Replace(strings.Join(label(j), "<br/>")))
+	}
+	for _, name := range customJobs(jobs) {
+		fmt.Fprintf(&b, "  %s[\"%s<br/>(custom.yml)\"]:::custom\n", id(name), quote.Replace(name))
+	}
+	for _, j := range jobs {
+		for _, r := range j.Requires {
+			fmt.Fprintf(&b, "  %s --> %s\n", id(r), id(j.Name))
+		}
+	}
+	if len(customJobs(jobs)) > 0 {
+		b.WriteString("  classDef custom stroke-dasharray: 5 5\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// label is the node label of j in the DOT and Mermaid graphs, one line per
+// element.
+func label(j WorkflowJob) []string {
+	return []string{
+		j.Name,
+		j.Job,
+		"branches: " + orNone(j.Branches),
+		"tags: " + orNone(j.Tags),
+		"why: " + strings.Join(j.Reasons, ", "),
+	}
+}
+
+// customJobs returns the requires of jobs that name no entry of jobs, which
+// are repo-owned custom.yml jobs, in first-seen order.
+func customJobs(jobs []WorkflowJob) []string {
+	var custom []string
+	for _, j := range jobs {
+		for _, r := range j.Requires {
+			if !isEntry(jobs, r) && !slices.Contains(custom, r) {
+				custom = append(custom, r)
+			}
+		}
+	}
+
+	return custom
+}
+
+func isEntry(jobs []WorkflowJob, name string) bool {
+	return slices.ContainsFunc(jobs, func(j WorkflowJob) bool { return j.Name == name })
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
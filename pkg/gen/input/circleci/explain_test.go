@@ -0,0 +1,175 @@
+package circleci
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/giantswarm/devctl/v8/pkg/gen"
+)
+
+func workflowJobs(t *testing.T, c Config) []WorkflowJob {
+	t.Helper()
+
+	jobs, err := newCircleCI(t, c).WorkflowJobs(context.Background())
+	if err != nil {
+		t.Fatalf("WorkflowJobs() returned unexpected error: %v", err)
+	}
+
+	return jobs
+}
+
+func findJob(t *testing.T, jobs []WorkflowJob, name string) WorkflowJob {
+	t.Helper()
+
+	i := slices.IndexFunc(jobs, func(j WorkflowJob) bool { return j.Name == name })
+	if i < 0 {
+		t.Fatalf("job %q not in workflow", name)
+	}
+
+	return jobs[i]
+}
+
+// Test_WorkflowJobsFilters verifies the branch and tag annotations follow the
+// generated filters: go-build runs everywhere, branch validation skips main
+// and tags, and the release jobs run on tags only.
+func Test_WorkflowJobsFilters(t *testing.T) {
+	jobs := workflowJobs(t, Config{
+		RepoName:      repoMCPKubernetes,
+		Language:      gen.LanguageGo,
+		Flavours:      gen.FlavourSlice{gen.FlavourApp},
+		HasDockerfile: true,
+	})
+
+	testCases := []struct {
+		name     string
+		job      string
+		branches string
+		tags     string
+		requires []string
+	}{
+		{name: "go-build", job: jobGoBuild, branches: "all", tags: "/^v.*/"},
+		{name: "build-image", job: jobPushRegistries, branches: "all except main", requires: []string{"go-build"}},
+		{name: "push-to-registries-release", job: jobPushRegistries, tags: "/^v.*/", requires: []string{"go-build"}},
+		{name: "sync-china-registry", job: jobSyncChina, tags: "/^v.*/", requires: []string{"push-to-registries-release"}},
+		{name: "execute-chart-tests-release", job: jobRunTests, tags: "/^v.*/", requires: []string{"build-chart", "push-to-registries-release"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := findJob(t, jobs, tc.name)
+			if got.Job != tc.job {
+				t.Errorf("job = %q, want %q", got.Job, tc.job)
+			}
+			if got.Branches != tc.branches {
+				t.Errorf("branches = %q, want %q", got.Branches, tc.branches)
+			}
+			if got.Tags != tc.tags {
+				t.Errorf("tags = %q, want %q", got.Tags, tc.tags)
+			}
+			if !slices.Equal(got.Requires, tc.requires) {
+				t.Errorf("requires = %v, want %v", got.Requires, tc.requires)
+			}
+		})
+	}
+}
+
+// Test_WorkflowJobsReasons verifies each job names the flag or repo file it
+// was generated for.
+func Test_WorkflowJobsReasons(t *testing.T) {
+	jobs := workflowJobs(t, Config{
+		RepoName:            repoBackstage,
+		Language:            gen.LanguageGo,
+		AdditionalLanguages: []gen.Language{gen.LanguageNode},
+		Flavours:            gen.FlavourSlice{gen.FlavourApp},
+		ImageDockerfile:     backstageDockerfile,
+		NodeBuildOutput:     backstageBuildOutput,
+		SkipATS:             true,
+	})
+
+	testCases := []struct {
+		name    string
+		reasons []string
+	}{
+		{name: "go-build", reasons: []string{"--language go"}},
+		{name: "node-build", reasons: []string{"--additional-language node", "--node-build-output " + backstageBuildOutput}},
+		{name: "push-to-registries-release", reasons: []string{"--image-dockerfile " + backstageDockerfile}},
+		{name: "build-chart", reasons: []string{"--flavour app"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := findJob(t, jobs, tc.name)
+			if !slices.Equal(got.Reasons, tc.reasons) {
+				t.Errorf("reasons = %v, want %v", got.Reasons, tc.reasons)
+			}
+		})
+	}
+
+	if slices.ContainsFunc(jobs, func(j WorkflowJob) bool { return strings.HasPrefix(j.Name, "execute-chart-tests") }) {
+		t.Errorf("--skip-ats still lists chart test jobs")
+	}
+}
+
+// Test_Explain verifies every format draws the requires edges and marks a
+// custom.yml job the image build waits on.
+func Test_Explain(t *testing.T) {
+	jobs := workflowJobs(t, Config{
+		RepoName:         repoMCPKubernetes,
+		Language:         gen.LanguageGo,
+		Flavours:         gen.FlavourSlice{gen.FlavourApp},
+		HasDockerfile:    true,
+		ImagePreBuildJob: "generate-assets",
+	})
+
+	testCases := []struct {
+		format string
+		want   []string
+	}{
+		{
+			format: ExplainFormatText,
+			want: []string{
+				"push-to-registries-release (architect/push-to-registries)\n  branches: none\n  tags:     /^v.*/\n  requires: go-build, generate-assets (custom.yml)\n  why:      Dockerfile present\n",
+			},
+		},
+		{
+			format: ExplainFormatDOT,
+			want: []string{
+				`"go-build" [label="go-build\narchitect/go-build\nbranches: all\ntags: /^v.*/\nwhy: --language go"];`,
+				`"generate-assets" [label="generate-assets\n(custom.yml)", style=dashed];`,
+				`"generate-assets" -> "push-to-registries-release";`,
+			},
+		},
+		{
+			format: ExplainFormatMermaid,
+			want: []string{
+				"flowchart LR\n",
+				`go_build["go-build<br/>architect/go-build<br/>branches: all<br/>tags: /^v.*/<br/>why: --language go"]`,
+				"generate_assets --> push_to_registries_release\n",
+				"classDef custom",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.format, func(t *testing.T) {
+			var out bytes.Buffer
+			err := Explain(&out, jobs, tc.format)
+			if err != nil {
+				t.Fatalf("Explain() returned unexpected error: %v", err)
+			}
+			for _, w := range tc.want {
+				if !contains(out.String(), w) {
+					t.Errorf("output does not contain %q:\n%s", w, out.String())
+				}
+			}
+		})
+	}
+
+	err := Explain(&bytes.Buffer{}, jobs, "svg")
+	if !IsInvalidConfig(err) {
+		t.Errorf("expected invalid config error for an unknown format, got %v", err)
+	}
+}
@@ -0,0 +1,153 @@
+package file
+
+import (
+	_ "embed"
+	"strings"
+
+	"github.com/giantswarm/devctl/v8/pkg/gen/input"
+	"github.com/giantswarm/devctl/v8/pkg/gen/input/circleci/internal/params"
+)
+
+const (
+	githubActionsPath = ".github/workflows/zz_generated.ci.yaml"
+
+	// defaultImagePlatforms is the platform list of the architect
+	// push-to-registries job when the repo does not set one.
+	defaultImagePlatforms = "linux/amd64,linux/arm64"
+
+	// releaseBinaryPlatforms matches the architectures matrix of the cli
+	// flavour go-build job.
+	releaseBinaryPlatforms = "linux/amd64,linux/arm64,darwin/amd64,darwin/arm64,windows/amd64,windows/arm64"
+)
+
+//go:embed actions.yaml.template
+var githubActionsTemplate string
+
+// NewGitHubActionsInput emits .github/workflows/zz_generated.ci.yaml: the
+// GitHub Actions equivalent of .circleci/workflows.yml, derived from the same
+// params. Defaults the architect orb applies on CircleCI (image name,
+// Dockerfile, platforms, OCI repository of the catalog) are resolved here,
+// as there is no orb on GitHub Actions.
+func NewGitHubActionsInput(p params.Params) input.Input {
+	imageName := p.ImageName
+	if imageName == "" {
+		imageName = "giantswarm/" + p.RepoName
+	}
+	imageDockerfile := p.ImageDockerfile
+	if imageDockerfile == "" {
+		imageDockerfile = "Dockerfile"
+	}
+	imagePlatforms := p.ImagePlatforms
+	if imagePlatforms == "" {
+		imagePlatforms = defaultImagePlatforms
+	}
+	// Branch images are amd64 only unless the repo pins its platforms, like
+	// the CircleCI push-to-registries branch job.
+	branchImagePlatforms := p.ImagePlatforms
+	if branchImagePlatforms == "" {
+		branchImagePlatforms = "linux/amd64"
+	}
+	binaryPlatforms := defaultImagePlatforms
+	if p.ReleaseBinaries {
+		binaryPlatforms = releaseBinaryPlatforms
+	}
+
+	// The image jobs download the outputs the build jobs upload: the Go
+	// binaries and the Node build output, if any.
+	var imageArtifacts []string
+	if p.HasGo {
+		imageArtifacts = append(imageArtifacts, "go-build")
+	}
+	if p.NodeBuildOutput != "" {
+		imageArtifacts = append(imageArtifacts, p.NodeJobName)
+	}
+
+	i := input.Input{
+		Path:         githubActionsPath,
+		TemplateBody: githubActionsTemplate,
+		TemplateDelims: input.InputTemplateDelims{
+			Left:  "{{{{",
+			Right: "}}}}",
+		},
+		TemplateData: map[string]interface{}{
+			"RepoName":                p.RepoName,
+			"HasGo":                   p.HasGo,
+			"HasNode":                 p.HasNode,
+			"HasPython":               p.HasPython,
+			"HasDockerfile":           p.HasDockerfile,
+			"HasApp":                  p.HasApp,
+			"SkipATS":                 p.SkipATS,
+			"ChartName":               p.ChartName,
+			"ChartRepository":         chartRepository(p.AppCatalog),
+			"ChartRepositoryTest":     chartRepository(p.AppCatalogTest),
+			"ForcePublic":             p.ForcePublic,
+			"BranchPublish":           p.BranchPublish,
+			"ImagePrivateOnly":        p.ImagePrivateOnly,
+			"ImageName":               imageName,
+			"ImagePlatformsOrDefault": imagePlatforms,
+			"BranchImagePlatforms":    branchImagePlatforms,
+			"ImageDockerfile":         imageDockerfile,
+			"ReleaseBinaries":         p.ReleaseBinaries,
+			"BinaryPlatforms":         binaryPlatforms,
+
+			"BuildJobNames":            p.BuildJobNames,
+			"ImageArtifacts":           imageArtifacts,
+			"NodeJobName":              p.NodeJobName,
+			"NodeImageVersion":         p.NodeImageVersion,
+			"NodeInstallCommand":       p.NodeInstallCommand,
+			"NodeRunPrefix":            p.NodeRunPrefix,
+			"NodeCachePath":            p.NodeCachePath,
+			"NodeCacheRestoreKey":      p.NodeCacheRestoreKey,
+			"NodeLockfile":             p.NodeLockfile,
+			"NodeBuildCachePaths":      p.NodeBuildCachePaths,
+			"NodeBuildCacheRestoreKey": p.NodeBuildCacheRestoreKey,
+			"NodeCorepack":             p.NodeCorepack,
+			"NodeTestTarget":           p.NodeTestTarget,
+			"NodeBuildTarget":          p.NodeBuildTarget,
+			"NodeBuildOutput":          p.NodeBuildOutput,
+
+			"PythonJobName":         p.PythonJobName,
+			"PythonImageVersion":    p.PythonImageVersion,
+			"PythonSetupCommand":    p.PythonActionsSetup,
+			"PythonInstallCommand":  p.PythonInstallCommand,
+			"PythonTestCommand":     p.PythonTestCommand,
+			"PythonCachePath":       p.PythonCachePath,
+			"PythonCacheRestoreKey": p.PythonCacheRestoreKey,
+			"PythonLockfile":        p.PythonLockfile,
+		},
+	}
+
+	return i
+}
+
+// NewGitHubActionsDeletionInput deletes the file NewGitHubActionsInput
+// generates. Wired into the CircleCI branch of the runner so switching the
+// provider back leaves the repo with one pipeline.
+func NewGitHubActionsDeletionInput() input.Input {
+	return input.Input{
+		Delete: true,
+		Path:   githubActionsPath,
+	}
+}
+
+// NewCircleCIDeletionInputs delete the files NewSetupConfigInput and
+// NewWorkflowsInput generate. Wired into the GitHub Actions branch of the
+// runner. The repo-owned .circleci/custom.yml is left alone.
+func NewCircleCIDeletionInputs() []input.Input {
+	return []input.Input{
+		{
+			Delete: true,
+			Path:   ".circleci/config.yml",
+		},
+		{
+			Delete: true,
+			Path:   ".circleci/workflows.yml",
+		},
+	}
+}
+
+// chartRepository returns the gsoci OCI repository the charts of the given
+// catalog are pushed to, e.g. charts/giantswarm for giantswarm-catalog.
+func chartRepository(catalog string) string {
+	return "charts/" + strings.TrimSuffix(catalog, "-catalog")
+}
@@ -2,6 +2,7 @@ package file
 
 import (
 	_ "embed"
+	"strings"
 
 	"github.com/giantswarm/devctl/v8/pkg/gen/input"
 	"github.com/giantswarm/devctl/v8/pkg/gen/input/circleci/internal/params"
@@ -13,6 +14,12 @@ var setupConfigTemplate string
 //go:embed workflows.yml.template
 var workflowsTemplate string
 
+// MergeProgram is the yq program the setup workflow runs to merge
+// .circleci/custom.yml (path in $CUSTOM) into the generated workflows.yml.
+//
+//go:embed merge.yq
+var MergeProgram string
+
 // NewSetupConfigInput emits .circleci/config.yml: a static dynamic-config
 // setup workflow that merges the optional repo-owned .circleci/custom.yml
 // into the generated .circleci/workflows.yml at pipeline runtime and
@@ -24,12 +31,26 @@ func NewSetupConfigInput(p params.Params) input.Input {
 		TemplateBody: setupConfigTemplate,
 		TemplateData: map[string]interface{}{
 			"ContinuationOrbVersion": p.ContinuationOrbVersion,
+			"MergeProgram":           indentProgram(MergeProgram, "              "),
 		},
 	}
 
 	return i
 }
 
+// indentProgram indents every non-blank line of program by pad, so it nests
+// in the setup job's command block scalar without trailing whitespace.
+func indentProgram(program, pad string) string {
+	lines := strings.Split(strings.TrimRight(program, "\n"), "\n")
+	for i, l := range lines {
+		if l != "" {
+			lines[i] = pad + l
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
 // NewWorkflowsInput emits .circleci/workflows.yml: the golden pipeline
 // content, derived from the repo's signals. The setup workflow continues the
 // pipeline with this file (plus the optional custom.yml merge).
@@ -40,6 +61,9 @@ func NewWorkflowsInput(p params.Params) input.Input {
 		TemplateData: map[string]interface{}{
 			"RepoName":         p.RepoName,
 			"Language":         p.Language,
+			"HasGo":            p.HasGo,
+			"HasNode":          p.HasNode,
+			"HasPython":        p.HasPython,
 			"HasDockerfile":    p.HasDockerfile,
 			"HasApp":           p.HasApp,
 			"SkipATS":          p.SkipATS,
@@ -58,7 +82,7 @@ func NewWorkflowsInput(p params.Params) input.Input {
 			"ResourceClass":    p.ResourceClass,
 			"OrbVersion":       p.OrbVersion,
 
-			"BuildJobName":             p.BuildJobName,
+			"BuildJobNames":            p.BuildJobNames,
 			"NodeJobName":              p.NodeJobName,
 			"NodeImageVersion":         p.NodeImageVersion,
 			"NodeInstallCommand":       p.NodeInstallCommand,
@@ -74,6 +98,15 @@ func NewWorkflowsInput(p params.Params) input.Input {
 			"NodeTestTarget":           p.NodeTestTarget,
 			"NodeBuildTarget":          p.NodeBuildTarget,
 			"NodeBuildOutput":          p.NodeBuildOutput,
+
+			"PythonJobName":         p.PythonJobName,
+			"PythonImageVersion":    p.PythonImageVersion,
+			"PythonSetupCommand":    p.PythonSetupCommand,
+			"PythonInstallCommand":  p.PythonInstallCommand,
+			"PythonTestCommand":     p.PythonTestCommand,
+			"PythonCachePath":       p.PythonCachePath,
+			"PythonCacheKey":        p.PythonCacheKey,
+			"PythonCacheRestoreKey": p.PythonCacheRestoreKey,
 		},
 	}
 
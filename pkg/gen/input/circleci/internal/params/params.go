@@ -11,6 +11,12 @@ type Params struct {
 	// Language is the repo language (e.g. "go"). "go" selects the go-build
 	// job.
 	Language string
+	// HasGo, HasNode, and HasPython are true when the repo builds the
+	// language, as its Language or as an additional language. Each selects
+	// the build job of the language.
+	HasGo     bool
+	HasNode   bool
+	HasPython bool
 	// HasDockerfile is true when the repo ships a Dockerfile. It selects the
 	// image pipeline (push-to-registries with split-china-push and the
 	// paired sync-china-registry job).
@@ -28,8 +34,8 @@ type Params struct {
 	// ChartName is the chart name used for the push-to-app-catalog `chart`
 	// param and the helm/<chart> directory. Defaults to RepoName. Set it for
 	// repos whose chart directory does not match the repo name (e.g.
-	// docs-proxy ships helm/docs-proxy-app). The append-only custom.yml merge
-	// cannot rename a generated job's chart, so the generator carries it.
+	// docs-proxy ships helm/docs-proxy-app). The generator carries it rather than
+	// a custom.yml override because the chart directory lookup needs it too.
 	ChartName string
 	// ForcePublic pushes the image and chart as public artifacts even though
 	// the repo is private (architect `force-public: true` on push-to-registries
@@ -54,8 +60,8 @@ type Params struct {
 	BranchPublish bool
 	// ImagePreBuildJob names a repo-owned job (defined in .circleci/custom.yml)
 	// that the release image build must wait on. The generated
-	// push-to-registries-release job gains a `requires` entry for it, which the
-	// append-only custom.yml merge cannot inject into a generated job. Used for
+	// push-to-registries-release job gains a `requires` entry for it (a custom.yml
+	// override can do the same for a single job). Used for
 	// workspace-handoff pre-steps (e.g. a job that persists a generated file the
 	// Docker build context overlays via attach_workspace). The branch
 	// build-image (and branch-publish push-to-registries) job gains the same
@@ -72,9 +78,9 @@ type Params struct {
 	// ImageName overrides the `giantswarm/<repo>` default the architect orb
 	// derives for the published image (the push-to-registries / sync-china-registry
 	// `image` param). Set it for repos whose image name differs from the repo
-	// name (e.g. kserve publishes `giantswarm/kserve-controller`). The
-	// append-only custom.yml merge cannot rename a generated job's image, so the
-	// generator carries it. Empty keeps the orb default.
+	// name (e.g. kserve publishes `giantswarm/kserve-controller`). The generator
+	// carries it so every image job agrees on the name. Empty keeps the orb
+	// default.
 	ImageName string
 	// ImagePlatforms overrides the buildx platform list for the image build
 	// (the push-to-registries `platforms` param on the build-image and
@@ -82,17 +88,14 @@ type Params struct {
 	// default (linux/amd64,linux/arm64 when no go-build .platforms file). Set it
 	// for repos whose image targets a single architecture (e.g. vllm ships an
 	// arm64-only image for DGX Spark; an amd64 build has no prebuilt wheels and
-	// fails). The append-only custom.yml merge cannot cap a generated job's
-	// platforms, so the generator carries it.
+	// fails). The generator carries it so the branch and release builds agree.
 	ImagePlatforms string
 	// ImageDockerfile overrides the Dockerfile path on the image jobs (the
 	// architect push-to-registries `dockerfile` param). Set it for repos whose
 	// Dockerfile is not at the repo root (e.g. backstage builds from
 	// packages/backend/Dockerfile). A non-empty value also forces the image
 	// pipeline on, since the root-Dockerfile derivation misses a nested
-	// Dockerfile. The append-only custom.yml merge cannot set this on a
-	// generated job, so the generator carries it. Empty keeps the orb default
-	// ("Dockerfile").
+	// Dockerfile. Empty keeps the orb default ("Dockerfile").
 	ImageDockerfile string
 	// ReleaseBinaries is true when the repo distributes cross-platform Go
 	// binaries on its GitHub Release (derived from the "cli" flavour on a Go
@@ -115,10 +118,11 @@ type Params struct {
 	// ContinuationOrbVersion is the circleci/continuation orb version the
 	// setup config pins.
 	ContinuationOrbVersion string
-	// BuildJobName is the build/test job the image and chart jobs gate on via
-	// `requires` -- "go-build" for Go, "node-build"/"node-test" for Node. Empty
-	// for languageless repos (the image/chart jobs then gate on nothing extra).
-	BuildJobName string
+	// BuildJobNames are the build/test jobs the image and chart jobs gate on
+	// via `requires` -- "go-build" for Go, "node-build"/"node-test" for Node,
+	// "python-test" for Python, one per language. Empty for languageless repos
+	// (the image/chart jobs then gate on nothing extra).
+	BuildJobNames []string
 	// NodeJobName is the generated Node job's name: "node-build" when it
 	// persists a build output for an image handoff, "node-test" otherwise.
 	// Empty for non-Node repos.
@@ -144,6 +148,9 @@ type Params struct {
 	// NodeCacheRestoreKey is the lockfile-agnostic restore_cache prefix, so a
 	// changed lockfile still warm-starts from the last good cache.
 	NodeCacheRestoreKey string
+	// NodeLockfile is the lockfile of the detected package manager. The
+	// GitHub Actions provider keys its caches on its hash.
+	NodeLockfile string
 	// NodeBuildCachePaths is the build-output cache: the materialized
 	// dependency tree (node_modules, Yarn install-state) holding compiled
 	// native addons, so a warm run skips the node-gyp rebuild the dependency
@@ -173,4 +180,35 @@ type Params struct {
 	// NodeBuildOutput is the workspace path the Node job persists for an image
 	// handoff (e.g. "packages/*/dist/*"). Empty omits persist_to_workspace.
 	NodeBuildOutput string
+	// PythonJobName is the generated Python job's name, "python-test". Empty
+	// for non-Python repos.
+	PythonJobName string
+	// PythonImageVersion is the cimg/python Docker tag the Python job runs
+	// on, taken from the repo's .python-version when it pins one. It also
+	// salts the cache keys.
+	PythonImageVersion string
+	// PythonSetupCommand installs the package manager when cimg/python does
+	// not bundle it (uv). Empty otherwise.
+	PythonSetupCommand string
+	// PythonActionsSetup installs the package manager in the GitHub Actions
+	// Python job, whose python image bundles neither uv nor poetry. Empty
+	// for pip.
+	PythonActionsSetup string
+	// PythonInstallCommand installs dependencies for the detected package
+	// manager (e.g. "uv sync --frozen").
+	PythonInstallCommand string
+	// PythonTestCommand runs pytest in the environment of the detected
+	// package manager (e.g. "uv run pytest").
+	PythonTestCommand string
+	// PythonCachePath is the dependency cache directory for the detected
+	// package manager (e.g. "~/.cache/uv").
+	PythonCachePath string
+	// PythonCacheKey is the full save_cache key, salted with the Python
+	// version and embedding the lockfile checksum.
+	PythonCacheKey string
+	// PythonCacheRestoreKey is the lockfile-agnostic restore_cache prefix.
+	PythonCacheRestoreKey string
+	// PythonLockfile is the lockfile of the detected package manager. The
+	// GitHub Actions provider keys its cache on its hash.
+	PythonLockfile string
 }
@@ -0,0 +1,242 @@
+package circleci
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/giantswarm/microerror"
+	"gopkg.in/yaml.v3"
+)
+
+// Orbs the generated config pins.
+const (
+	OrbArchitect    = "giantswarm/architect"
+	OrbContinuation = "circleci/continuation"
+)
+
+// OrbVersionLatest pins an orb to its latest release, resolved at generation
+// time with ResolveOrbVersions.
+const OrbVersionLatest = "latest"
+
+// DefaultOrbManifest is the repo-owned orb manifest the runner reads when
+// present, see ReadOrbManifest.
+const DefaultOrbManifest = ".circleci/orbs.yaml"
+
+// orbSources are the GitHub repositories whose releases publish the orbs.
+var orbSources = map[string]string{
+	OrbArchitect:    "giantswarm/architect-orb",
+	OrbContinuation: "CircleCI-Public/continuation-orb",
+}
+
+// AllOrbs returns the orbs the generated config pins.
+func AllOrbs() []string {
+	return []string{OrbArchitect, OrbContinuation}
+}
+
+// DefaultOrbVersions returns the orb versions baked into devctl, keyed by orb.
+func DefaultOrbVersions() map[string]string {
+	return map[string]string{
+		OrbArchitect:    OrbVersion,
+		OrbContinuation: ContinuationOrbVersion,
+	}
+}
+
+// ReadOrbManifest reads the orb pins of the manifest at the given path,
+// keyed by orb. Versions are exact (e.g. 10.1.0) or "latest":
+//
+//	orbs:
+//	  giantswarm/architect: 10.1.0
+//	  circleci/continuation: latest
+func ReadOrbManifest(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is provided by the user
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var manifest struct {
+		Orbs map[string]string `yaml:"orbs"`
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	err = dec.Decode(&manifest)
+	if err != nil {
+		return nil, microerror.Maskf(invalidConfigError, "%s: %s", path, err)
+	}
+
+	for orb, version := range manifest.Orbs {
+		if !slices.Contains(AllOrbs(), orb) {
+			return nil, microerror.Maskf(invalidConfigError, "%s: orb %#q is not one of <%s>", path, orb, strings.Join(AllOrbs(), "|"))
+		}
+		if !ValidOrbVersion(version) {
+			return nil, microerror.Maskf(invalidConfigError, "%s: version %#q of orb %#q must be %q or an exact version like 10.1.0", path, version, orb, OrbVersionLatest)
+		}
+	}
+
+	return manifest.Orbs, nil
+}
+
+// ValidOrbVersion reports whether version can pin an orb: an exact
+// major.minor.patch, or "latest".
+func ValidOrbVersion(version string) bool {
+	if version == OrbVersionLatest {
+		return true
+	}
+	_, err := semver.StrictNewVersion(version)
+	return err == nil
+}
+
+// LatestTagFunc returns the latest release tag of the given GitHub
+// repository.
+type LatestTagFunc func(ctx context.Context, owner, repo string) (string, error)
+
+// ResolveOrbVersions returns the version to pin for every orb: the pin in
+// pins, or the baked-in default for orbs without one. "latest" pins resolve
+// to the latest release returned by latest, which is only called for them.
+func ResolveOrbVersions(ctx context.Context, latest LatestTagFunc, pins map[string]string) (map[string]string, error) {
+	versions := DefaultOrbVersions()
+
+	for _, orb := range AllOrbs() {
+		pin := pins[orb]
+		switch pin {
+		case "":
+			continue
+		case OrbVersionLatest:
+			owner, name, _ := strings.Cut(orbSources[orb], "/")
+			tag, err := latest(ctx, owner, name)
+			if err != nil {
+				return nil, microerror.Maskf(executionFailedError, "resolving latest release of orb %s: %s", orb, err)
+			}
+			versions[orb] = strings.TrimPrefix(tag, "v")
+		default:
+			versions[orb] = pin
+		}
+	}
+
+	return versions, nil
+}
+
+// ParseOrbVersions returns the orb versions the given generated config files
+// (.circleci/config.yml and .circleci/workflows.yml) pin, keyed by orb. Orbs
+// devctl does not generate are left out.
+func ParseOrbVersions(files ...[]byte) (map[string]string, error) {
+	versions := map[string]string{}
+
+	for _, data := range files {
+		var config struct {
+			Orbs map[string]string `yaml:"orbs"`
+		}
+		err := yaml.Unmarshal(data, &config)
+		if err != nil {
+			return nil, microerror.Maskf(executionFailedError, "parsing orbs: %s", err)
+		}
+
+		for _, ref := range config.Orbs {
+			orb, version, ok := strings.Cut(ref, "@")
+			if ok && slices.Contains(AllOrbs(), orb) {
+				versions[orb] = version
+			}
+		}
+	}
+
+	return versions, nil
+}
+
+// OrbRelease is a published release of an orb.
+type OrbRelease struct {
+	Version string
+	Notes   string
+	URL     string
+}
+
+// ReleasesFunc returns the published releases of the given GitHub repository,
+// newest first.
+type ReleasesFunc func(ctx context.Context, owner, repo string) ([]OrbRelease, error)
+
+// OrbLag is an orb whose pinned version is behind its latest release.
+type OrbLag struct {
+	Orb     string
+	Current string
+	Latest  string
+	// Releases are the releases between Current (exclusive) and Latest
+	// (inclusive), newest first.
+	Releases []OrbRelease
+}
+
+// CheckOrbs compares the orb versions pinned, as returned by
+// ParseOrbVersions, with the releases returned by releases. It returns the
+// orbs that lag behind, in AllOrbs order.
+func CheckOrbs(ctx context.Context, releases ReleasesFunc, pinned map[string]string) ([]OrbLag, error) {
+	var lags []OrbLag
+
+	for _, orb := range AllOrbs() {
+		current, ok := pinned[orb]
+		if !ok {
+			continue
+		}
+		currentVersion, err := semver.NewVersion(current)
+		if err != nil {
+			return nil, microerror.Maskf(executionFailedError, "orb %s pins %#q, which is not a version", orb, current)
+		}
+
+		owner, name, _ := strings.Cut(orbSources[orb], "/")
+		all, err := releases(ctx, owner, name)
+		if err != nil {
+			return nil, microerror.Maskf(executionFailedError, "listing releases of orb %s: %s", orb, err)
+		}
+
+		lag := OrbLag{Orb: orb, Current: current}
+		for _, r := range all {
+			v, err := semver.NewVersion(r.Version)
+			if err != nil || v.Prerelease() != "" || !v.GreaterThan(currentVersion) {
+				continue
+			}
+			r.Version = strings.TrimPrefix(r.Version, "v")
+			lag.Releases = append(lag.Releases, r)
+		}
+		if len(lag.Releases) == 0 {
+			continue
+		}
+
+		slices.SortFunc(lag.Releases, func(a, b OrbRelease) int {
+			return semver.MustParse(b.Version).Compare(semver.MustParse(a.Version))
+		})
+		lag.Latest = lag.Releases[0].Version
+		lags = append(lags, lag)
+	}
+
+	return lags, nil
+}
+
+// Excerpt returns the first maxLines non-blank lines of release notes,
+// marking a cut with "...".
+func Excerpt(notes string, maxLines int) string {
+	var lines []string
+	for _, l := range strings.Split(notes, "\n") {
+		l = strings.TrimSpace(l)
+		if l == "" {
+			continue
+		}
+		if len(lines) == maxLines {
+			lines = append(lines, "...")
+			break
+		}
+		lines = append(lines, l)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// String formats the lag as a one-line summary, e.g.
+// "giantswarm/architect 9.3.0 -> 10.1.0 (3 releases)".
+func (l OrbLag) String() string {
+	unit := "releases"
+	if len(l.Releases) == 1 {
+		unit = "release"
+	}
+	return fmt.Sprintf("%s %s -> %s (%d %s)", l.Orb, l.Current, l.Latest, len(l.Releases), unit)
+}
@@ -0,0 +1,174 @@
+package circleci
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/giantswarm/devctl/v8/pkg/gen"
+)
+
+func Test_ReadOrbManifest(t *testing.T) {
+	testCases := []struct {
+		name     string
+		manifest string
+		want     map[string]string
+		wantErr  bool
+	}{
+		{
+			name:     "case 0: exact and latest pins",
+			manifest: "orbs:\n  giantswarm/architect: 10.1.0\n  circleci/continuation: latest\n",
+			want:     map[string]string{OrbArchitect: "10.1.0", OrbContinuation: OrbVersionLatest},
+		},
+		{
+			name:     "case 1: unknown orb",
+			manifest: "orbs:\n  giantswarm/architekt: 10.1.0\n",
+			wantErr:  true,
+		},
+		{
+			name:     "case 2: partial version",
+			manifest: "orbs:\n  giantswarm/architect: \"10\"\n",
+			wantErr:  true,
+		},
+		{
+			name:     "case 3: unknown field",
+			manifest: "orb:\n  giantswarm/architect: 10.1.0\n",
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "orbs.yaml")
+			if err := os.WriteFile(path, []byte(tc.manifest), 0600); err != nil { // #nosec G703 -- t.TempDir() path, test-only
+				t.Fatalf("write manifest: %v", err)
+			}
+
+			got, err := ReadOrbManifest(path)
+			if tc.wantErr {
+				if !IsInvalidConfig(err) {
+					t.Fatalf("expected invalid config error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadOrbManifest() returned unexpected error: %v", err)
+			}
+			if !maps.Equal(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// Test_ResolveOrbVersions verifies pins win over the defaults and that only
+// "latest" pins reach GitHub.
+func Test_ResolveOrbVersions(t *testing.T) {
+	var asked []string
+	latest := func(_ context.Context, owner, repo string) (string, error) {
+		asked = append(asked, owner+"/"+repo)
+		return "v10.2.0", nil
+	}
+
+	got, err := ResolveOrbVersions(context.Background(), latest, map[string]string{OrbArchitect: OrbVersionLatest})
+	if err != nil {
+		t.Fatalf("ResolveOrbVersions() returned unexpected error: %v", err)
+	}
+	want := map[string]string{OrbArchitect: "10.2.0", OrbContinuation: ContinuationOrbVersion}
+	if !maps.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if len(asked) != 1 || asked[0] != "giantswarm/architect-orb" {
+		t.Errorf("expected one lookup of giantswarm/architect-orb, got %v", asked)
+	}
+
+	got, err = ResolveOrbVersions(context.Background(), nil, map[string]string{OrbContinuation: "2.1.0"})
+	if err != nil {
+		t.Fatalf("ResolveOrbVersions() returned unexpected error: %v", err)
+	}
+	want = map[string]string{OrbArchitect: OrbVersion, OrbContinuation: "2.1.0"}
+	if !maps.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	failing := func(context.Context, string, string) (string, error) { return "", errors.New("rate limited") }
+	_, err = ResolveOrbVersions(context.Background(), failing, map[string]string{OrbContinuation: OrbVersionLatest})
+	if !IsExecutionFailed(err) {
+		t.Errorf("expected execution failed error, got %v", err)
+	}
+}
+
+// Test_OrbVersionPins verifies Config pins reach both generated files, and
+// that unresolved or partial versions are rejected.
+func Test_OrbVersionPins(t *testing.T) {
+	c := newCircleCI(t, Config{
+		RepoName:               repoMCPKubernetes,
+		Language:               gen.LanguageGo,
+		OrbVersion:             "10.2.0",
+		ContinuationOrbVersion: "2.1.0",
+	})
+
+	got, err := ParseOrbVersions([]byte(renderInput(t, c.SetupConfig())), []byte(renderInput(t, c.Workflows())))
+	if err != nil {
+		t.Fatalf("ParseOrbVersions() returned unexpected error: %v", err)
+	}
+	want := map[string]string{OrbArchitect: "10.2.0", OrbContinuation: "2.1.0"}
+	if !maps.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	for _, version := range []string{OrbVersionLatest, "10"} {
+		_, err := New(Config{RepoName: repoMCPKubernetes, Language: gen.LanguageGo, OrbVersion: version})
+		if !IsInvalidConfig(err) {
+			t.Errorf("expected invalid config error for orb version %q, got %v", version, err)
+		}
+	}
+}
+
+// Test_CheckOrbs verifies the lag report lists the releases newer than the
+// pinned version, newest first, and leaves up-to-date orbs out.
+func Test_CheckOrbs(t *testing.T) {
+	releases := func(_ context.Context, owner, repo string) ([]OrbRelease, error) {
+		switch owner + "/" + repo {
+		case "giantswarm/architect-orb":
+			return []OrbRelease{
+				{Version: "v10.0.0", Notes: "### Changed\n\n- Drop go-build `platforms` param."},
+				{Version: "v10.1.0-rc.1"},
+				{Version: "v10.1.0", Notes: "### Added\n\n- sync-china-registry retries."},
+				{Version: "v9.3.0"},
+			}, nil
+		default:
+			return []OrbRelease{{Version: "v2.0.1"}}, nil
+		}
+	}
+
+	lags, err := CheckOrbs(context.Background(), releases, map[string]string{OrbArchitect: "9.3.0", OrbContinuation: "2.0.1"})
+	if err != nil {
+		t.Fatalf("CheckOrbs() returned unexpected error: %v", err)
+	}
+	if len(lags) != 1 {
+		t.Fatalf("expected only the architect orb to lag, got %v", lags)
+	}
+
+	lag := lags[0]
+	if got := lag.String(); got != "giantswarm/architect 9.3.0 -> 10.1.0 (2 releases)" {
+		t.Errorf("summary = %q", got)
+	}
+	if lag.Releases[0].Version != "10.1.0" || lag.Releases[1].Version != "10.0.0" {
+		t.Errorf("expected releases 10.1.0, 10.0.0, got %v", lag.Releases)
+	}
+}
+
+func Test_Excerpt(t *testing.T) {
+	notes := "### Added\n\n- one\n- two\n\n- three\n"
+
+	if got := Excerpt(notes, 2); got != "### Added\n- one\n..." {
+		t.Errorf("Excerpt(2) = %q", got)
+	}
+	if got := Excerpt(notes, 4); got != "### Added\n- one\n- two\n- three" {
+		t.Errorf("Excerpt(4) = %q", got)
+	}
+}
@@ -1,15 +1,59 @@
 package dependabot
 
 import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+
+	"github.com/giantswarm/devctl/v8/pkg/gen"
 	"github.com/giantswarm/devctl/v8/pkg/gen/input"
 	"github.com/giantswarm/devctl/v8/pkg/gen/input/dependabot/internal/file"
 	"github.com/giantswarm/devctl/v8/pkg/gen/input/dependabot/internal/params"
 )
 
+var groupRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// defaultIgnore holds the ignore rules devctl applies to every update entry
+// of an ecosystem.
+var defaultIgnore = map[string][]Ignore{
+	gen.EcosystemGomod.String(): {
+		{DependencyName: "k8s.io/*", Versions: []string{">=0.19.0"}},
+	},
+	gen.EcosystemGithubActions.String(): {
+		{DependencyName: "zricethezav/gitleaks-action"},
+		{DependencyName: "actions/setup-go"},
+	},
+}
+
 type Config struct {
 	Interval   string
 	Reviewers  []string
 	Ecosystems []string
+	// Directories holds the manifest directories of a monorepo. When set,
+	// one update entry is generated per directory and ecosystem and
+	// Ecosystems is ignored. Otherwise Ecosystems are updated in the root
+	// directory.
+	Directories []Directory
+	// Rules holds registries and the groups, ignore and allow rules of
+	// the ecosystems.
+	Rules Rules
+}
+
+// Directory configures the updates of a single manifest directory.
+type Directory struct {
+	// Path is the slash separated directory path relative to the
+	// repository root, e.g. "services/api". Empty or "." is the root.
+	Path       string
+	Ecosystems []string
+	// Interval overrides Config.Interval when set.
+	Interval string
+	// Reviewers overrides Config.Reviewers when set.
+	Reviewers []string
+	// Group, when set, groups all updates of the directory into one PR
+	// per ecosystem.
+	Group string
 }
 
 type Dependabot struct {
@@ -17,15 +61,89 @@ type Dependabot struct {
 }
 
 func New(config Config) (*Dependabot, error) {
+	err := config.Rules.validate()
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	directories := config.Directories
+	if len(directories) == 0 {
+		directories = []Directory{{Ecosystems: config.Ecosystems}}
+	}
+
+	var updates []params.Update
+	for _, d := range directories {
+		interval := config.Interval
+		if d.Interval != "" {
+			interval = d.Interval
+		}
+		if !gen.IsValidSchedule(interval) {
+			return nil, microerror.Maskf(invalidConfigError, "interval of directory %#q must be one of <%s>, got %#q", d.Path, strings.Join(gen.AllowedSchedule(), "|"), interval)
+		}
+		if !gen.IsValidEcoSystem(d.Ecosystems) {
+			return nil, microerror.Maskf(invalidConfigError, "ecosystems of directory %#q must be one of <%s>", d.Path, strings.Join(gen.AllowedEcosystems(), "|"))
+		}
+
+		if d.Group != "" && !groupRegexp.MatchString(d.Group) {
+			return nil, microerror.Maskf(invalidConfigError, "group of directory %#q must match %s, got %#q", d.Path, groupRegexp, d.Group)
+		}
+
+		reviewers := config.Reviewers
+		if len(d.Reviewers) > 0 {
+			reviewers = d.Reviewers
+		}
+
+		ecosystems := append([]string(nil), d.Ecosystems...)
+		sort.Strings(ecosystems)
+		for _, e := range ecosystems {
+			rules := config.Rules.Ecosystems[e]
+
+			groups := map[string]Group{}
+			for name, g := range rules.Groups {
+				groups[name] = g
+			}
+			if d.Group != "" {
+				if _, ok := groups[d.Group]; ok {
+					return nil, microerror.Maskf(invalidConfigError, "group %#q of directory %#q is also defined for ecosystem %#q", d.Group, d.Path, e)
+				}
+				groups[d.Group] = Group{Patterns: []string{"*"}}
+			}
+
+			u := params.Update{
+				Directory:  directoryPath(d.Path),
+				Ecosystem:  e,
+				Interval:   interval,
+				Reviewers:  reviewers,
+				Registries: rules.Registries,
+			}
+			if len(groups) > 0 {
+				u.Groups = groups
+			}
+			for _, i := range append(defaultIgnore[e], rules.Ignore...) {
+				u.Ignore = append(u.Ignore, params.Ignore(i))
+			}
+			for _, a := range rules.Allow {
+				u.Allow = append(u.Allow, params.Allow(a))
+			}
+
+			updates = append(updates, u)
+		}
+	}
+
+	if len(updates) == 0 {
+		return nil, microerror.Maskf(invalidConfigError, "no ecosystems to update, set ecosystems or add manifests")
+	}
+
 	w := &Dependabot{
 		params: params.Params{
 			Dir: ".github/",
 
-			Ecosystems: config.Ecosystems,
-			Interval:   config.Interval,
-			Reviewers:  config.Reviewers,
+			Updates: updates,
 		},
 	}
+	if len(config.Rules.Registries) > 0 {
+		w.params.Registries = config.Rules.Registries
+	}
 
 	return w, nil
 }
@@ -33,3 +151,13 @@ func New(config Config) (*Dependabot, error) {
 func (d *Dependabot) CreateDependabot() input.Input {
 	return file.NewCreateDependabotInput(d.params)
 }
+
+// directoryPath returns the dependabot notation of a directory path, i.e.
+// absolute within the repository.
+func directoryPath(p string) string {
+	p = strings.Trim(p, "/")
+	if p == "" || p == "." {
+		return "/"
+	}
+	return "/" + p
+}
@@ -0,0 +1,150 @@
+package dependabot
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/giantswarm/devctl/v8/pkg/gen"
+)
+
+func render(t *testing.T, c Config) string {
+	t.Helper()
+
+	d, err := New(c)
+	if err != nil {
+		t.Fatalf("New() returned unexpected error: %v", err)
+	}
+
+	out, err := gen.Render(context.Background(), d.CreateDependabot())
+	if err != nil {
+		t.Fatalf("render dependabot.yml: %v", err)
+	}
+
+	return string(out)
+}
+
+func Test_RootEcosystems(t *testing.T) {
+	got := render(t, Config{
+		Interval:   "weekly",
+		Reviewers:  []string{"giantswarm/team-x"},
+		Ecosystems: []string{"gomod", "docker"},
+	})
+
+	docker := strings.Index(got, "package-ecosystem: docker")
+	gomod := strings.Index(got, "package-ecosystem: gomod")
+	if docker < 0 || gomod < 0 || docker > gomod {
+		t.Errorf("expected sorted docker and gomod entries:\n%s", got)
+	}
+	if strings.Count(got, `directory: "/"`) != 2 {
+		t.Errorf("expected two root entries:\n%s", got)
+	}
+	if strings.Contains(got, "groups:") {
+		t.Errorf("expected no groups without directory settings:\n%s", got)
+	}
+}
+
+func Test_Directories(t *testing.T) {
+	got := render(t, Config{
+		Interval:  "weekly",
+		Reviewers: []string{"giantswarm/team-x"},
+		Directories: []Directory{
+			{Path: ".", Ecosystems: []string{"github-actions"}},
+			{
+				Path:       "services/api",
+				Ecosystems: []string{"gomod", "docker"},
+				Interval:   "daily",
+				Reviewers:  []string{"giantswarm/team-honeybadger"},
+				Group:      "api",
+			},
+		},
+	})
+
+	want := `  - package-ecosystem: docker
+    directory: "/services/api"
+    schedule:
+      interval: daily
+      time: "04:00"
+    open-pull-requests-limit: 10
+    reviewers:
+      - giantswarm/team-honeybadger
+    groups:
+      api:
+        patterns:
+          - '*'
+  - package-ecosystem: gomod
+    directory: "/services/api"
+`
+	if !strings.Contains(got, want) {
+		t.Errorf("expected per-directory entries\n%s\nin:\n%s", want, got)
+	}
+	if !strings.Contains(got, "  - package-ecosystem: github-actions\n    directory: \"/\"\n    schedule:\n      interval: weekly") {
+		t.Errorf("expected root entry with the default interval:\n%s", got)
+	}
+}
+
+func Test_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+	}{
+		{"no ecosystems", Config{Interval: "weekly"}},
+		{"invalid directory interval", Config{Interval: "weekly", Directories: []Directory{{Path: "a", Ecosystems: []string{"npm"}, Interval: "hourly"}}}},
+		{"invalid ecosystem", Config{Interval: "weekly", Directories: []Directory{{Path: "a", Ecosystems: []string{"composer"}}}}},
+		{"directory group collides with ecosystem group", Config{
+			Interval:    "weekly",
+			Directories: []Directory{{Path: "a", Ecosystems: []string{"npm"}, Group: "all"}},
+			Rules:       Rules{Ecosystems: map[string]EcosystemRules{"npm": {Groups: map[string]Group{"all": {Patterns: []string{"*"}}}}}},
+		}},
+		{"undefined registry", Config{
+			Interval:   "weekly",
+			Ecosystems: []string{"npm"},
+			Rules:      Rules{Ecosystems: map[string]EcosystemRules{"npm": {Registries: []string{"npm-github"}}}},
+		}},
+		{"invalid group", Config{Interval: "weekly", Directories: []Directory{{Path: "a", Ecosystems: []string{"npm"}, Group: "a: b"}}}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := New(tc.config)
+			if !IsInvalidConfig(err) {
+				t.Errorf("New() error = %v, want invalid config error", err)
+			}
+		})
+	}
+}
+
+func Test_Rules(t *testing.T) {
+	got := render(t, Config{
+		Interval:   "weekly",
+		Ecosystems: []string{"npm", "gomod"},
+		Rules: Rules{
+			Registries: map[string]Registry{
+				"npm-github": {Type: "npm-registry", URL: "https://npm.pkg.github.com", Token: "${{ secrets.NPM_TOKEN }}"},
+			},
+			Ecosystems: map[string]EcosystemRules{
+				"gomod": {
+					Ignore: []Ignore{{DependencyName: "github.com/aws/*", UpdateTypes: []string{"version-update:semver-major"}}},
+				},
+				"npm": {
+					Registries: []string{"npm-github"},
+					Groups: map[string]Group{
+						"eslint": {Patterns: []string{"eslint*", "@typescript-eslint/*"}, UpdateTypes: []string{"minor", "patch"}},
+					},
+					Allow: []Allow{{DependencyType: "direct"}, {DependencyName: "@giantswarm/*"}},
+				},
+			},
+		},
+	})
+
+	for _, want := range []string{
+		"registries:\n  npm-github:\n    type: npm-registry\n    url: https://npm.pkg.github.com\n    token: ${{ secrets.NPM_TOKEN }}\nupdates:\n",
+		"    ignore:\n      - dependency-name: \"k8s.io/*\"\n        versions:\n          - \">=0.19.0\"\n      - dependency-name: \"github.com/aws/*\"\n        update-types:\n          - \"version-update:semver-major\"\n",
+		"    registries:\n      - \"npm-github\"\n    groups:\n      eslint:\n        patterns:\n          - eslint*\n          - '@typescript-eslint/*'\n        update-types:\n          - minor\n          - patch\n",
+		"    allow:\n      - dependency-type: \"direct\"\n      - dependency-name: \"@giantswarm/*\"\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected\n%s\nin:\n%s", want, got)
+		}
+	}
+}
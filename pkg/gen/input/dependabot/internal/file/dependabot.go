@@ -20,12 +20,9 @@ func NewCreateDependabotInput(p params.Params) input.Input {
 		Path:         filepath.Join(p.Dir, "dependabot.yml"),
 		TemplateBody: createDependabotTemplate,
 		TemplateData: map[string]interface{}{
-			"EcosystemGithubActions": params.EcosystemGithubActions(p),
-			"EcosystemGomod":         params.EcosystemGomod(p),
-			"Ecosystems":             params.Ecosystems(p),
-			"Header":                 params.Header("#", createDependabotTemplateSha),
-			"Interval":               params.Interval(p),
-			"Reviewers":              params.Reviewers(p),
+			"Header":     params.Header("#", createDependabotTemplateSha),
+			"Registries": params.Registries(p),
+			"Updates":    params.Updates(p),
 		},
 	}
 
@@ -2,28 +2,18 @@ package params
 
 import (
 	"sort"
-
-	"github.com/giantswarm/devctl/v8/pkg/gen"
 )
 
-func Ecosystems(p Params) []string {
-	sort.Strings(p.Ecosystems)
-	return p.Ecosystems
-}
-
-func EcosystemGithubActions(p Params) string {
-	return gen.EcosystemGithubActions.String()
-}
-
-func EcosystemGomod(p Params) string {
-	return gen.EcosystemGomod.String()
-}
-
-func Interval(p Params) string {
-	return p.Interval
+func Registries(p Params) interface{} {
+	return p.Registries
 }
 
-func Reviewers(p Params) []string {
-	sort.Strings(p.Reviewers)
-	return p.Reviewers
+func Updates(p Params) []Update {
+	updates := make([]Update, len(p.Updates))
+	for i, u := range p.Updates {
+		u.Reviewers = append([]string(nil), u.Reviewers...)
+		sort.Strings(u.Reviewers)
+		updates[i] = u
+	}
+	return updates
 }
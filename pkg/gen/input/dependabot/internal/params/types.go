@@ -4,10 +4,43 @@ type Params struct {
 	// Dir is the name of the directory where the files of the resource
 	// should be generated.
 	Dir string
-	// Ecosystems contains the ecosystem for each one package manager that you want GitHub Dependabot to monitor for new versions
-	Ecosystems []string
-	// Interval to check for daily, weekly, or monthly updates (default: weekly).
+	// Updates contains one entry per directory and package manager that
+	// you want GitHub Dependabot to monitor for new versions.
+	Updates []Update
+	// Registries are the private registries keyed by name. The value is
+	// rendered with toYaml, so it must carry yaml tags.
+	Registries interface{}
+}
+
+type Update struct {
+	// Directory is the manifest directory in dependabot notation, e.g. "/"
+	// or "/services/api".
+	Directory string
+	// Ecosystem is the package manager of the manifests, e.g. gomod.
+	Ecosystem string
+	// Interval to check for daily, weekly, or monthly updates.
 	Interval string
 	// Reviewers is a set of people or teams who are assigned as reviewers.
 	Reviewers []string
+	// Groups are the groups of the entry keyed by name. The value is
+	// rendered with toYaml, so it must carry yaml tags. Nil disables
+	// grouping.
+	Groups interface{}
+	// Ignore excludes dependencies or versions from updates.
+	Ignore []Ignore
+	// Allow restricts updates to the matching dependencies.
+	Allow []Allow
+	// Registries are the names of the registries the entry uses.
+	Registries []string
+}
+
+type Ignore struct {
+	DependencyName string
+	Versions       []string
+	UpdateTypes    []string
+}
+
+type Allow struct {
+	DependencyName string
+	DependencyType string
 }
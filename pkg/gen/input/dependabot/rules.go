@@ -0,0 +1,200 @@
+package dependabot
+
+import (
+	"bytes"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+	"gopkg.in/yaml.v3"
+
+	"github.com/giantswarm/devctl/v8/pkg/gen"
+)
+
+// registryTypes are the private registry types supported by dependabot.
+var registryTypes = []string{
+	"cargo-registry",
+	"composer-repository",
+	"docker-registry",
+	"git",
+	"goproxy-server",
+	"helm-registry",
+	"hex-organization",
+	"hex-repository",
+	"maven-repository",
+	"npm-registry",
+	"nuget-feed",
+	"pub-repository",
+	"python-index",
+	"rubygems-server",
+	"terraform-registry",
+}
+
+var updateTypes = []string{
+	"version-update:semver-major",
+	"version-update:semver-minor",
+	"version-update:semver-patch",
+}
+
+var groupUpdateTypes = []string{"major", "minor", "patch"}
+
+// Rules holds the dependabot settings beyond schedule and reviewers. It is
+// read from a YAML file, see ReadRules:
+//
+//	registries:
+//	  npm-github:
+//	    type: npm-registry
+//	    url: https://npm.pkg.github.com
+//	    token: ${{ secrets.NPM_TOKEN }}
+//	ecosystems:
+//	  npm:
+//	    registries: [npm-github]
+//	    groups:
+//	      eslint:
+//	        patterns: ["eslint*", "@typescript-eslint/*"]
+//	    ignore:
+//	      - dependency-name: react
+//	        update-types: ["version-update:semver-major"]
+//	    allow:
+//	      - dependency-type: direct
+type Rules struct {
+	// Registries are the private registries dependabot can access, keyed
+	// by name.
+	Registries map[string]Registry `yaml:"registries"`
+	// Ecosystems holds the rules applied to every update entry of an
+	// ecosystem, keyed by ecosystem, e.g. gomod.
+	Ecosystems map[string]EcosystemRules `yaml:"ecosystems"`
+}
+
+// Registry is a private registry, see
+// https://docs.github.com/en/code-security/dependabot/working-with-dependabot/configuring-access-to-private-registries-for-dependabot.
+type Registry struct {
+	Type         string `yaml:"type"`
+	URL          string `yaml:"url,omitempty"`
+	Username     string `yaml:"username,omitempty"`
+	Password     string `yaml:"password,omitempty"`
+	Key          string `yaml:"key,omitempty"`
+	Token        string `yaml:"token,omitempty"`
+	ReplacesBase bool   `yaml:"replaces-base,omitempty"`
+}
+
+type EcosystemRules struct {
+	// Registries are the names of the registries the updates use, or "*"
+	// for all of them.
+	Registries []string `yaml:"registries"`
+	// Groups groups updates into single PRs, keyed by group name.
+	Groups map[string]Group `yaml:"groups"`
+	// Ignore excludes dependencies or versions from updates. They are
+	// added to the ignore rules devctl applies to some ecosystems anyway.
+	Ignore []Ignore `yaml:"ignore"`
+	// Allow restricts updates to the matching dependencies.
+	Allow []Allow `yaml:"allow"`
+}
+
+type Group struct {
+	DependencyType  string   `yaml:"dependency-type,omitempty"`
+	Patterns        []string `yaml:"patterns,omitempty"`
+	ExcludePatterns []string `yaml:"exclude-patterns,omitempty"`
+	UpdateTypes     []string `yaml:"update-types,omitempty"`
+}
+
+type Ignore struct {
+	DependencyName string   `yaml:"dependency-name"`
+	Versions       []string `yaml:"versions"`
+	UpdateTypes    []string `yaml:"update-types"`
+}
+
+type Allow struct {
+	DependencyName string `yaml:"dependency-name"`
+	DependencyType string `yaml:"dependency-type"`
+}
+
+// ReadRules reads the rules file at the given path.
+func ReadRules(path string) (Rules, error) {
+	b, err := os.ReadFile(path) // #nosec G304 -- path is provided by the user
+	if err != nil {
+		return Rules{}, microerror.Mask(err)
+	}
+
+	var r Rules
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	dec.KnownFields(true)
+	err = dec.Decode(&r)
+	if err != nil {
+		return Rules{}, microerror.Maskf(invalidConfigError, "%s: %s", path, err)
+	}
+
+	err = r.validate()
+	if err != nil {
+		return Rules{}, microerror.Maskf(invalidConfigError, "%s: %s", path, err)
+	}
+
+	return r, nil
+}
+
+func (r Rules) validate() error {
+	for name, registry := range r.Registries {
+		if !groupRegexp.MatchString(name) {
+			return microerror.Maskf(invalidConfigError, "registry name must match %s, got %#q", groupRegexp, name)
+		}
+		if !slices.Contains(registryTypes, registry.Type) {
+			return microerror.Maskf(invalidConfigError, "registry %#q type must be one of <%s>, got %#q", name, strings.Join(registryTypes, "|"), registry.Type)
+		}
+	}
+
+	for _, ecosystem := range sortedKeys(r.Ecosystems) {
+		rules := r.Ecosystems[ecosystem]
+
+		_, err := gen.NewEcosystem(ecosystem)
+		if err != nil {
+			return microerror.Maskf(invalidConfigError, "ecosystems key %#q must be one of <%s>", ecosystem, strings.Join(gen.AllowedEcosystems(), "|"))
+		}
+
+		for _, name := range rules.Registries {
+			if _, ok := r.Registries[name]; !ok && name != "*" {
+				return microerror.Maskf(invalidConfigError, "ecosystem %#q uses undefined registry %#q", ecosystem, name)
+			}
+		}
+		for name, g := range rules.Groups {
+			if !groupRegexp.MatchString(name) {
+				return microerror.Maskf(invalidConfigError, "ecosystem %#q group name must match %s, got %#q", ecosystem, groupRegexp, name)
+			}
+			if len(g.Patterns) == 0 && g.DependencyType == "" {
+				return microerror.Maskf(invalidConfigError, "ecosystem %#q group %#q must define patterns or dependency-type", ecosystem, name)
+			}
+			for _, t := range g.UpdateTypes {
+				if !slices.Contains(groupUpdateTypes, t) {
+					return microerror.Maskf(invalidConfigError, "ecosystem %#q group %#q update-types must be one of <%s>, got %#q", ecosystem, name, strings.Join(groupUpdateTypes, "|"), t)
+				}
+			}
+		}
+		for _, i := range rules.Ignore {
+			if i.DependencyName == "" {
+				return microerror.Maskf(invalidConfigError, "ecosystem %#q ignore rules must define dependency-name", ecosystem)
+			}
+			for _, t := range i.UpdateTypes {
+				if !slices.Contains(updateTypes, t) {
+					return microerror.Maskf(invalidConfigError, "ecosystem %#q ignore update-types must be one of <%s>, got %#q", ecosystem, strings.Join(updateTypes, "|"), t)
+				}
+			}
+		}
+		for _, a := range rules.Allow {
+			if a.DependencyName == "" && a.DependencyType == "" {
+				return microerror.Maskf(invalidConfigError, "ecosystem %#q allow rules must define dependency-name or dependency-type", ecosystem)
+			}
+		}
+	}
+
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,217 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+)
+
+const (
+	// generatedScanBytes bounds how much of a file is searched for a
+	// "DO NOT EDIT" marker. Generators write it at the top of the file.
+	generatedScanBytes = 1024
+
+	// npmDefaultTest is the test script npm init puts into package.json.
+	npmDefaultTest = `echo "Error: no test specified" && exit 1`
+)
+
+var (
+	// makeTargetRegexp matches make targets documented with a "##" comment,
+	// as listed by make help.
+	makeTargetRegexp = regexp.MustCompile(`^([a-zA-Z0-9_.-]+):[^=]*?##\s*(.*)$`)
+
+	// skippedDirs are not searched for generated files.
+	skippedDirs = []string{".git", "node_modules", "vendor", ".venv", "venv", "__pycache__"}
+)
+
+// Facts are facts about the repository which the rules point assistants
+// at.
+type Facts struct {
+	// MakeTargets are the documented targets of the Makefile and the
+	// Makefile.*.mk files it includes, sorted by name.
+	MakeTargets []MakeTarget
+	// TestCommands are the commands running the tests.
+	TestCommands []string
+	// GeneratedFiles are the slash-separated paths of the files which are
+	// marked as generated, sorted.
+	GeneratedFiles []string
+}
+
+type MakeTarget struct {
+	Name        string
+	Description string
+}
+
+// DetectFacts detects the facts of the repository in dir.
+func DetectFacts(dir string) (Facts, error) {
+	var facts Facts
+	var err error
+
+	facts.MakeTargets, err = makeTargets(dir)
+	if err != nil {
+		return Facts{}, microerror.Mask(err)
+	}
+
+	facts.TestCommands, err = testCommands(dir, facts.MakeTargets)
+	if err != nil {
+		return Facts{}, microerror.Mask(err)
+	}
+
+	facts.GeneratedFiles, err = generatedFiles(dir)
+	if err != nil {
+		return Facts{}, microerror.Mask(err)
+	}
+
+	return facts, nil
+}
+
+func makeTargets(dir string) ([]MakeTarget, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "Makefile.*.mk"))
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	paths = append([]string{filepath.Join(dir, "Makefile")}, paths...)
+
+	seen := map[string]bool{}
+	var targets []MakeTarget
+	for _, p := range paths {
+		data, err := os.ReadFile(p) // #nosec G304 -- Makefiles of the repo
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			m := makeTargetRegexp.FindStringSubmatch(scanner.Text())
+			if m == nil || seen[m[1]] {
+				continue
+			}
+			seen[m[1]] = true
+			targets = append(targets, MakeTarget{Name: m[1], Description: strings.TrimSpace(m[2])})
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	sort.Slice(targets, func(i, j int) bool { return targets[i].Name < targets[j].Name })
+
+	return targets, nil
+}
+
+func testCommands(dir string, targets []MakeTarget) ([]string, error) {
+	var commands []string
+
+	if slices.ContainsFunc(targets, func(t MakeTarget) bool { return t.Name == "test" }) {
+		commands = append(commands, "make test")
+	}
+
+	if exists(filepath.Join(dir, "go.mod")) {
+		commands = append(commands, "go test ./...")
+	}
+
+	if exists(filepath.Join(dir, "pyproject.toml")) || exists(filepath.Join(dir, "setup.py")) || exists(filepath.Join(dir, "pytest.ini")) {
+		commands = append(commands, "pytest")
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "package.json")) // #nosec G304 -- package.json of the repo
+	if err == nil {
+		var pkg struct {
+			Scripts map[string]string `json:"scripts"`
+		}
+		err = json.Unmarshal(data, &pkg)
+		if err != nil {
+			return nil, microerror.Maskf(invalidConfigError, "package.json: %s", err)
+		}
+		if test := pkg.Scripts["test"]; test != "" && test != npmDefaultTest {
+			commands = append(commands, "npm test")
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, microerror.Mask(err)
+	}
+
+	if exists(filepath.Join(dir, "tests", "e2e", "go.mod")) {
+		commands = append(commands, "cd tests/e2e && go test ./...")
+	}
+
+	return commands, nil
+}
+
+// generatedFiles returns the files whose name starts with zz_generated. or
+// which carry a "DO NOT EDIT" marker at their top.
+func generatedFiles(dir string) ([]string, error) {
+	var files []string
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && slices.Contains(skippedDirs, d.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		generated := strings.HasPrefix(d.Name(), "zz_generated.")
+		if !generated {
+			generated, err = hasGeneratedMarker(path)
+			if err != nil {
+				return err
+			}
+		}
+		if generated {
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			files = append(files, filepath.ToSlash(rel))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	sort.Strings(files)
+
+	return files, nil
+}
+
+func hasGeneratedMarker(path string) (bool, error) {
+	f, err := os.Open(path) // #nosec G304 -- files of the repo
+	if err != nil {
+		return false, microerror.Mask(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	head := make([]byte, generatedScanBytes)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return false, microerror.Mask(err)
+	}
+
+	return bytes.Contains(head[:n], []byte("DO NOT EDIT")), nil
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,20 @@
+package file
+
+import _ "embed"
+
+//go:embed app_rules.md.template
+var appRulesTemplate string
+
+//go:generate go run ../../../update-template-sha.go app_rules.md.template
+//go:embed app_rules.md.template.sha
+var appRulesTemplateSha string
+
+func appRules() rules {
+	return rules{
+		name:        "app-llm-rules",
+		description: "Helm chart development guidelines for Giant Swarm apps",
+		globs:       "helm/**",
+		template:    appRulesTemplate,
+		templateSha: appRulesTemplateSha,
+	}
+}
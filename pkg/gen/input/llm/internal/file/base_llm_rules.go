@@ -1,29 +1,20 @@
 package file
 
-import (
-	_ "embed"
+import _ "embed"
 
-	"github.com/giantswarm/devctl/v8/pkg/gen/input"
-	"github.com/giantswarm/devctl/v8/pkg/gen/input/llm/internal/params"
-)
-
-//go:embed base_llm_rules.mdc.template
+//go:embed base_llm_rules.md.template
 var baseLLMRulesTemplate string
 
-//go:generate go run ../../../update-template-sha.go base_llm_rules.mdc.template
-//go:embed base_llm_rules.mdc.template.sha
+//go:generate go run ../../../update-template-sha.go base_llm_rules.md.template
+//go:embed base_llm_rules.md.template.sha
 var baseLLMRulesTemplateSha string
 
-func NewBaseLLMRulesInput(p params.Params) input.Input {
-	i := input.Input{
-		Path:         params.RegenerableFileName(p, "base-llm-rules.mdc"),
-		TemplateBody: baseLLMRulesTemplate,
-		TemplateData: map[string]interface{}{
-			"Header":       params.Header(baseLLMRulesTemplateSha),
-			"IsLanguageGo": params.IsLanguageGo(p),
-			"Language":     p.Language,
-		},
+func baseLLMRules() rules {
+	return rules{
+		name:        "base-llm-rules",
+		description: "Instructions for AI/LLM assistants",
+		globs:       "",
+		template:    baseLLMRulesTemplate,
+		templateSha: baseLLMRulesTemplateSha,
 	}
-
-	return i
 }
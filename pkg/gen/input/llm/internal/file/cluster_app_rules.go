@@ -0,0 +1,20 @@
+package file
+
+import _ "embed"
+
+//go:embed cluster_app_rules.md.template
+var clusterAppRulesTemplate string
+
+//go:generate go run ../../../update-template-sha.go cluster_app_rules.md.template
+//go:embed cluster_app_rules.md.template.sha
+var clusterAppRulesTemplateSha string
+
+func clusterAppRules() rules {
+	return rules{
+		name:        "cluster-app-llm-rules",
+		description: "Values schema guidelines for cluster apps",
+		globs:       "helm/**/values.schema.json,helm/**/values.yaml",
+		template:    clusterAppRulesTemplate,
+		templateSha: clusterAppRulesTemplateSha,
+	}
+}
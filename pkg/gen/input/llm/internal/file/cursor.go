@@ -0,0 +1,23 @@
+package file
+
+import (
+	"github.com/giantswarm/devctl/v8/pkg/gen/input"
+	"github.com/giantswarm/devctl/v8/pkg/gen/input/llm/internal/params"
+)
+
+// NewCursorInputs returns one Cursor .mdc rules file per rule set.
+func NewCursorInputs(p params.Params) []input.Input {
+	sets := ruleSets(p)
+
+	var inputs []input.Input
+	for _, r := range sets {
+		i := input.Input{
+			Path:         params.RegenerableFileName(p, r.name+".mdc"),
+			TemplateBody: frontMatter(r) + r.template + "\n{{ .Header }}\n",
+			TemplateData: templateData(p, sets, false, params.Header(r.templateSha)),
+		}
+		inputs = append(inputs, i)
+	}
+
+	return inputs
+}
@@ -1,27 +1,20 @@
 package file
 
-import (
-	_ "embed"
+import _ "embed"
 
-	"github.com/giantswarm/devctl/v8/pkg/gen/input"
-	"github.com/giantswarm/devctl/v8/pkg/gen/input/llm/internal/params"
-)
-
-//go:embed go_rules.mdc.template
+//go:embed go_rules.md.template
 var goRulesTemplate string
 
-//go:generate go run ../../../update-template-sha.go go_rules.mdc.template
-//go:embed go_rules.mdc.template.sha
+//go:generate go run ../../../update-template-sha.go go_rules.md.template
+//go:embed go_rules.md.template.sha
 var goRulesTemplateSha string
 
-func NewGoLLMRulesInput(p params.Params) input.Input {
-	i := input.Input{
-		Path:         params.RegenerableFileName(p, "go-llm-rules.mdc"),
-		TemplateBody: goRulesTemplate,
-		TemplateData: map[string]interface{}{
-			"Header": params.Header(goRulesTemplateSha),
-		},
+func goRules() rules {
+	return rules{
+		name:        "go-llm-rules",
+		description: "Go language-specific development guidelines and patterns",
+		globs:       "**/*.go,go.mod,go.sum",
+		template:    goRulesTemplate,
+		templateSha: goRulesTemplateSha,
 	}
-
-	return i
 }
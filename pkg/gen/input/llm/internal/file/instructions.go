@@ -0,0 +1,43 @@
+package file
+
+import (
+	"strings"
+
+	"github.com/giantswarm/devctl/v8/pkg/gen/input"
+	"github.com/giantswarm/devctl/v8/pkg/gen/input/llm/internal/params"
+)
+
+// NewAgentsInput returns the AGENTS.md file holding all rule sets.
+func NewAgentsInput(p params.Params) input.Input {
+	return newInstructionsInput(p, "AGENTS.md")
+}
+
+// NewCopilotInput returns the GitHub Copilot repository instructions holding
+// all rule sets.
+func NewCopilotInput(p params.Params) input.Input {
+	return newInstructionsInput(p, ".github/copilot-instructions.md")
+}
+
+// newInstructionsInput renders all rule sets into the single markdown file
+// at path. The header lists the templates of all rule sets.
+func newInstructionsInput(p params.Params, path string) input.Input {
+	sets := ruleSets(p)
+
+	var bodies, urls []string
+	for _, r := range sets {
+		bodies = append(bodies, r.template)
+		urls = append(urls, r.templateSha)
+	}
+
+	i := input.Input{
+		Path:         path,
+		TemplateBody: strings.Join(bodies, "\n") + "\n{{ .Header }}\n",
+		TemplateData: templateData(p, sets, true, params.Header(strings.Join(urls, "\n"))),
+		// The conventional names do not mark the files as regenerable,
+		// and the repo may have written them by hand. Only the ones gen
+		// llm wrote are regenerated.
+		OwnerMarker: params.HeaderMarker,
+	}
+
+	return i
+}
@@ -0,0 +1,20 @@
+package file
+
+import _ "embed"
+
+//go:embed k8sapi_rules.md.template
+var k8sAPIRulesTemplate string
+
+//go:generate go run ../../../update-template-sha.go k8sapi_rules.md.template
+//go:embed k8sapi_rules.md.template.sha
+var k8sAPIRulesTemplateSha string
+
+func k8sAPIRules() rules {
+	return rules{
+		name:        "k8sapi-llm-rules",
+		description: "Kubernetes API type guidelines",
+		globs:       "api/**,pkg/apis/**,config/crd/**",
+		template:    k8sAPIRulesTemplate,
+		templateSha: k8sAPIRulesTemplateSha,
+	}
+}
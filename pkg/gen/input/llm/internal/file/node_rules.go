@@ -0,0 +1,20 @@
+package file
+
+import _ "embed"
+
+//go:embed node_rules.md.template
+var nodeRulesTemplate string
+
+//go:generate go run ../../../update-template-sha.go node_rules.md.template
+//go:embed node_rules.md.template.sha
+var nodeRulesTemplateSha string
+
+func nodeRules() rules {
+	return rules{
+		name:        "node-llm-rules",
+		description: "Node.js language-specific development guidelines and patterns",
+		globs:       "**/*.js,**/*.mjs,**/*.cjs,**/*.ts,**/*.tsx,package.json",
+		template:    nodeRulesTemplate,
+		templateSha: nodeRulesTemplateSha,
+	}
+}
@@ -0,0 +1,20 @@
+package file
+
+import _ "embed"
+
+//go:embed python_rules.md.template
+var pythonRulesTemplate string
+
+//go:generate go run ../../../update-template-sha.go python_rules.md.template
+//go:embed python_rules.md.template.sha
+var pythonRulesTemplateSha string
+
+func pythonRules() rules {
+	return rules{
+		name:        "python-llm-rules",
+		description: "Python language-specific development guidelines and patterns",
+		globs:       "**/*.py,pyproject.toml,requirements*.txt",
+		template:    pythonRulesTemplate,
+		templateSha: pythonRulesTemplateSha,
+	}
+}
@@ -0,0 +1,20 @@
+package file
+
+import _ "embed"
+
+//go:embed repo_facts.md.template
+var repoFactsTemplate string
+
+//go:generate go run ../../../update-template-sha.go repo_facts.md.template
+//go:embed repo_facts.md.template.sha
+var repoFactsTemplateSha string
+
+func repoFacts() rules {
+	return rules{
+		name:        "repo-facts",
+		description: "Facts detected in this repository",
+		globs:       "",
+		template:    repoFactsTemplate,
+		templateSha: repoFactsTemplateSha,
+	}
+}
@@ -0,0 +1,84 @@
+package file
+
+import (
+	"strings"
+
+	"github.com/giantswarm/devctl/v8/pkg/gen"
+	"github.com/giantswarm/devctl/v8/pkg/gen/input/llm/internal/params"
+)
+
+// rules is a rule set for LLM assistants. Its template holds the
+// instructions only, each format adds its own front matter and header.
+type rules struct {
+	// name is the file name suffix of the rule set in the Cursor format.
+	name        string
+	description string
+	// globs are the files the rule set applies to in the Cursor format. Rule
+	// sets without globs always apply.
+	globs       string
+	template    string
+	templateSha string
+}
+
+// ruleSets returns the rule sets for the language and flavours of the repo,
+// starting with the base rule set.
+func ruleSets(p params.Params) []rules {
+	sets := []rules{baseLLMRules()}
+
+	switch p.Language {
+	case gen.LanguageGo.String():
+		sets = append(sets, goRules())
+	case gen.LanguagePython.String():
+		sets = append(sets, pythonRules())
+	case gen.LanguageNode.String():
+		sets = append(sets, nodeRules())
+	}
+
+	if p.Flavours.Contains(gen.FlavourApp) {
+		sets = append(sets, appRules())
+	}
+	if p.Flavours.Contains(gen.FlavourClusterApp) {
+		sets = append(sets, clusterAppRules())
+	}
+	if p.Flavours.Contains(gen.FlavourKubernetesAPI) {
+		sets = append(sets, k8sAPIRules())
+	}
+
+	if params.HasFacts(p) {
+		sets = append(sets, repoFacts())
+	}
+
+	return sets
+}
+
+// frontMatter returns the Cursor rule front matter of the given rule set.
+func frontMatter(r rules) string {
+	var b strings.Builder
+	b.WriteString("---\n")
+	b.WriteString("description: " + r.description + "\n")
+	if r.globs != "" {
+		b.WriteString("globs: " + r.globs + "\n")
+	} else {
+		b.WriteString("alwaysApply: true\n")
+	}
+	b.WriteString("---\n\n")
+	return b.String()
+}
+
+// templateData returns the data of the rule set templates. combined is true
+// for formats rendering all rule sets into a single file.
+func templateData(p params.Params, sets []rules, combined bool, header string) map[string]interface{} {
+	var descriptions []string
+	for _, r := range sets[1:] {
+		descriptions = append(descriptions, r.description)
+	}
+
+	return map[string]interface{}{
+		"Combined":     combined,
+		"Facts":        p,
+		"Header":       header,
+		"IsLanguageGo": params.IsLanguageGo(p),
+		"Language":     p.Language,
+		"RuleSets":     descriptions,
+	}
+}
@@ -14,12 +14,20 @@ func IsLanguageGo(p Params) bool {
 	return p.Language == "go"
 }
 
+func HasFacts(p Params) bool {
+	return len(p.MakeTargets) > 0 || len(p.TestCommands) > 0 || len(p.GeneratedFiles) > 0
+}
+
+// HeaderMarker is the line of Header that marks a file as generated by
+// gen llm.
+const HeaderMarker = "DO NOT EDIT. Generated with devctl."
+
 func Header(githubUrl string) string {
 	return fmt.Sprintf(`
 <!--
-DO NOT EDIT. Generated with devctl.
+%s
 This file is maintained at:
 %s
 Manual changes will be overwritten.
--->`, githubUrl)
+-->`, HeaderMarker, githubUrl)
 }
@@ -12,4 +12,19 @@ type Params struct {
 
 	// Language is the language of the repo that the rules are for.
 	Language string
+
+	// MakeTargets are the documented make targets of the repo.
+	MakeTargets []MakeTarget
+
+	// TestCommands are the commands running the tests of the repo.
+	TestCommands []string
+
+	// GeneratedFiles are the files of the repo which must not be edited by
+	// hand.
+	GeneratedFiles []string
+}
+
+type MakeTarget struct {
+	Name        string
+	Description string
 }
@@ -1,38 +1,105 @@
 package llm
 
 import (
+	"slices"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+
 	"github.com/giantswarm/devctl/v8/pkg/gen"
 	"github.com/giantswarm/devctl/v8/pkg/gen/input"
 	"github.com/giantswarm/devctl/v8/pkg/gen/input/llm/internal/file"
 	"github.com/giantswarm/devctl/v8/pkg/gen/input/llm/internal/params"
 )
 
+const (
+	// FormatCursor is one .mdc rules file per rule set in .cursor/rules.
+	FormatCursor = "cursor"
+	// FormatAgents is a single AGENTS.md in the repository root.
+	FormatAgents = "agents"
+	// FormatCopilot is a single .github/copilot-instructions.md.
+	FormatCopilot = "copilot"
+)
+
+// AllFormats returns the supported output formats.
+func AllFormats() []string {
+	return []string{FormatCursor, FormatAgents, FormatCopilot}
+}
+
 type Config struct {
 	Flavours gen.FlavourSlice
 	Language string
+
+	// Formats are the output formats to generate, see AllFormats. Defaults
+	// to FormatCursor.
+	Formats []string
+	// Facts are the repository facts to include in the rules, see
+	// DetectFacts.
+	Facts Facts
 }
 
 type LLM struct {
-	params params.Params
+	formats []string
+	params  params.Params
 }
 
 func New(config Config) (*LLM, error) {
+	if len(config.Formats) == 0 {
+		config.Formats = []string{FormatCursor}
+	}
+	for _, f := range config.Formats {
+		if !slices.Contains(AllFormats(), f) {
+			return nil, microerror.Maskf(invalidConfigError, "%T.Formats must contain only <%s>, got %#q", config, strings.Join(AllFormats(), "|"), f)
+		}
+	}
+
 	l := &LLM{
+		formats: config.Formats,
 		params: params.Params{
 			Dir: ".cursor/rules",
 
 			Flavours: config.Flavours,
 			Language: config.Language,
+
+			TestCommands: config.Facts.TestCommands,
 		},
 	}
 
+	for _, t := range config.Facts.MakeTargets {
+		l.params.MakeTargets = append(l.params.MakeTargets, params.MakeTarget(t))
+	}
+
+	// The files generated here in any format are detected as generated
+	// files on the next run. Leave them out so that the output does not
+	// change.
+	l.params.GeneratedFiles = config.Facts.GeneratedFiles
+	outputs := map[string]bool{}
+	for _, i := range (&LLM{formats: AllFormats(), params: l.params}).Files() {
+		outputs[i.Path] = true
+	}
+	l.params.GeneratedFiles = nil
+	for _, p := range config.Facts.GeneratedFiles {
+		if !outputs[p] {
+			l.params.GeneratedFiles = append(l.params.GeneratedFiles, p)
+		}
+	}
+
 	return l, nil
 }
 
-func (l *LLM) BaseLLMRules() input.Input {
-	return file.NewBaseLLMRulesInput(l.params)
-}
+// Files returns the inputs of the configured formats.
+func (l *LLM) Files() []input.Input {
+	var files []input.Input
+	for _, f := range l.formats {
+		switch f {
+		case FormatCursor:
+			files = append(files, file.NewCursorInputs(l.params)...)
+		case FormatAgents:
+			files = append(files, file.NewAgentsInput(l.params))
+		case FormatCopilot:
+			files = append(files, file.NewCopilotInput(l.params))
+		}
+	}
 
-func (l *LLM) GoLLMRules() input.Input {
-	return file.NewGoLLMRulesInput(l.params)
+	return files
 }
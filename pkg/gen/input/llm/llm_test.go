@@ -0,0 +1,234 @@
+package llm
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/giantswarm/devctl/v8/pkg/gen"
+)
+
+func Test_DetectFacts(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"Makefile":                                "include Makefile.*.mk\n\ntest: ## Runs the tests.\n\tgo test ./...\nFOO := bar ## not a target\n",
+		"Makefile.gen.app.mk":                     "lint-chart: check-env ## Runs ct against the default chart.\ntest: ## Shadowed by the Makefile.\n",
+		"go.mod":                                  "module example.com/x\n",
+		"package.json":                            `{"scripts": {"test": "echo \"Error: no test specified\" && exit 1"}}`,
+		"api/v1/zz_generated.deepcopy.go":         "package v1\n",
+		"helm/x/README.md":                        "<!-- DO NOT EDIT. Generated with helm-docs. -->\n",
+		"helm/x/values.yaml":                      "replicas: 1\n",
+		"vendor/example.com/y/zz_generated.go":    "package y\n",
+		"node_modules/y/index.js":                 "// DO NOT EDIT\n",
+		".cursor/rules/zz_generated.go-rules.mdc": "---\n",
+	}
+	for p, content := range files {
+		p = filepath.Join(dir, p)
+		err := os.MkdirAll(filepath.Dir(p), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(p, []byte(content), 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	facts, err := DetectFacts(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Facts{
+		MakeTargets: []MakeTarget{
+			{Name: "lint-chart", Description: "Runs ct against the default chart."},
+			{Name: "test", Description: "Runs the tests."},
+		},
+		TestCommands: []string{"make test", "go test ./..."},
+		GeneratedFiles: []string{
+			".cursor/rules/zz_generated.go-rules.mdc",
+			"api/v1/zz_generated.deepcopy.go",
+			"helm/x/README.md",
+		},
+	}
+	if !reflect.DeepEqual(facts, want) {
+		t.Errorf("DetectFacts() = %+v, want %+v", facts, want)
+	}
+}
+
+func Test_Files(t *testing.T) {
+	facts := Facts{
+		TestCommands: []string{"pytest"},
+		GeneratedFiles: []string{
+			".cursor/rules/zz_generated.base-llm-rules.mdc",
+			"AGENTS.md",
+			"helm/x/README.md",
+		},
+	}
+
+	l, err := New(Config{
+		Flavours: gen.FlavourSlice{gen.FlavourApp, gen.FlavourClusterApp},
+		Language: "python",
+		Formats:  AllFormats(),
+		Facts:    facts,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rendered := map[string]string{}
+	var paths []string
+	for _, i := range l.Files() {
+		out, err := gen.Render(context.Background(), i)
+		if err != nil {
+			t.Fatalf("render %s: %v", i.Path, err)
+		}
+		rendered[i.Path] = string(out)
+		paths = append(paths, i.Path)
+	}
+
+	wantPaths := []string{
+		".cursor/rules/zz_generated.base-llm-rules.mdc",
+		".cursor/rules/zz_generated.python-llm-rules.mdc",
+		".cursor/rules/zz_generated.app-llm-rules.mdc",
+		".cursor/rules/zz_generated.cluster-app-llm-rules.mdc",
+		".cursor/rules/zz_generated.repo-facts.mdc",
+		"AGENTS.md",
+		".github/copilot-instructions.md",
+	}
+	if !reflect.DeepEqual(paths, wantPaths) {
+		t.Fatalf("paths = %q, want %q", paths, wantPaths)
+	}
+
+	if got := rendered[".cursor/rules/zz_generated.python-llm-rules.mdc"]; !strings.HasPrefix(got, "---\ndescription: Python language-specific development guidelines and patterns\nglobs: ") {
+		t.Errorf("expected Cursor front matter with globs:\n%s", got)
+	}
+	if got := rendered[".cursor/rules/zz_generated.base-llm-rules.mdc"]; !strings.Contains(got, "alwaysApply: true") || !strings.Contains(got, "the other rules files in this repository") {
+		t.Errorf("expected an always applied base rule set referring to the other files:\n%s", got)
+	}
+
+	for _, p := range []string{"AGENTS.md", ".github/copilot-instructions.md"} {
+		got := rendered[p]
+		if strings.HasPrefix(got, "---") {
+			t.Errorf("%s: expected no Cursor front matter:\n%s", p, got)
+		}
+		for _, want := range []string{
+			"the following sections of this file",
+			"# Python Code Guidelines",
+			"# Helm Chart Guidelines",
+			"# Cluster App Guidelines",
+			"- `pytest`",
+			"- `helm/x/README.md`",
+			"repo_facts.md.template",
+		} {
+			if !strings.Contains(got, want) {
+				t.Errorf("%s: expected %q:\n%s", p, want, got)
+			}
+		}
+		// The files generated by gen llm itself are left out.
+		if strings.Contains(got, "- `AGENTS.md`") || strings.Contains(got, "base-llm-rules.mdc`") {
+			t.Errorf("%s: expected the generated rules not to be listed:\n%s", p, got)
+		}
+	}
+}
+
+// Test_FilesRegenerate verifies that the AGENTS.md and Copilot instructions
+// gen llm wrote before are regenerated.
+func Test_FilesRegenerate(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	generated := "stale\n<!--\nDO NOT EDIT. Generated with devctl.\n-->\n"
+	for _, p := range []string{"AGENTS.md", ".github/copilot-instructions.md"} {
+		err := os.MkdirAll(filepath.Dir(p), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(p, []byte(generated), 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	l, err := New(Config{
+		Formats: []string{FormatAgents, FormatCopilot},
+		Facts:   Facts{TestCommands: []string{"make test"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = gen.Execute(context.Background(), l.Files()...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, p := range []string{"AGENTS.md", ".github/copilot-instructions.md"} {
+		b, err := os.ReadFile(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(b), "- `make test`") {
+			t.Errorf("%s was not regenerated:\n%s", p, b)
+		}
+	}
+}
+
+// Test_FilesKeepHandWritten verifies that an AGENTS.md gen llm did not write
+// is left alone, and that generating it fails naming the file.
+func Test_FilesKeepHandWritten(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	err := os.WriteFile("AGENTS.md", []byte("hand-written\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := New(Config{Formats: []string{FormatAgents}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = gen.Execute(context.Background(), l.Files()...)
+	if !gen.IsUnownedFile(err) {
+		t.Fatalf("Execute() error = %v, want unowned file error", err)
+	}
+	if !strings.Contains(err.Error(), "AGENTS.md") {
+		t.Errorf("Execute() error = %v, want it to name AGENTS.md", err)
+	}
+
+	b, err := os.ReadFile("AGENTS.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "hand-written\n" {
+		t.Errorf("AGENTS.md was overwritten:\n%s", b)
+	}
+}
+
+func Test_FilesWithoutFacts(t *testing.T) {
+	l, err := New(Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := l.Files()
+	if len(files) != 1 || files[0].Path != ".cursor/rules/zz_generated.base-llm-rules.mdc" {
+		t.Fatalf("expected the base rule set only, got %d files", len(files))
+	}
+
+	out, err := gen.Render(context.Background(), files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), "Additional rules can be found in the general style guide.\n") {
+		t.Errorf("expected no list of rule sets:\n%s", out)
+	}
+}
+
+func Test_NewInvalidFormat(t *testing.T) {
+	_, err := New(Config{Formats: []string{"windsurf"}})
+	if !IsInvalidConfig(err) {
+		t.Errorf("New() error = %v, want invalid config error", err)
+	}
+}
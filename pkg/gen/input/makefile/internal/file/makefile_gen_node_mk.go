@@ -0,0 +1,28 @@
+package file
+
+import (
+	_ "embed"
+
+	"github.com/giantswarm/devctl/v8/pkg/gen/input"
+	"github.com/giantswarm/devctl/v8/pkg/gen/input/makefile/internal/params"
+)
+
+//go:embed Makefile.gen.node.mk.template
+var makefileGenNodeMkTemplate string
+
+//go:generate go run ../../../update-template-sha.go Makefile.gen.node.mk.template
+//go:embed Makefile.gen.node.mk.template.sha
+var makefileGenNodeMkTemplateSha string
+
+func NewMakefileGenNodeMkInput(p params.Params) input.Input {
+	i := input.Input{
+		Path:         "Makefile.gen.node.mk",
+		TemplateBody: makefileGenNodeMkTemplate,
+		TemplateData: map[string]interface{}{
+			"Header":         params.Header("#", makefileGenNodeMkTemplateSha),
+			"PackageManager": p.NodePackageManager,
+		},
+	}
+
+	return i
+}
@@ -0,0 +1,28 @@
+package file
+
+import (
+	_ "embed"
+
+	"github.com/giantswarm/devctl/v8/pkg/gen/input"
+	"github.com/giantswarm/devctl/v8/pkg/gen/input/makefile/internal/params"
+)
+
+//go:embed Makefile.gen.python.mk.template
+var makefileGenPythonMkTemplate string
+
+//go:generate go run ../../../update-template-sha.go Makefile.gen.python.mk.template
+//go:embed Makefile.gen.python.mk.template.sha
+var makefileGenPythonMkTemplateSha string
+
+func NewMakefileGenPythonMkInput(p params.Params) input.Input {
+	i := input.Input{
+		Path:         "Makefile.gen.python.mk",
+		TemplateBody: makefileGenPythonMkTemplate,
+		TemplateData: map[string]interface{}{
+			"Header":         params.Header("#", makefileGenPythonMkTemplateSha),
+			"PackageManager": p.PythonPackageManager,
+		},
+	}
+
+	return i
+}
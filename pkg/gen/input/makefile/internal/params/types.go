@@ -4,4 +4,9 @@ import "github.com/giantswarm/devctl/v8/pkg/gen"
 
 type Params struct {
 	Flavours gen.FlavourSlice
+	// NodePackageManager and PythonPackageManager are the package managers
+	// of the language includes, see gen.DetectNodePackageManager and
+	// gen.DetectPythonPackageManager.
+	NodePackageManager   string
+	PythonPackageManager string
 }
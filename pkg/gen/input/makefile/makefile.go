@@ -9,6 +9,13 @@ import (
 
 type Config struct {
 	Flavours gen.FlavourSlice
+	// NodePackageManager is the package manager of Makefile.gen.node.mk.
+	// Empty detects it from the lockfile in the working directory.
+	NodePackageManager string
+	// PythonPackageManager is the package manager of
+	// Makefile.gen.python.mk. Empty detects it from the lockfile in the
+	// working directory.
+	PythonPackageManager string
 }
 
 type Makefile struct {
@@ -16,9 +23,18 @@ type Makefile struct {
 }
 
 func New(config Config) (*Makefile, error) {
+	if config.NodePackageManager == "" {
+		config.NodePackageManager = gen.DetectNodePackageManager(".")
+	}
+	if config.PythonPackageManager == "" {
+		config.PythonPackageManager = gen.DetectPythonPackageManager(".")
+	}
+
 	m := &Makefile{
 		params: params.Params{
-			Flavours: config.Flavours,
+			Flavours:             config.Flavours,
+			NodePackageManager:   config.NodePackageManager,
+			PythonPackageManager: config.PythonPackageManager,
 		},
 	}
 
@@ -47,6 +63,14 @@ func (m *Makefile) MakefileGenGo() []input.Input {
 	return file.NewMakefileGenGoMkInput(m.params)
 }
 
+func (m *Makefile) MakefileGenNode() input.Input {
+	return file.NewMakefileGenNodeMkInput(m.params)
+}
+
+func (m *Makefile) MakefileGenPython() input.Input {
+	return file.NewMakefileGenPythonMkInput(m.params)
+}
+
 func (m *Makefile) MakefileGenKubernetesAPI() []input.Input {
 	return []input.Input{
 		file.NewMakefileGenKubernetesAPIMkInput(m.params),
@@ -0,0 +1,91 @@
+package makefile
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/giantswarm/devctl/v8/pkg/gen"
+	"github.com/giantswarm/devctl/v8/pkg/gen/input"
+)
+
+// templateURLRegexp matches the template URL of the header, which carries
+// the revision of the last commit touching the template.
+var templateURLRegexp = regexp.MustCompile(`https://github\.com/giantswarm/devctl/blob/\S+`)
+
+// Test_Golden renders the language includes and compares them to the golden
+// files in testdata, with the template URL of the header masked.
+func Test_Golden(t *testing.T) {
+	m, err := New(Config{Flavours: gen.FlavourSlice{gen.FlavourGeneric}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name   string
+		input  input.Input
+		golden string
+	}{
+		{
+			name:   "case 0: python",
+			input:  m.MakefileGenPython(),
+			golden: "testdata/Makefile.gen.python.mk",
+		},
+		{
+			name:   "case 1: node",
+			input:  m.MakefileGenNode(),
+			golden: "testdata/Makefile.gen.node.mk",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rendered, err := gen.Render(context.Background(), tc.input)
+			if err != nil {
+				t.Fatalf("render %s: %v", tc.input.Path, err)
+			}
+			got := templateURLRegexp.ReplaceAllString(string(rendered), "<template>")
+
+			want, err := os.ReadFile(tc.golden) // #nosec G304 -- fixed in-package testdata path
+			if err != nil {
+				t.Fatalf("read golden: %v", err)
+			}
+
+			if got != string(want) {
+				t.Errorf("generated %s does not match golden %s\n--- got ---\n%s\n--- want ---\n%s", tc.input.Path, tc.golden, got, string(want))
+			}
+		})
+	}
+}
+
+// Test_PackageManager checks that the includes render the package managers
+// of the config instead of detecting them.
+func Test_PackageManager(t *testing.T) {
+	m, err := New(Config{
+		NodePackageManager:   gen.PackageManagerNPM,
+		PythonPackageManager: gen.PackageManagerPoetry,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		input input.Input
+		want  string
+	}{
+		{input: m.MakefileGenNode(), want: "\nNODE_PACKAGE_MANAGER ?= npm\n"},
+		{input: m.MakefileGenPython(), want: "\nPYTHON_PACKAGE_MANAGER ?= poetry\n"},
+	}
+
+	for _, tc := range testCases {
+		rendered, err := gen.Render(context.Background(), tc.input)
+		if err != nil {
+			t.Fatalf("render %s: %v", tc.input.Path, err)
+		}
+		if !strings.Contains(string(rendered), tc.want) {
+			t.Errorf("%s: expected %q:\n%s", tc.input.Path, tc.want, rendered)
+		}
+	}
+}
@@ -43,11 +43,15 @@ func NewCreatePreCommitConfigInput(p params.Params) input.Input {
 			"HasBash":         params.HasFlavor(p, "bash"),
 			"HasMd":           params.HasFlavor(p, "md"),
 			"HasHelmchart":    params.HasFlavor(p, "helmchart"),
+			"HasDockerfile":   params.HasFlavor(p, "dockerfile"),
+			"HasActions":      params.HasFlavor(p, "actions"),
+			"HasKyverno":      params.HasFlavor(p, "kyverno"),
 			"RepoName":        p.RepoName,
 			"HelmCharts":      p.HelmCharts,
 			"RefFixPython":    refFixPython,
 			"NodeRunPrefix":   p.NodeRunPrefix,
 			"NodeDevLintHook": p.NodeDevLintHook,
+			"Revs":            p.Revs,
 		},
 	}
 
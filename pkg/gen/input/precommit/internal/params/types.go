@@ -26,4 +26,6 @@ type Params struct {
 	// eslint/prettier toolchain. No per-script knob: the repo converges its
 	// scripts to the convention, the generator does not bend to the repo.
 	NodeDevLintHook bool
+	// Revs are the revisions of the hook repositories, keyed by owner/repo.
+	Revs map[string]string
 }
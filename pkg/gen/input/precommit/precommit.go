@@ -1,13 +1,18 @@
 package precommit
 
 import (
-	"os"
+	"bytes"
+	"context"
+	"slices"
+	"strings"
 
 	"github.com/giantswarm/microerror"
 
+	"github.com/giantswarm/devctl/v8/pkg/gen"
 	"github.com/giantswarm/devctl/v8/pkg/gen/input"
 	"github.com/giantswarm/devctl/v8/pkg/gen/input/precommit/internal/file"
 	"github.com/giantswarm/devctl/v8/pkg/gen/input/precommit/internal/params"
+	"github.com/giantswarm/devctl/v8/pkg/gen/internal"
 )
 
 type Config struct {
@@ -15,6 +20,9 @@ type Config struct {
 	Flavors          []string
 	RepoName         string
 	K8sSchemaVersion string
+	// Revs overrides the revisions of the hook repositories, keyed by
+	// owner/repo, see DefaultRevs and ResolveRevs.
+	Revs map[string]string
 }
 
 type PreCommit struct {
@@ -24,6 +32,11 @@ type PreCommit struct {
 func New(config Config) (*PreCommit, error) {
 	workingDir := "."
 
+	revs := DefaultRevs()
+	for repo, rev := range config.Revs {
+		revs[repo] = rev
+	}
+
 	p := params.Params{
 		Dir:              "",
 		Language:         config.Language,
@@ -31,6 +44,7 @@ func New(config Config) (*PreCommit, error) {
 		RepoName:         config.RepoName,
 		WorkingDir:       workingDir,
 		K8sSchemaVersion: config.K8sSchemaVersion,
+		Revs:             revs,
 	}
 
 	if params.HasFlavor(p, "helmchart") {
@@ -43,7 +57,7 @@ func New(config Config) (*PreCommit, error) {
 
 	// Dev-only Node lint hook: a single `ci:lint` pre-push hook for every Node
 	// repo (the convention, no per-script knob). The run prefix is detected from
-	// the lockfile, mirroring the circleci generator's package-manager probe.
+	// the lockfile, like the circleci generator does.
 	if config.Language == "node" {
 		p.NodeDevLintHook = true
 		p.NodeRunPrefix = nodeRunPrefix(workingDir)
@@ -52,28 +66,52 @@ func New(config Config) (*PreCommit, error) {
 	return &PreCommit{params: p}, nil
 }
 
-// nodeRunPrefix returns the package-manager script-run prefix for the lockfile
-// present in dir. Mirrors the circleci generator's detectPackageManager probe;
-// kept local to avoid a dependency on the circleci input package. Defaults to
-// "yarn run" (Berry is the unset default there too).
+// nodeRunPrefix returns the package-manager script-run prefix for the
+// repository in dir, see gen.DetectNodePackageManager.
 func nodeRunPrefix(dir string) string {
-	if _, err := os.Stat(dir + "/package-lock.json"); err == nil {
+	switch gen.DetectNodePackageManager(dir) {
+	case gen.PackageManagerNPM:
 		return "npm run"
-	}
-	if _, err := os.Stat(dir + "/pnpm-lock.yaml"); err == nil {
+	case gen.PackageManagerPNPM:
 		return "pnpm run"
-	}
-	if _, err := os.Stat(dir + "/yarn.lock"); err == nil {
+	default:
 		return "yarn run"
 	}
-	// No lockfile (e.g. dry-run/tests): fall back to npm, the most portable.
-	return "npm run"
 }
 
 func (p *PreCommit) CreatePreCommitConfig() input.Input {
 	return file.NewCreatePreCommitConfigInput(p.params)
 }
 
+// HookRepos returns the hook repositories the generated config pins, keyed
+// by owner/repo as in DefaultRevs. Repositories of hooks not emitted for the
+// configured language and flavours are left out.
+func (p *PreCommit) HookRepos(ctx context.Context) ([]string, error) {
+	// Render the config with a marker per repository as its revision and
+	// look up the markers that made it in.
+	params := p.params
+	params.Revs = map[string]string{}
+	for repo := range DefaultRevs() {
+		params.Revs[repo] = "devctl-rev:" + repo + ":"
+	}
+
+	var b bytes.Buffer
+	err := internal.Execute(ctx, &b, file.NewCreatePreCommitConfigInput(params))
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var repos []string
+	for repo, marker := range params.Revs {
+		if strings.Contains(b.String(), marker) {
+			repos = append(repos, repo)
+		}
+	}
+	slices.Sort(repos)
+
+	return repos, nil
+}
+
 func (p *PreCommit) CreatePreCommitAction() input.Input {
 	return file.NewCreatePreCommitActionInput(p.params)
 }
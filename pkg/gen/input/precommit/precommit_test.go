@@ -1,12 +1,13 @@
 package precommit
 
 import (
-	"bytes"
+	"context"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
-	"text/template"
+
+	"github.com/giantswarm/devctl/v8/pkg/gen"
 )
 
 func renderConfig(t *testing.T, c Config) string {
@@ -16,17 +17,11 @@ func renderConfig(t *testing.T, c Config) string {
 	if err != nil {
 		t.Fatalf("New() returned unexpected error: %v", err)
 	}
-	in := p.CreatePreCommitConfig()
-
-	tpl, err := template.New("config").Parse(in.TemplateBody)
+	out, err := gen.Render(context.Background(), p.CreatePreCommitConfig())
 	if err != nil {
-		t.Fatalf("parse template: %v", err)
-	}
-	var out bytes.Buffer
-	if err := tpl.Execute(&out, in.TemplateData); err != nil {
-		t.Fatalf("execute template: %v", err)
+		t.Fatalf("render .pre-commit-config.yaml: %v", err)
 	}
-	return out.String()
+	return string(out)
 }
 
 // Test_NodeDevLintHook verifies the dev-only ci:lint hook is emitted at the
@@ -53,7 +48,7 @@ func Test_NodeDevLintHook(t *testing.T) {
 		for _, want := range []string{
 			"- repo: local",
 			"id: ci-lint",
-			"entry: npm run ci:lint", // no lockfile in test dir -> npm fallback
+			"entry: yarn run ci:lint", // no lockfile in test dir -> Yarn Berry fallback
 			"stages: [pre-push]",
 		} {
 			if !strings.Contains(got, want) {
@@ -225,3 +220,105 @@ func Test_CreatePreCommitConfig_Path(t *testing.T) {
 		t.Errorf("path: expected %q, got %q", ".pre-commit-config.yaml", got.Path)
 	}
 }
+
+// Test_HookSets verifies each language and flavor emits its pinned hook set and
+// that no other hook set leaks into the config.
+func Test_HookSets(t *testing.T) {
+	hookSets := map[string][]string{
+		"node": {
+			"id: check-json",
+			`exclude: "(^|/)(tsconfig[^/]*\\.json|\\.vscode/.*|\\.devcontainer/.*)$"`,
+		},
+		"terraform": {
+			"- repo: https://github.com/antonbabenko/pre-commit-terraform\n    rev: v1.99.4",
+			"id: terraform_fmt",
+			"id: terraform_validate",
+			"id: terraform_tflint",
+		},
+		"dockerfile": {
+			"- repo: https://github.com/hadolint/hadolint\n    rev: v2.12.0",
+			"id: hadolint-docker",
+		},
+		"actions": {
+			"- repo: https://github.com/rhysd/actionlint\n    rev: v1.7.7",
+			"id: actionlint",
+			"- repo: https://github.com/zizmorcore/zizmor-pre-commit\n    rev: v1.11.0",
+			"id: zizmor",
+		},
+		"kyverno": {
+			"id: chainsaw-lint",
+			"additional_dependencies: ['github.com/kyverno/chainsaw@v0.2.12']",
+			`files: (^|/)chainsaw-test\.ya?ml$`,
+		},
+	}
+
+	testCases := []struct {
+		name   string
+		config Config
+		want   []string
+	}{
+		{
+			name:   "node",
+			config: Config{Language: "node"},
+			want:   []string{"node"},
+		},
+		{
+			name:   "terraform",
+			config: Config{Language: "terraform"},
+			want:   []string{"terraform"},
+		},
+		{
+			name:   "generic with dockerfile, actions and kyverno flavors",
+			config: Config{Language: "generic", Flavors: []string{"dockerfile", "actions", "kyverno"}},
+			want:   []string{"dockerfile", "actions", "kyverno"},
+		},
+		{
+			name:   "go without flavors",
+			config: Config{Language: "go", RepoName: "my-repo"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := renderConfig(t, tc.config)
+
+			if n := strings.Count(got, "- repo: https://github.com/pre-commit/pre-commit-hooks"); n != 1 {
+				t.Errorf("expected pre-commit-hooks once in rendered config, got %d times:\n%s", n, got)
+			}
+			for set, hooks := range hookSets {
+				wanted := false
+				for _, w := range tc.want {
+					wanted = wanted || w == set
+				}
+				for _, hook := range hooks {
+					if wanted && !strings.Contains(got, hook) {
+						t.Errorf("expected %s hook %q in rendered config, got:\n%s", set, hook, got)
+					}
+					if !wanted && strings.Contains(got, hook) {
+						t.Errorf("expected no %s hook %q in rendered config, got:\n%s", set, hook, got)
+					}
+				}
+			}
+		})
+	}
+}
+
+// Test_TerraformAction verifies the pre-commit workflow installs the binaries the
+// terraform hooks call, and only for terraform repos.
+func Test_TerraformAction(t *testing.T) {
+	for _, language := range []string{"terraform", "go"} {
+		p, err := New(Config{Language: language, RepoName: "my-repo"})
+		if err != nil {
+			t.Fatalf("New() returned unexpected error: %v", err)
+		}
+		out, err := gen.Render(context.Background(), p.CreatePreCommitAction())
+		if err != nil {
+			t.Fatalf("render pre-commit workflow: %v", err)
+		}
+
+		installs := strings.Contains(string(out), "name: Install terraform and tflint") && strings.Contains(string(out), `TFLINT_VERSION: "0.58.1"`)
+		if installs != (language == "terraform") {
+			t.Errorf("%s: terraform install step present = %v, got:\n%s", language, installs, out)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package precommit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/giantswarm/microerror"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultRevs are the revisions the generated config pins, keyed by the
+// owner/repo of the hook repository on GitHub.
+var defaultRevs = map[string]string{
+	"antonbabenko/pre-commit-terraform":  "v1.99.4",
+	"astral-sh/ruff-pre-commit":          "v0.15.6",
+	"compilerla/conventional-pre-commit": "v4.4.0",
+	"detailyang/pre-commit-shell":        "v1.0.6",
+	"dnephin/pre-commit-golang":          "v0.5.1",
+	"giantswarm/schemalint":              "v2.6.3",
+	"hadolint/hadolint":                  "v2.12.0",
+	"igorshubovych/markdownlint-cli":     "v0.49.1",
+	"kyverno/chainsaw":                   "v0.2.12",
+	"norwoodj/helm-docs":                 "v1.14.2",
+	"pre-commit/mirrors-mypy":            "v2.3.0",
+	"pre-commit/pre-commit-hooks":        "v6.0.0",
+	"rhysd/actionlint":                   "v1.7.7",
+	"zizmorcore/zizmor-pre-commit":       "v1.11.0",
+}
+
+// goModuleRevs are the repositories whose revision is also used as Go module
+// version in additional_dependencies. The module path carries the major
+// version, so ResolveRevs does not bump their major version.
+var goModuleRevs = map[string]bool{
+	"giantswarm/schemalint": true,
+	"kyverno/chainsaw":      true,
+}
+
+const githubPrefix = "https://github.com/"
+
+// DefaultRevs returns the revisions the generated config pins, keyed by the
+// owner/repo of the hook repository on GitHub.
+func DefaultRevs() map[string]string {
+	revs := make(map[string]string, len(defaultRevs))
+	for repo, rev := range defaultRevs {
+		revs[repo] = rev
+	}
+	return revs
+}
+
+// KeepRevs returns the revisions to pin without looking up the latest tags:
+// the default revisions, or the revisions in existing, as returned by
+// ReadRevs, where those are newer. Regenerating the config so never
+// downgrades hooks bumped before, e.g. by ResolveRevs or Renovate.
+func KeepRevs(existing map[string]string) map[string]string {
+	revs := DefaultRevs()
+	for repo, rev := range revs {
+		if goModuleRevs[repo] && !sameMajor(rev, existing[repo]) {
+			continue
+		}
+		revs[repo] = newest(rev, existing[repo])
+	}
+
+	return revs
+}
+
+// LatestTagFunc returns the latest tag of the given GitHub repository.
+type LatestTagFunc func(ctx context.Context, owner, repo string) (string, error)
+
+// ResolveRevs returns the revisions to pin: for every hook repository of
+// repos, as returned by PreCommit.HookRepos, the newest of the default
+// revision, the revision in existing, as returned by ReadRevs, and the latest
+// tag returned by latest. The other repositories keep their default revision.
+// Revisions which are not semver versions lose against the ones that are.
+func ResolveRevs(ctx context.Context, latest LatestTagFunc, existing map[string]string, repos []string) (map[string]string, error) {
+	revs := DefaultRevs()
+
+	for _, repo := range repos {
+		rev, ok := revs[repo]
+		if !ok {
+			continue
+		}
+		owner, name, _ := strings.Cut(repo, "/")
+		tag, err := latest(ctx, owner, name)
+		if err != nil {
+			return nil, microerror.Maskf(executionFailedError, "resolving latest tag of %s: %s", repo, err)
+		}
+
+		if goModuleRevs[repo] && !sameMajor(rev, tag) {
+			tag = rev
+		}
+
+		revs[repo] = newest(rev, existing[repo], tag)
+	}
+
+	return revs, nil
+}
+
+// ReadRevs returns the revisions pinned in the pre-commit config at the
+// given path, keyed by the owner/repo of GitHub hook repositories. A missing
+// file has no revisions.
+func ReadRevs(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is the generated config
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]string{}, nil
+	} else if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var config struct {
+		Repos []struct {
+			Repo string `yaml:"repo"`
+			Rev  string `yaml:"rev"`
+		} `yaml:"repos"`
+	}
+	err = yaml.NewDecoder(bytes.NewReader(data)).Decode(&config)
+	if err != nil {
+		return nil, microerror.Maskf(executionFailedError, "%s: %s", path, err)
+	}
+
+	revs := map[string]string{}
+	for _, r := range config.Repos {
+		repo, ok := strings.CutPrefix(r.Repo, githubPrefix)
+		if !ok || r.Rev == "" {
+			continue
+		}
+		repo = strings.TrimSuffix(repo, ".git")
+		revs[repo] = newest(revs[repo], r.Rev)
+	}
+
+	return revs, nil
+}
+
+// newest returns the highest semver version of the given revisions, or the
+// first non-empty one when none is a semver version.
+func newest(revs ...string) string {
+	var result string
+	var resultVersion *semver.Version
+	for _, rev := range revs {
+		if rev == "" {
+			continue
+		}
+		v, err := semver.NewVersion(rev)
+		if err != nil {
+			if result == "" {
+				result = rev
+			}
+			continue
+		}
+		if resultVersion == nil || v.GreaterThan(resultVersion) {
+			result, resultVersion = rev, v
+		}
+	}
+	return result
+}
+
+func sameMajor(a, b string) bool {
+	va, errA := semver.NewVersion(a)
+	vb, errB := semver.NewVersion(b)
+	return errA == nil && errB == nil && va.Major() == vb.Major()
+}
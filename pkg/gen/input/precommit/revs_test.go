@@ -0,0 +1,246 @@
+package precommit
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"gopkg.in/yaml.v3"
+
+	"github.com/giantswarm/devctl/v8/pkg/gen"
+)
+
+// Test_TemplateRevs verifies every revision the template references is pinned
+// in DefaultRevs to a semver version, that no pinned revision is unused, and
+// that every GitHub hook repository in the rendered config gets its pin.
+func Test_TemplateRevs(t *testing.T) {
+	template, err := os.ReadFile("internal/file/pre-commit-config.yaml.template")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	referenced := map[string]bool{}
+	for _, m := range regexp.MustCompile(`index \$\.Revs "([^"]+)"`).FindAllStringSubmatch(string(template), -1) {
+		referenced[m[1]] = true
+	}
+	if strings.Count(string(template), "rev:") != strings.Count(string(template), "rev: {{ index $.Revs ") {
+		t.Errorf("expected every rev in the template to come from Revs")
+	}
+
+	revs := DefaultRevs()
+	for repo := range referenced {
+		if _, err := semver.NewVersion(revs[repo]); err != nil {
+			t.Errorf("template references %s, which has no pinned semver revision: %q", repo, revs[repo])
+		}
+	}
+	for repo := range revs {
+		if !referenced[repo] {
+			t.Errorf("revision of %s is pinned but not referenced by the template", repo)
+		}
+	}
+
+	for _, language := range []string{"go", "python", "node", "terraform", "generic"} {
+		got := renderConfig(t, Config{
+			Language: language,
+			Flavors:  []string{"actions", "bash", "dockerfile", "kyverno", "md"},
+			RepoName: "my-repo",
+		})
+
+		var config struct {
+			Repos []struct {
+				Repo string `yaml:"repo"`
+				Rev  string `yaml:"rev"`
+			} `yaml:"repos"`
+		}
+		err := yaml.Unmarshal([]byte(got), &config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, r := range config.Repos {
+			if r.Repo == "local" {
+				continue
+			}
+			repo := strings.TrimPrefix(r.Repo, githubPrefix)
+			if r.Rev == "" || r.Rev != revs[repo] {
+				t.Errorf("%s: repo %s has rev %q, want %q", language, r.Repo, r.Rev, revs[repo])
+			}
+		}
+	}
+}
+
+func Test_ResolveRevs(t *testing.T) {
+	latest := map[string]string{
+		// newer
+		"rhysd/actionlint": "v1.8.0",
+		// older than the default, e.g. a deleted release
+		"hadolint/hadolint": "v2.11.0",
+		// major bumps of Go modules are not picked up
+		"giantswarm/schemalint": "v3.0.0",
+		"kyverno/chainsaw":      "v0.3.1",
+	}
+	existing := map[string]string{
+		// the repo already pins a newer revision than the latest tag
+		"rhysd/actionlint": "v1.9.0",
+		// the repo pins an older revision
+		"norwoodj/helm-docs": "v1.11.0",
+	}
+
+	revs, err := ResolveRevs(context.Background(), func(ctx context.Context, owner, repo string) (string, error) {
+		if tag, ok := latest[owner+"/"+repo]; ok {
+			return tag, nil
+		}
+		return defaultRevs[owner+"/"+repo], nil
+	}, existing, slices.Sorted(maps.Keys(defaultRevs)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := DefaultRevs()
+	want["rhysd/actionlint"] = "v1.9.0"
+	want["kyverno/chainsaw"] = "v0.3.1"
+	if !reflect.DeepEqual(revs, want) {
+		t.Errorf("ResolveRevs() = %v, want %v", revs, want)
+	}
+}
+
+func Test_ResolveRevsError(t *testing.T) {
+	_, err := ResolveRevs(context.Background(), func(ctx context.Context, owner, repo string) (string, error) {
+		return "", fmt.Errorf("rate limited")
+	}, nil, []string{"rhysd/actionlint"})
+	if !IsExecutionFailed(err) {
+		t.Errorf("ResolveRevs() error = %v, want execution failed error", err)
+	}
+}
+
+func Test_ResolveRevsOnlyRepos(t *testing.T) {
+	var queried []string
+	revs, err := ResolveRevs(context.Background(), func(ctx context.Context, owner, repo string) (string, error) {
+		queried = append(queried, owner+"/"+repo)
+		return "v9.0.0", nil
+	}, nil, []string{"rhysd/actionlint"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(queried, []string{"rhysd/actionlint"}) {
+		t.Errorf("ResolveRevs() queried %v, want only rhysd/actionlint", queried)
+	}
+	want := DefaultRevs()
+	want["rhysd/actionlint"] = "v9.0.0"
+	if !reflect.DeepEqual(revs, want) {
+		t.Errorf("ResolveRevs() = %v, want %v", revs, want)
+	}
+}
+
+func Test_HookRepos(t *testing.T) {
+	p, err := New(Config{Language: "go", RepoName: "github.com/giantswarm/devctl"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repos, err := p.HookRepos(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Contains(repos, "dnephin/pre-commit-golang") || !slices.Contains(repos, "pre-commit/pre-commit-hooks") {
+		t.Errorf("HookRepos() = %v, want the Go and common hook repositories", repos)
+	}
+	for _, repo := range []string{"antonbabenko/pre-commit-terraform", "astral-sh/ruff-pre-commit", "kyverno/chainsaw"} {
+		if slices.Contains(repos, repo) {
+			t.Errorf("HookRepos() = %v, want no %s", repos, repo)
+		}
+	}
+}
+
+func Test_ReadRevs(t *testing.T) {
+	p := filepath.Join(t.TempDir(), ".pre-commit-config.yaml")
+	err := os.WriteFile(p, []byte(`repos:
+  - repo: https://github.com/pre-commit/pre-commit-hooks
+    rev: v6.0.0
+  - repo: https://github.com/pre-commit/pre-commit-hooks
+    rev: v6.1.0
+  - repo: https://github.com/rhysd/actionlint.git
+    rev: v1.7.9
+  - repo: local
+    hooks: []
+  - repo: https://gitlab.com/pycqa/flake8
+    rev: 7.0.0
+`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	revs, err := ReadRevs(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"pre-commit/pre-commit-hooks": "v6.1.0",
+		"rhysd/actionlint":            "v1.7.9",
+	}
+	if !reflect.DeepEqual(revs, want) {
+		t.Errorf("ReadRevs() = %v, want %v", revs, want)
+	}
+
+	revs, err = ReadRevs(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err != nil || len(revs) != 0 {
+		t.Errorf("ReadRevs(missing) = %v, %v, want no revisions", revs, err)
+	}
+}
+
+// Test_KeepRevsRegenerate verifies that regenerating over a config keeps the
+// revisions pinned newer than the defaults and replaces older ones, except
+// for Go module revisions of another major version.
+func Test_KeepRevsRegenerate(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	err := os.WriteFile(".pre-commit-config.yaml", []byte(`repos:
+  - repo: https://github.com/pre-commit/pre-commit-hooks
+    rev: v99.0.0
+  - repo: https://github.com/dnephin/pre-commit-golang
+    rev: v0.0.1
+`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	existing, err := ReadRevs(".pre-commit-config.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := New(Config{Language: "go", RepoName: "my-repo", Revs: KeepRevs(existing)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = gen.Execute(context.Background(), p.CreatePreCommitConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	revs, err := ReadRevs(".pre-commit-config.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defaults := DefaultRevs()
+	for repo, want := range map[string]string{
+		"pre-commit/pre-commit-hooks": "v99.0.0",
+		"dnephin/pre-commit-golang":   defaults["dnephin/pre-commit-golang"],
+	} {
+		if revs[repo] != want {
+			t.Errorf("%s rev = %q, want %q", repo, revs[repo], want)
+		}
+	}
+
+	kept := KeepRevs(map[string]string{"giantswarm/schemalint": "v99.0.0"})
+	if kept["giantswarm/schemalint"] != defaults["giantswarm/schemalint"] {
+		t.Errorf("KeepRevs() kept schemalint %q of another major version", kept["giantswarm/schemalint"])
+	}
+}
@@ -12,6 +12,10 @@ import (
 //go:embed renovate.json5.template
 var createRenovateTemplate string
 
+//go:generate go run ../../../update-template-sha.go renovate.json5.template
+//go:embed renovate.json5.template.sha
+var createRenovateTemplateSha string
+
 // squoteEscaper escapes the characters that would otherwise break a
 // single-quoted JSON5 string literal. GitHub reviewer/team slugs can't contain
 // these, but --interval is free-text, so values are escaped defensively to keep
@@ -46,10 +50,39 @@ func NewCreateRenovateInput(p params.Params) input.Input {
 		quotedReviewers[i] = squote(r)
 	}
 
+	ignorePaths := params.IgnorePaths(p)
+	quotedIgnorePaths := make([]string, len(ignorePaths))
+	for i, g := range ignorePaths {
+		quotedIgnorePaths[i] = squote(g)
+	}
+
+	var directoryRules []map[string]interface{}
+	for _, r := range params.DirectoryRules(p) {
+		quotedRuleReviewers := make([]string, len(r.Reviewers))
+		for i, reviewer := range r.Reviewers {
+			quotedRuleReviewers[i] = squote(reviewer)
+		}
+
+		rule := map[string]interface{}{
+			"MatchFileNames": squote(r.MatchFileNames),
+			"Reviewers":      quotedRuleReviewers,
+			"Schedule":       "",
+			"GroupName":      "",
+		}
+		if r.Schedule != "" {
+			rule["Schedule"] = squote(r.Schedule)
+		}
+		if r.GroupName != "" {
+			rule["GroupName"] = squote(r.GroupName)
+		}
+		directoryRules = append(directoryRules, rule)
+	}
+
 	i := input.Input{
 		Path:         filepath.Join(p.Dir, "renovate.json5"),
 		TemplateBody: createRenovateTemplate,
 		TemplateData: map[string]interface{}{
+			"TemplateURL":       createRenovateTemplateSha,
 			"Interval":          interval,
 			"Language":          params.Language(p),
 			"Reviewers":         quotedReviewers,
@@ -57,6 +90,8 @@ func NewCreateRenovateInput(p params.Params) input.Input {
 			"RepoName":          params.RepoName(p),
 			"HasCustomConfig":   params.HasCustomConfig(p),
 			"Deprecated":        params.Deprecated(p),
+			"IgnorePaths":       quotedIgnorePaths,
+			"DirectoryRules":    directoryRules,
 		},
 	}
 
@@ -27,3 +27,11 @@ func HasCustomConfig(p Params) bool {
 func Deprecated(p Params) bool {
 	return p.Deprecated
 }
+
+func IgnorePaths(p Params) []string {
+	return p.IgnorePaths
+}
+
+func DirectoryRules(p Params) []DirectoryRule {
+	return p.DirectoryRules
+}
@@ -30,4 +30,21 @@ type Params struct {
 	// which disables all routine updates and keeps only security/vulnerability
 	// remediation.
 	Deprecated bool
+	// IgnorePaths holds the globs rendered into the config's ignorePaths.
+	// Empty omits the key, keeping the ignorePaths of the presets.
+	IgnorePaths []string
+	// DirectoryRules holds the per-directory packageRules of a monorepo.
+	DirectoryRules []DirectoryRule
+}
+
+// DirectoryRule overrides settings for the manifests below a directory.
+type DirectoryRule struct {
+	// Dir is the slash separated directory path, "." for the root.
+	Dir string
+	// MatchFileNames is the glob matching the files of the directory.
+	MatchFileNames string
+	Reviewers      []string
+	// Schedule is a Renovate schedule, e.g. "before 4am on monday".
+	Schedule  string
+	GroupName string
 }
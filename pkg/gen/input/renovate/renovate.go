@@ -1,6 +1,9 @@
 package renovate
 
 import (
+	"sort"
+	"strings"
+
 	"github.com/giantswarm/devctl/v8/pkg/gen/input"
 	"github.com/giantswarm/devctl/v8/pkg/gen/input/renovate/internal/file"
 	"github.com/giantswarm/devctl/v8/pkg/gen/input/renovate/internal/params"
@@ -29,6 +32,28 @@ type Config struct {
 	// security/vulnerability remediation. Set for repos marked
 	// lifecycle: deprecated.
 	Deprecated bool
+	// IgnorePaths holds globs of directories, relative to the repository
+	// root, whose manifests Renovate ignores, e.g. "examples/*". Empty
+	// keeps the ignorePaths of the presets.
+	IgnorePaths []string
+	// Directories holds per-directory settings of a monorepo, rendered as
+	// packageRules matching the files below each directory.
+	Directories []Directory
+}
+
+// Directory configures the updates of a single manifest directory.
+type Directory struct {
+	// Path is the slash separated directory path relative to the
+	// repository root, e.g. "services/api". Empty or "." is the root.
+	Path string
+	// Reviewers are assigned to the update PRs of the directory.
+	Reviewers []string
+	// Interval is the Renovate schedule of the directory. daily, weekly
+	// and monthly are translated to the schedules of the corresponding
+	// Renovate presets, other values are used as they are.
+	Interval string
+	// Group, when set, groups all updates of the directory into one PR.
+	Group string
 }
 
 type Renovate struct {
@@ -47,6 +72,8 @@ func New(config Config) (*Renovate, error) {
 			RepoName:          config.RepoName,
 			HasCustomConfig:   config.HasCustomConfig,
 			Deprecated:        config.Deprecated,
+			IgnorePaths:       ignorePaths(config.IgnorePaths),
+			DirectoryRules:    directoryRules(config.Directories),
 		},
 	}
 
@@ -56,3 +83,71 @@ func New(config Config) (*Renovate, error) {
 func (d *Renovate) CreateRenovate() input.Input {
 	return file.NewCreateRenovateInput(d.params)
 }
+
+// ignorePaths turns directory globs into Renovate ignorePaths globs, which
+// match files.
+func ignorePaths(globs []string) []string {
+	var out []string
+	for _, g := range globs {
+		out = append(out, strings.TrimSuffix(strings.Trim(g, "/"), "/**")+"/**")
+	}
+	return out
+}
+
+// directoryRules returns one rule per directory that overrides anything,
+// ordered so that rules of subdirectories come after, and thereby win over,
+// the rules of their parents.
+func directoryRules(directories []Directory) []params.DirectoryRule {
+	var rules []params.DirectoryRule
+	for _, d := range directories {
+		if len(d.Reviewers) == 0 && d.Interval == "" && d.Group == "" {
+			continue
+		}
+
+		// Root manifests are the files directly in the root, as a
+		// recursive glob would match every other directory as well.
+		dir := strings.Trim(d.Path, "/")
+		match := dir + "/**"
+		if dir == "" || dir == "." {
+			dir = "."
+			match = "*"
+		}
+
+		rules = append(rules, params.DirectoryRule{
+			Dir:            dir,
+			MatchFileNames: match,
+			Reviewers:      d.Reviewers,
+			Schedule:       schedule(d.Interval),
+			GroupName:      d.Group,
+		})
+	}
+
+	depth := func(dir string) int {
+		if dir == "." {
+			return 0
+		}
+		return strings.Count(dir, "/") + 1
+	}
+	sort.SliceStable(rules, func(i, j int) bool {
+		if depth(rules[i].Dir) != depth(rules[j].Dir) {
+			return depth(rules[i].Dir) < depth(rules[j].Dir)
+		}
+		return rules[i].Dir < rules[j].Dir
+	})
+
+	return rules
+}
+
+// schedule translates dependabot style intervals into the schedules of the
+// Renovate schedule:daily, schedule:weekly and schedule:monthly presets.
+func schedule(interval string) string {
+	switch interval {
+	case "daily":
+		return "before 4am"
+	case "weekly":
+		return "before 4am on monday"
+	case "monthly":
+		return "before 4am on the first day of the month"
+	}
+	return interval
+}
@@ -1,15 +1,17 @@
 package renovate
 
 import (
-	"bytes"
+	"context"
 	"flag"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
-	"text/template"
 
 	"github.com/titanous/json5"
+
+	"github.com/giantswarm/devctl/v8/pkg/gen"
 )
 
 // update regenerates the golden fixtures in testdata/ instead of asserting
@@ -17,8 +19,12 @@ import (
 // changing the template.
 var update = flag.Bool("update", false, "update golden files")
 
-// render executes the renovate input the same way pkg/gen/internal.Execute
-// does, returning the bytes that would be written to renovate.json5.
+// templateURLRegexp matches the template URL of the header, which carries
+// the revision of the last commit touching the template.
+var templateURLRegexp = regexp.MustCompile(`https://github\.com/giantswarm/devctl/blob/\S+`)
+
+// render renders and validates the renovate input with gen.Render, returning
+// the bytes that would be written to renovate.json5.
 func render(t *testing.T, c Config) string {
 	t.Helper()
 
@@ -27,23 +33,12 @@ func render(t *testing.T, c Config) string {
 		t.Fatalf("New() returned unexpected error: %v", err)
 	}
 
-	in := r.CreateRenovate()
-
-	tpl := template.New("renovate")
-	if in.TemplateDelims.Left != "" {
-		tpl = tpl.Delims(in.TemplateDelims.Left, in.TemplateDelims.Right)
-	}
-	tpl, err = tpl.Parse(in.TemplateBody)
+	rendered, err := gen.Render(context.Background(), r.CreateRenovate())
 	if err != nil {
-		t.Fatalf("parse template: %v", err)
-	}
-
-	var rendered bytes.Buffer
-	if err := tpl.Execute(&rendered, in.TemplateData); err != nil {
-		t.Fatalf("execute template: %v", err)
+		t.Fatalf("render renovate.json5: %v", err)
 	}
 
-	return rendered.String()
+	return string(rendered)
 }
 
 // Test_ReviewersOmittedByDefault verifies that without reviewers the generated
@@ -401,6 +396,21 @@ func Test_Golden(t *testing.T) {
 			name:   "deprecated",
 			config: Config{Language: "go", Deprecated: true},
 		},
+		{
+			// Monorepo settings: ignored directories and per-directory
+			// rules, ordered parents first.
+			name: "monorepo",
+			config: Config{
+				Language:    "go",
+				IgnorePaths: []string{"examples/*", "hack/**"},
+				Directories: []Directory{
+					{Path: "services/api/v2", Interval: "monthly"},
+					{Path: "services/api", Reviewers: []string{"team:team-honeybadger"}, Interval: "daily", Group: "api"},
+					{Path: ".", Interval: "before 5am on monday"},
+					{Path: "services/web"},
+				},
+			},
+		},
 		{
 			// Every optional block on at once, so the golden pins how they
 			// compose and order.
@@ -427,6 +437,7 @@ func Test_Golden(t *testing.T) {
 				t.Fatalf("rendered config is not valid JSON5: %v\n%s", err, got)
 			}
 
+			got = templateURLRegexp.ReplaceAllString(got, "<template>")
 			golden := filepath.Join("testdata", tc.name+".json5.golden")
 
 			if *update {
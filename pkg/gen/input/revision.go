@@ -0,0 +1,56 @@
+package input
+
+import (
+	"embed"
+	"io/fs"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+)
+
+// templateShas holds the provenance files written by update-template-sha.go
+// next to every template. Each one contains the GitHub URL of the template
+// at the last commit that touched it.
+//
+//go:embed */internal/file/*.sha
+var templateShas embed.FS
+
+const templateURLPrefix = "https://github.com/giantswarm/devctl/blob/"
+
+// TemplateRevisions returns the devctl commit SHA of every template embedded
+// in this devctl binary, keyed by the template path within the devctl
+// repository, e.g. pkg/gen/input/dependabot/internal/file/dependabot.yml.template.
+func TemplateRevisions() (map[string]string, error) {
+	revisions := map[string]string{}
+
+	err := fs.WalkDir(templateShas, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		b, err := templateShas.ReadFile(path)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		url := strings.TrimSpace(string(b))
+		revision, templatePath, ok := strings.Cut(strings.TrimPrefix(url, templateURLPrefix), "/")
+		if !strings.HasPrefix(url, templateURLPrefix) || !ok || revision == "" {
+			// The file is empty when the template has never been
+			// committed, e.g. in a development checkout.
+			return nil
+		}
+
+		revisions[templatePath] = revision
+
+		return nil
+	})
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return revisions, nil
+}
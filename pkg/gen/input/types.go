@@ -18,14 +18,65 @@ type Input struct {
 	TemplateBody string
 	// TemplateData defines data for the template defined in TemplateBody.
 	TemplateData interface{}
+	// Partials are additional named templates, keyed by name, available to
+	// TemplateBody via the template action or the include function.
+	Partials map[string]string
 	// TemplateDelims are used to call
 	// https://golang.org/pkg/text/template/#Template.Delims if set.
 	TemplateDelims InputTemplateDelims
 	// SkipRegenCheck if set skips over the `isRegenerable` check when creating files
 	SkipRegenCheck bool
+	// OwnerMarker, if set, is a string only generated versions of the file
+	// contain. An existing file is overwritten when it contains the marker,
+	// and Execute fails when it does not, so files at conventional paths that
+	// are written by hand are never clobbered.
+	OwnerMarker string
+	// Validation selects the check run on the rendered content before it is
+	// written. When empty the check is picked from Path, see
+	// gen.ValidationFor.
+	Validation Validation
 }
 
 type InputTemplateDelims struct {
 	Left  string
 	Right string
 }
+
+// Validation is the kind of post-render check run on generated content.
+type Validation string
+
+const (
+	// ValidationAuto picks the check from the path of the generated file.
+	ValidationAuto Validation = ""
+	// ValidationNone disables the check, e.g. for YAML files containing
+	// Helm templating.
+	ValidationNone Validation = "none"
+	// ValidationYAML checks that the content is well-formed YAML.
+	ValidationYAML Validation = "yaml"
+	// ValidationJSON5 checks that the content is well-formed JSON5. Plain
+	// JSON is valid JSON5.
+	ValidationJSON5 Validation = "json5"
+	// ValidationGitHubWorkflow checks the structure of a GitHub Actions
+	// workflow.
+	ValidationGitHubWorkflow Validation = "github-workflow"
+	// ValidationDependabot checks the structure of a dependabot.yml.
+	ValidationDependabot Validation = "dependabot"
+	// ValidationCircleCI checks the structure of a CircleCI config.
+	ValidationCircleCI Validation = "circleci"
+	// ValidationPrecommit checks the structure of a .pre-commit-config.yaml.
+	ValidationPrecommit Validation = "pre-commit"
+)
+
+// AllValidations returns the explicit validation kinds, i.e. all but
+// ValidationAuto.
+func AllValidations() []string {
+	return []string{
+		string(ValidationNone),
+		string(ValidationYAML),
+		string(ValidationJSON5),
+		string(ValidationGitHubWorkflow),
+		string(ValidationDependabot),
+		string(ValidationCircleCI),
+		string(ValidationPrecommit),
+	}
+}
@@ -0,0 +1,275 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/giantswarm/microerror"
+	"gopkg.in/yaml.v3"
+)
+
+// funcMap returns the functions available to every generator template. The
+// names follow Helm/Sprig where an equivalent exists so templates read
+// familiar to chart authors. include needs the template being executed to
+// look up named templates, hence the argument.
+func funcMap(tmpl *template.Template) template.FuncMap {
+	return template.FuncMap{
+		// Strings.
+		"lower":      strings.ToLower,
+		"upper":      strings.ToUpper,
+		"title":      titleCase,
+		"camelcase":  camelCase,
+		"snakecase":  snakeCase,
+		"kebabcase":  kebabCase,
+		"trim":       strings.TrimSpace,
+		"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+		"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+		"replace":    func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+		"contains":   func(substr, s string) bool { return strings.Contains(s, substr) },
+		"hasPrefix":  func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+		"hasSuffix":  func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+		"split":      func(sep, s string) []string { return strings.Split(s, sep) },
+		"join":       join,
+		"quote":      func(v interface{}) string { return fmt.Sprintf("%q", fmt.Sprint(v)) },
+		"indent":     indent,
+		"nindent":    func(spaces int, s string) string { return "\n" + indent(spaces, s) },
+
+		// Defaults and conditionals.
+		"default":  defaultValue,
+		"empty":    empty,
+		"coalesce": coalesce,
+		"ternary": func(t, f interface{}, cond bool) interface{} {
+			if cond {
+				return t
+			}
+			return f
+		},
+
+		// Collections.
+		"list": func(v ...interface{}) []interface{} { return v },
+		"dict": dict,
+		"has":  has,
+
+		// Encoding.
+		"toYaml": toYaml,
+		"toJson": toJSON,
+
+		// Versions.
+		"semverCompare": semverCompare,
+
+		// Sub-templates.
+		"include": func(name string, data interface{}) (string, error) {
+			var b bytes.Buffer
+			err := tmpl.ExecuteTemplate(&b, name, data)
+			if err != nil {
+				return "", microerror.Mask(err)
+			}
+			return b.String(), nil
+		},
+	}
+}
+
+// words splits s into words at non-alphanumeric characters and lower to
+// upper case transitions, e.g. "fooBar-baz_qux" into foo, Bar, baz and qux.
+func words(s string) []string {
+	var out []string
+	var cur []rune
+
+	flush := func() {
+		if len(cur) > 0 {
+			out = append(out, string(cur))
+			cur = nil
+		}
+	}
+
+	runes := []rune(s)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r) && i > 0 && len(cur) > 0 &&
+			(unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]))):
+			flush()
+			cur = append(cur, r)
+		default:
+			cur = append(cur, r)
+		}
+	}
+	flush()
+
+	return out
+}
+
+func capitalize(w string) string {
+	r := []rune(strings.ToLower(w))
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+func titleCase(s string) string {
+	ws := words(s)
+	for i, w := range ws {
+		ws[i] = capitalize(w)
+	}
+	return strings.Join(ws, " ")
+}
+
+func camelCase(s string) string {
+	ws := words(s)
+	for i, w := range ws {
+		if i == 0 {
+			ws[i] = strings.ToLower(w)
+			continue
+		}
+		ws[i] = capitalize(w)
+	}
+	return strings.Join(ws, "")
+}
+
+func snakeCase(s string) string {
+	return strings.ToLower(strings.Join(words(s), "_"))
+}
+
+func kebabCase(s string) string {
+	return strings.ToLower(strings.Join(words(s), "-"))
+}
+
+func join(sep string, v interface{}) string {
+	switch l := v.(type) {
+	case []string:
+		return strings.Join(l, sep)
+	case nil:
+		return ""
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return fmt.Sprint(v)
+	}
+
+	parts := make([]string, rv.Len())
+	for i := range parts {
+		parts[i] = fmt.Sprint(rv.Index(i).Interface())
+	}
+
+	return strings.Join(parts, sep)
+}
+
+func indent(spaces int, s string) string {
+	pad := strings.Repeat(" ", spaces)
+	return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+}
+
+// empty reports whether v is the zero value of its type or an empty
+// collection.
+func empty(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return rv.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return rv.IsNil()
+	}
+
+	return rv.IsZero()
+}
+
+func defaultValue(def interface{}, v ...interface{}) interface{} {
+	if len(v) == 0 || empty(v[0]) {
+		return def
+	}
+	return v[0]
+}
+
+func coalesce(v ...interface{}) interface{} {
+	for _, x := range v {
+		if !empty(x) {
+			return x
+		}
+	}
+	return nil
+}
+
+func dict(v ...interface{}) (map[string]interface{}, error) {
+	if len(v)%2 != 0 {
+		return nil, microerror.Maskf(executionFailedError, "dict expects an even number of arguments, got %d", len(v))
+	}
+
+	d := map[string]interface{}{}
+	for i := 0; i < len(v); i += 2 {
+		k, ok := v[i].(string)
+		if !ok {
+			return nil, microerror.Maskf(executionFailedError, "dict keys must be strings, got %T", v[i])
+		}
+		d[k] = v[i+1]
+	}
+
+	return d, nil
+}
+
+func has(needle interface{}, haystack interface{}) bool {
+	rv := reflect.ValueOf(haystack)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return false
+	}
+
+	for i := 0; i < rv.Len(); i++ {
+		if reflect.DeepEqual(rv.Index(i).Interface(), needle) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func toYaml(v interface{}) (string, error) {
+	var b bytes.Buffer
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	err := enc.Encode(v)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+	err = enc.Close()
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
+
+func toJSON(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	return string(b), nil
+}
+
+// semverCompare reports whether version satisfies the constraint, e.g.
+// semverCompare ">=1.2.0" "1.3.0".
+func semverCompare(constraint, version string) (bool, error) {
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return false, microerror.Mask(err)
+	}
+
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return false, microerror.Mask(err)
+	}
+
+	return c.Check(v), nil
+}
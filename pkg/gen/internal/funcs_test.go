@@ -0,0 +1,116 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/giantswarm/devctl/v8/pkg/gen/input"
+)
+
+func TestExecuteFuncs(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		data     interface{}
+		partials map[string]string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name: "case conversion",
+			body: `{{ camelcase "cluster-app values" }} {{ snakecase "fooBarBaz" }} {{ kebabcase "HTTPServer_name" }} {{ title "app-test suite" }} {{ upper "a" }}{{ lower "B" }}`,
+			want: "clusterAppValues foo_bar_baz http-server-name App Test Suite Ab",
+		},
+		{
+			name: "default and empty",
+			body: `{{ .Missing | default "fallback" }} {{ .Set | default "fallback" }} {{ empty .List }} {{ coalesce .Missing "" "first" }}`,
+			data: map[string]interface{}{"Missing": "", "Set": "value", "List": []string{}},
+			want: "fallback value true first",
+		},
+		{
+			name: "toYaml with nindent",
+			body: "values:{{ toYaml .Values | nindent 2 }}",
+			data: map[string]interface{}{"Values": map[string]interface{}{"replicas": 2, "image": map[string]string{"tag": "1.0.0"}}},
+			want: "values:\n  image:\n    tag: 1.0.0\n  replicas: 2",
+		},
+		{
+			name: "toJson",
+			body: `{{ toJson .List }} {{ dict "a" 1 | toJson }}`,
+			data: map[string]interface{}{"List": []string{"go", "node"}},
+			want: `["go","node"] {"a":1}`,
+		},
+		{
+			name: "indent",
+			body: `{{ indent 2 "a\nb" }}`,
+			want: "  a\n  b",
+		},
+		{
+			name: "semverCompare",
+			body: `{{ semverCompare ">=1.17.0" "1.17.1" }} {{ semverCompare "<1.0.0" "v1.2.3" }}`,
+			want: "true false",
+		},
+		{
+			name:    "semverCompare invalid version",
+			body:    `{{ semverCompare ">=1.0.0" "latest" }}`,
+			wantErr: true,
+		},
+		{
+			name: "strings and collections",
+			body: `{{ join "," .List }} {{ has "node" .List }} {{ replace "-" "_" "a-b" }} {{ trimPrefix "v" "v1.2.3" }} {{ quote "x" }} {{ ternary "yes" "no" (contains "ell" "hello") }}`,
+			data: map[string]interface{}{"List": []string{"go", "node"}},
+			want: `go,node true a_b 1.2.3 "x" yes`,
+		},
+		{
+			name: "include defined template",
+			body: `{{ define "name" }}app-{{ . }}{{ end }}{{ include "name" "x" | upper }}`,
+			want: "APP-X",
+		},
+		{
+			name:     "include partial",
+			body:     `steps:{{ include "steps.tpl" .Steps | nindent 2 }}`,
+			data:     map[string]interface{}{"Steps": []string{"lint", "test"}},
+			partials: map[string]string{"steps.tpl": `{{- range $i, $s := . }}{{ if $i }}{{ "\n" }}{{ end }}- run: make {{ $s }}{{ end -}}`},
+			want:     "steps:\n  - run: make lint\n  - run: make test",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var b bytes.Buffer
+			err := Execute(context.Background(), &b, input.Input{
+				TemplateBody: tc.body,
+				TemplateData: tc.data,
+				Partials:     tc.partials,
+			})
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("Execute() returned no error, rendered %q", b.String())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() returned unexpected error: %v", err)
+			}
+			if b.String() != tc.want {
+				t.Errorf("Execute() rendered\n%q\nwant\n%q", b.String(), tc.want)
+			}
+		})
+	}
+}
+
+func TestExecuteFuncsWithDelims(t *testing.T) {
+	var b bytes.Buffer
+	err := Execute(context.Background(), &b, input.Input{
+		TemplateBody:   `[[ include "p" . ]] ${{ github.sha }}`,
+		TemplateData:   "x",
+		TemplateDelims: input.InputTemplateDelims{Left: "[[", Right: "]]"},
+		Partials:       map[string]string{"p": `[[ . | upper ]]`},
+	})
+	if err != nil {
+		t.Fatalf("Execute() returned unexpected error: %v", err)
+	}
+	if got, want := b.String(), "X ${{ github.sha }}"; got != want {
+		t.Errorf("Execute() rendered %q, want %q", got, want)
+	}
+}
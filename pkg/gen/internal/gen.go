@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sort"
 	"text/template"
 
 	"github.com/giantswarm/microerror"
@@ -15,6 +16,7 @@ func Execute(ctx context.Context, w io.Writer, f input.Input) error {
 	var err error
 
 	tmpl := template.New(fmt.Sprintf("%T", f))
+	tmpl = tmpl.Funcs(funcMap(tmpl))
 
 	emptyDelims := input.InputTemplateDelims{}
 	if f.TemplateDelims != emptyDelims {
@@ -26,6 +28,15 @@ func Execute(ctx context.Context, w io.Writer, f input.Input) error {
 		return microerror.Mask(err)
 	}
 
+	// Partials are parsed into the same template set so the main template
+	// can render them with the template action or include.
+	for _, name := range sortedKeys(f.Partials) {
+		_, err = tmpl.New(name).Parse(f.Partials[name])
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
 	err = tmpl.Execute(w, f.TemplateData)
 	if err != nil {
 		return microerror.Mask(err)
@@ -33,3 +44,13 @@ func Execute(ctx context.Context, w io.Writer, f input.Input) error {
 
 	return nil
 }
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,21 @@
+package monorepo
+
+import "github.com/giantswarm/microerror"
+
+var invalidConfigError = &microerror.Error{
+	Kind: "invalidConfigError",
+}
+
+// IsInvalidConfig asserts invalidConfigError.
+func IsInvalidConfig(err error) bool {
+	return microerror.Cause(err) == invalidConfigError
+}
+
+var invalidSettingsError = &microerror.Error{
+	Kind: "invalidSettingsError",
+}
+
+// IsInvalidSettings asserts invalidSettingsError.
+func IsInvalidSettings(err error) bool {
+	return microerror.Cause(err) == invalidSettingsError
+}
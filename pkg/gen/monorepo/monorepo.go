@@ -0,0 +1,204 @@
+// Package monorepo discovers the package manifests of a repository, so
+// dependency update configs can cover every directory of a monorepo.
+package monorepo
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+
+	"github.com/giantswarm/devctl/v8/pkg/gen"
+)
+
+// Root is the Path of the repository root directory.
+const Root = "."
+
+// skipDirs are never searched for manifests. They hold dependencies or
+// test fixtures rather than manifests of the repository itself.
+var skipDirs = map[string]bool{
+	".git":         true,
+	"node_modules": true,
+	"testdata":     true,
+	"vendor":       true,
+}
+
+// Directory is a directory holding package manifests.
+type Directory struct {
+	// Path is the slash separated directory path relative to the
+	// repository root, Root for the root itself, e.g. "services/api".
+	Path string
+	// Ecosystems are the dependabot ecosystems of the manifests found in
+	// the directory, sorted.
+	Ecosystems []string
+}
+
+type Config struct {
+	// Dir is the repository root. Defaults to the working directory.
+	Dir string
+	// Ignore holds globs of directory paths, relative to Dir, which are
+	// not searched, together with their subdirectories, e.g. "examples/*".
+	Ignore []string
+	// Recursive searches all subdirectories. Otherwise only the root is
+	// searched.
+	Recursive bool
+}
+
+// Discover returns the directories holding package manifests, sorted by path
+// with the root first.
+func Discover(config Config) ([]Directory, error) {
+	if config.Dir == "" {
+		config.Dir = "."
+	}
+	for _, pattern := range config.Ignore {
+		_, err := path.Match(pattern, "")
+		if err != nil {
+			return nil, microerror.Maskf(invalidConfigError, "ignore glob %#q is malformed", pattern)
+		}
+	}
+
+	found := map[string]map[string]bool{}
+	add := func(dir string, e gen.Ecosystem) {
+		if found[dir] == nil {
+			found[dir] = map[string]bool{}
+		}
+		found[dir][e.String()] = true
+	}
+
+	// Workflows, abs test definitions and dev containers only live at the
+	// root.
+	if isDir(filepath.Join(config.Dir, ".github", "workflows")) {
+		add(Root, gen.EcosystemGithubActions)
+	}
+	if isFile(filepath.Join(config.Dir, ".abs", "main.yaml")) {
+		add(Root, gen.EcosystemPIP)
+	}
+	if isFile(filepath.Join(config.Dir, ".devcontainer", "devcontainer.json")) || isFile(filepath.Join(config.Dir, ".devcontainer.json")) {
+		add(Root, gen.EcosystemDevcontainers)
+	}
+	// Charts conventionally live in helm/<chart>, so they are found even
+	// when subdirectories are not searched.
+	if !config.Recursive {
+		charts, err := filepath.Glob(filepath.Join(config.Dir, "helm", "*", "Chart.yaml"))
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		for _, c := range charts {
+			rel, err := filepath.Rel(config.Dir, filepath.Dir(c))
+			if err != nil {
+				return nil, microerror.Mask(err)
+			}
+			if !Ignored(filepath.ToSlash(rel), config.Ignore) {
+				add(filepath.ToSlash(rel), gen.EcosystemHelm)
+			}
+		}
+	}
+
+	err := filepath.WalkDir(config.Dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		rel, err := filepath.Rel(config.Dir, p)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		rel = filepath.ToSlash(rel)
+
+		if d.IsDir() {
+			if rel == Root {
+				return nil
+			}
+			if !config.Recursive || skipDirs[d.Name()] || strings.HasPrefix(d.Name(), ".") || Ignored(rel, config.Ignore) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		dir := path.Dir(rel)
+		for _, e := range ecosystems(filepath.Dir(p), d.Name()) {
+			add(dir, e)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var dirs []Directory
+	for dir, set := range found {
+		d := Directory{Path: dir}
+		for e := range set {
+			d.Ecosystems = append(d.Ecosystems, e)
+		}
+		sort.Strings(d.Ecosystems)
+		dirs = append(dirs, d)
+	}
+	sort.Slice(dirs, func(i, j int) bool {
+		if dirs[i].Path == Root || dirs[j].Path == Root {
+			return dirs[i].Path == Root && dirs[j].Path != Root
+		}
+		return dirs[i].Path < dirs[j].Path
+	})
+
+	return dirs, nil
+}
+
+// Ignored reports whether the directory at the given slash separated path,
+// or one of its parents, matches one of the globs.
+func Ignored(dir string, globs []string) bool {
+	for p := dir; p != Root && p != "/"; p = path.Dir(p) {
+		for _, g := range globs {
+			if ok, _ := path.Match(g, p); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ecosystems returns the ecosystems of the manifest with the given name in
+// dir.
+func ecosystems(dir, name string) []gen.Ecosystem {
+	switch {
+	case name == "Dockerfile" || strings.HasPrefix(name, "Dockerfile."):
+		return []gen.Ecosystem{gen.EcosystemDocker}
+	case name == "go.mod" && isFile(filepath.Join(dir, "go.sum")):
+		return []gen.Ecosystem{gen.EcosystemGomod}
+	case name == "package.json":
+		return []gen.Ecosystem{gen.EcosystemNPM}
+	// setup.py tells us the directory is likely a python project.
+	case name == "setup.py" || name == "pyproject.toml" || name == "requirements.txt":
+		return []gen.Ecosystem{gen.EcosystemPIP}
+	case name == "Gemfile":
+		return []gen.Ecosystem{gen.EcosystemBundler}
+	case name == "Cargo.toml":
+		return []gen.Ecosystem{gen.EcosystemCargo}
+	case name == "build.gradle" || name == "build.gradle.kts" || name == "settings.gradle" || name == "settings.gradle.kts":
+		return []gen.Ecosystem{gen.EcosystemGradle}
+	case name == "pom.xml":
+		return []gen.Ecosystem{gen.EcosystemMaven}
+	case name == "Chart.yaml":
+		return []gen.Ecosystem{gen.EcosystemHelm}
+	case name == "pubspec.yaml":
+		return []gen.Ecosystem{gen.EcosystemPub}
+	case path.Ext(name) == ".tf":
+		return []gen.Ecosystem{gen.EcosystemTerraform}
+	}
+	return nil
+}
+
+func isDir(p string) bool {
+	fi, err := os.Stat(p)
+	return err == nil && fi.IsDir()
+}
+
+func isFile(p string) bool {
+	fi, err := os.Stat(p)
+	return err == nil && !fi.IsDir()
+}
@@ -0,0 +1,164 @@
+package monorepo
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeTree(t *testing.T, files ...string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for _, f := range files {
+		p := filepath.Join(dir, filepath.FromSlash(f))
+		err := os.MkdirAll(filepath.Dir(p), 0750)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(p, nil, 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func TestDiscover(t *testing.T) {
+	dir := writeTree(t,
+		".github/workflows/ci.yaml",
+		"go.mod", "go.sum", "Dockerfile",
+		"services/api/go.mod", "services/api/go.sum", "services/api/Dockerfile.debug",
+		"services/web/package.json",
+		"services/web/node_modules/left-pad/package.json",
+		"services/lib/go.mod",
+		"examples/demo/requirements.txt",
+		"pkg/testdata/go.mod", "pkg/testdata/go.sum",
+		".hidden/package.json",
+		".devcontainer/devcontainer.json",
+		"helm/my-app/Chart.yaml",
+		"infra/main.tf", "infra/variables.tf",
+		"rust/Cargo.toml", "ruby/Gemfile", "java/pom.xml", "java/build.gradle.kts", "dart/pubspec.yaml",
+	)
+
+	tests := []struct {
+		name   string
+		config Config
+		want   []Directory
+	}{
+		{
+			name:   "root only",
+			config: Config{Dir: dir},
+			want: []Directory{
+				{Path: Root, Ecosystems: []string{"devcontainers", "docker", "github-actions", "gomod"}},
+				{Path: "helm/my-app", Ecosystems: []string{"helm"}},
+			},
+		},
+		{
+			name:   "recursive",
+			config: Config{Dir: dir, Recursive: true},
+			want: []Directory{
+				{Path: Root, Ecosystems: []string{"devcontainers", "docker", "github-actions", "gomod"}},
+				{Path: "dart", Ecosystems: []string{"pub"}},
+				{Path: "examples/demo", Ecosystems: []string{"pip"}},
+				{Path: "helm/my-app", Ecosystems: []string{"helm"}},
+				{Path: "infra", Ecosystems: []string{"terraform"}},
+				{Path: "java", Ecosystems: []string{"gradle", "maven"}},
+				{Path: "ruby", Ecosystems: []string{"bundler"}},
+				{Path: "rust", Ecosystems: []string{"cargo"}},
+				{Path: "services/api", Ecosystems: []string{"docker", "gomod"}},
+				{Path: "services/web", Ecosystems: []string{"npm"}},
+			},
+		},
+		{
+			name:   "recursive with ignore globs",
+			config: Config{Dir: dir, Recursive: true, Ignore: []string{"examples", "services/w*", "[a-r]*"}},
+			want: []Directory{
+				{Path: Root, Ecosystems: []string{"devcontainers", "docker", "github-actions", "gomod"}},
+				{Path: "services/api", Ecosystems: []string{"docker", "gomod"}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Discover(tc.config)
+			if err != nil {
+				t.Fatalf("Discover() returned unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Discover() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDiscoverMalformedIgnore(t *testing.T) {
+	_, err := Discover(Config{Dir: t.TempDir(), Ignore: []string{"["}})
+	if !IsInvalidConfig(err) {
+		t.Errorf("Discover() error = %v, want invalid config error", err)
+	}
+}
+
+func TestSettings(t *testing.T) {
+	dir := t.TempDir()
+	p := filepath.Join(dir, "settings.yaml")
+	err := os.WriteFile(p, []byte(`ignore:
+  - examples/*
+directories:
+  - path: services/api
+    interval: daily
+  - path: services/*
+    reviewers: [giantswarm/team-honeybadger]
+    group: services
+`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := ReadSettings(p)
+	if err != nil {
+		t.Fatalf("ReadSettings() returned unexpected error: %v", err)
+	}
+
+	if d, ok := s.For("services/api"); !ok || d.Interval != "daily" || d.Group != "" {
+		t.Errorf("For(services/api) = %+v, %v, want the first matching entry", d, ok)
+	}
+	if d, ok := s.For("services/web"); !ok || d.Group != "services" {
+		t.Errorf("For(services/web) = %+v, %v, want the glob entry", d, ok)
+	}
+	if _, ok := s.For("services/web/ui"); ok {
+		t.Errorf("For(services/web/ui) matched although globs do not cross directories")
+	}
+	if _, ok := s.For(Root); ok {
+		t.Errorf("For(.) matched")
+	}
+}
+
+func TestReadSettingsInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings string
+	}{
+		{"unknown field", "directories:\n  - path: a\n    reviewer: [x]\n"},
+		{"missing path", "directories:\n  - interval: daily\n"},
+		{"malformed glob", "ignore: ['[']\n"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := filepath.Join(t.TempDir(), "settings.yaml")
+			err := os.WriteFile(p, []byte(tc.settings), 0600)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = ReadSettings(p)
+			if !IsInvalidSettings(err) {
+				t.Errorf("ReadSettings() error = %v, want invalid settings error", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package monorepo
+
+import (
+	"bytes"
+	"os"
+	"path"
+
+	"github.com/giantswarm/microerror"
+	"gopkg.in/yaml.v3"
+)
+
+// Settings holds the per-directory settings of a monorepo. It is read from
+// a YAML file, see ReadSettings:
+//
+//	ignore:
+//	  - examples/*
+//	directories:
+//	  - path: services/*
+//	    reviewers: [giantswarm/team-honeybadger]
+//	    interval: daily
+//	    group: services
+type Settings struct {
+	// Ignore holds globs of directories which are not searched for
+	// manifests, see Config.Ignore.
+	Ignore []string `yaml:"ignore"`
+	// Directories holds the settings of the directories matching their
+	// path glob. The first matching entry applies.
+	Directories []DirectorySettings `yaml:"directories"`
+}
+
+// DirectorySettings overrides the generator defaults for the directories
+// matching Path.
+type DirectorySettings struct {
+	// Path is a glob of slash separated directory paths relative to the
+	// repository root, e.g. "services/*". Root matches the root itself.
+	Path string `yaml:"path"`
+	// Reviewers are assigned to the update PRs of the directory.
+	Reviewers []string `yaml:"reviewers"`
+	// Interval is the update schedule of the directory, e.g. daily.
+	Interval string `yaml:"interval"`
+	// Group, when set, groups the updates of the directory into a single
+	// PR per ecosystem with the given name.
+	Group string `yaml:"group"`
+}
+
+// ReadSettings reads the settings file at the given path.
+func ReadSettings(p string) (Settings, error) {
+	b, err := os.ReadFile(p) // #nosec G304 -- path is provided by the user
+	if err != nil {
+		return Settings{}, microerror.Mask(err)
+	}
+
+	var s Settings
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	dec.KnownFields(true)
+	err = dec.Decode(&s)
+	if err != nil {
+		return Settings{}, microerror.Maskf(invalidSettingsError, "%s: %s", p, err)
+	}
+
+	for _, pattern := range s.Ignore {
+		_, err := path.Match(pattern, "")
+		if err != nil {
+			return Settings{}, microerror.Maskf(invalidSettingsError, "%s: ignore glob %#q is malformed", p, pattern)
+		}
+	}
+	for _, d := range s.Directories {
+		if d.Path == "" {
+			return Settings{}, microerror.Maskf(invalidSettingsError, "%s: directories must define path", p)
+		}
+		_, err := path.Match(d.Path, "")
+		if err != nil {
+			return Settings{}, microerror.Maskf(invalidSettingsError, "%s: directory path glob %#q is malformed", p, d.Path)
+		}
+	}
+
+	return s, nil
+}
+
+// For returns the settings of the directory at the given path. The returned
+// bool is false when no entry matches.
+func (s Settings) For(dir string) (DirectorySettings, bool) {
+	for _, d := range s.Directories {
+		if ok, _ := path.Match(d.Path, dir); ok {
+			return d, true
+		}
+	}
+	return DirectorySettings{}, false
+}
@@ -0,0 +1,60 @@
+package gen
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Node package managers, as DetectNodePackageManager returns them. Yarn Berry
+// and Yarn Classic are told apart because their install commands and caches
+// differ.
+const (
+	PackageManagerNPM         = "npm"
+	PackageManagerYarn        = "yarn"
+	PackageManagerYarnClassic = "yarn-classic"
+	PackageManagerPNPM        = "pnpm"
+)
+
+// Python package managers, as DetectPythonPackageManager returns them.
+const (
+	PackageManagerUV     = "uv"
+	PackageManagerPoetry = "poetry"
+	PackageManagerPip    = "pip"
+)
+
+// DetectNodePackageManager returns the Node package manager of the repository
+// in dir from its lockfile: package-lock.json is npm, pnpm-lock.yaml is pnpm,
+// a yarn.lock with the v1 header is Yarn Classic and any other yarn.lock Yarn
+// Berry. Repositories without a lockfile get Yarn Berry too.
+//
+// Every generator rendering Node commands uses it, so the CI config, the
+// Makefile and the pre-commit hooks agree on the package manager.
+func DetectNodePackageManager(dir string) string {
+	if _, err := os.Stat(filepath.Join(dir, "package-lock.json")); err == nil {
+		return PackageManagerNPM
+	}
+	if _, err := os.Stat(filepath.Join(dir, "pnpm-lock.yaml")); err == nil {
+		return PackageManagerPNPM
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "yarn.lock")) // #nosec G304 -- fixed lockfile name
+	if err == nil && strings.Contains(string(data), "yarn lockfile v1") {
+		return PackageManagerYarnClassic
+	}
+
+	return PackageManagerYarn
+}
+
+// DetectPythonPackageManager returns the Python package manager of the
+// repository in dir from its lockfile: uv.lock is uv, poetry.lock is poetry.
+// Repositories with neither are installed with pip.
+func DetectPythonPackageManager(dir string) string {
+	if _, err := os.Stat(filepath.Join(dir, "uv.lock")); err == nil {
+		return PackageManagerUV
+	}
+	if _, err := os.Stat(filepath.Join(dir, "poetry.lock")); err == nil {
+		return PackageManagerPoetry
+	}
+
+	return PackageManagerPip
+}
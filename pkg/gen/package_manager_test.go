@@ -0,0 +1,71 @@
+package gen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectPackageManager(t *testing.T) {
+	tests := []struct {
+		name       string
+		files      map[string]string
+		wantNode   string
+		wantPython string
+	}{
+		{
+			name:       "no lockfile",
+			wantNode:   PackageManagerYarn,
+			wantPython: PackageManagerPip,
+		},
+		{
+			name:     "package-lock.json",
+			files:    map[string]string{"package-lock.json": "{}", "yarn.lock": ""},
+			wantNode: PackageManagerNPM,
+		},
+		{
+			name:     "pnpm-lock.yaml",
+			files:    map[string]string{"pnpm-lock.yaml": ""},
+			wantNode: PackageManagerPNPM,
+		},
+		{
+			name:     "yarn classic",
+			files:    map[string]string{"yarn.lock": "# THIS IS AN AUTOGENERATED FILE. DO NOT EDIT THIS FILE DIRECTLY.\n# yarn lockfile v1\n"},
+			wantNode: PackageManagerYarnClassic,
+		},
+		{
+			name:     "yarn berry",
+			files:    map[string]string{"yarn.lock": "__metadata:\n  version: 8\n"},
+			wantNode: PackageManagerYarn,
+		},
+		{
+			name:       "uv.lock",
+			files:      map[string]string{"uv.lock": "", "requirements.txt": ""},
+			wantPython: PackageManagerUV,
+		},
+		{
+			name:       "poetry.lock",
+			files:      map[string]string{"poetry.lock": ""},
+			wantPython: PackageManagerPoetry,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if got := DetectNodePackageManager(dir); tt.wantNode != "" && got != tt.wantNode {
+				t.Errorf("DetectNodePackageManager() = %q, want %q", got, tt.wantNode)
+			}
+			if got := DetectPythonPackageManager(dir); tt.wantPython != "" && got != tt.wantPython {
+				t.Errorf("DetectPythonPackageManager() = %q, want %q", got, tt.wantPython)
+			}
+		})
+	}
+}
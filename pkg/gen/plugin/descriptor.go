@@ -0,0 +1,268 @@
+package plugin
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+	"gopkg.in/yaml.v3"
+
+	"github.com/giantswarm/devctl/v8/pkg/gen"
+	"github.com/giantswarm/devctl/v8/pkg/gen/input"
+)
+
+// DescriptorFileName is the name of the file describing a plugin. It lives
+// in the root of the plugin directory, next to the plugin's templates.
+const DescriptorFileName = "devctl-plugin.yaml"
+
+const (
+	FlagTypeBool        = "bool"
+	FlagTypeString      = "string"
+	FlagTypeStringSlice = "stringSlice"
+)
+
+// reservedKeys are template data keys populated by devctl itself.
+var reservedKeys = map[string]bool{
+	"Flavours": true,
+	"Header":   true,
+	"Language": true,
+}
+
+var (
+	nameRegexp = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+	keyRegexp  = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// Descriptor describes a plugin generator. It is read from the
+// devctl-plugin.yaml file of the plugin directory.
+type Descriptor struct {
+	// Name is the name of the `devctl gen` subcommand registered for the
+	// plugin.
+	Name string `yaml:"name"`
+	// Description is shown as the command's help text.
+	Description string `yaml:"description"`
+	// Flags are the command line flags of the command. Their values are
+	// passed to the templates.
+	Flags []Flag `yaml:"flags"`
+	// Files are the files generated by the command.
+	Files []File `yaml:"files"`
+	// Partials are template files, relative to the plugin directory,
+	// available to every file template by their path through the
+	// template action or the include function.
+	Partials []string `yaml:"partials"`
+}
+
+// Flag describes a command line flag of a plugin command.
+type Flag struct {
+	// Name is the flag name, e.g. "repo-name" for --repo-name.
+	Name string `yaml:"name"`
+	// Shorthand is the optional one letter shorthand of the flag.
+	Shorthand string `yaml:"shorthand"`
+	// Description is the flag usage text.
+	Description string `yaml:"description"`
+	// Type is one of bool, string and stringSlice. Defaults to string.
+	Type string `yaml:"type"`
+	// Default is the default flag value in its command line form, e.g.
+	// "true" for a bool flag or "a,b" for a stringSlice flag.
+	Default string `yaml:"default"`
+	// Required makes the command fail when the flag is not set.
+	Required bool `yaml:"required"`
+	// Key is the template data key the flag value is exposed as. Defaults
+	// to the flag name in UpperCamelCase, e.g. "RepoName" for "repo-name".
+	Key string `yaml:"key"`
+}
+
+// File describes a file generated by a plugin command.
+type File struct {
+	// Template is the path of the Go text template, relative to the plugin
+	// directory.
+	Template string `yaml:"template"`
+	// Path is the path of the generated file, relative to the repository
+	// root.
+	Path string `yaml:"path"`
+	// Regenerable makes the file overwritten on every run. Otherwise the
+	// file is a scaffolding file which is only created when it does not
+	// exist yet, unless its name marks it as regenerable anyway (e.g. a
+	// zz_generated. prefix).
+	Regenerable bool `yaml:"regenerable"`
+	// Permissions of the generated file in octal notation, e.g. "0755".
+	// Defaults to "0644".
+	Permissions string `yaml:"permissions"`
+	// HeaderComment is the comment prefix used to render the "DO NOT
+	// EDIT" header exposed to the template as .Header, e.g. "#".
+	HeaderComment string `yaml:"headerComment"`
+	// Delims optionally overrides the template action delimiters.
+	Delims Delims `yaml:"delims"`
+	// Flavours restricts generation to repositories of the given
+	// flavours. Empty means all flavours.
+	Flavours []string `yaml:"flavours"`
+	// Languages restricts generation to repositories of the given
+	// languages. Empty means all languages.
+	Languages []string `yaml:"languages"`
+	// Validation selects the check run on the rendered file, e.g. "none"
+	// for YAML containing Helm templating. Empty picks the check from the
+	// file path.
+	Validation string `yaml:"validation"`
+}
+
+type Delims struct {
+	Left  string `yaml:"left"`
+	Right string `yaml:"right"`
+}
+
+// TemplateKey returns the template data key of the flag.
+func (f Flag) TemplateKey() string {
+	if f.Key != "" {
+		return f.Key
+	}
+
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(f.Name, func(r rune) bool { return r == '-' || r == '_' }) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+
+	return b.String()
+}
+
+// FlagType returns the flag type, defaulting to string.
+func (f Flag) FlagType() string {
+	if f.Type == "" {
+		return FlagTypeString
+	}
+
+	return f.Type
+}
+
+func readDescriptor(dir string) (Descriptor, error) {
+	b, err := os.ReadFile(filepath.Join(dir, DescriptorFileName))
+	if os.IsNotExist(err) {
+		return Descriptor{}, microerror.Maskf(notFoundError, "no %s in %#q", DescriptorFileName, dir)
+	} else if err != nil {
+		return Descriptor{}, microerror.Mask(err)
+	}
+
+	var d Descriptor
+	dec := yaml.NewDecoder(strings.NewReader(string(b)))
+	dec.KnownFields(true)
+	err = dec.Decode(&d)
+	if err != nil {
+		return Descriptor{}, microerror.Maskf(invalidDescriptorError, "%s: %s", filepath.Join(dir, DescriptorFileName), err)
+	}
+
+	err = d.validate()
+	if err != nil {
+		return Descriptor{}, microerror.Maskf(invalidDescriptorError, "%s: %s", filepath.Join(dir, DescriptorFileName), err)
+	}
+
+	return d, nil
+}
+
+func (d Descriptor) validate() error {
+	if !nameRegexp.MatchString(d.Name) {
+		return microerror.Maskf(invalidDescriptorError, "name must match %s, got %#q", nameRegexp, d.Name)
+	}
+	if len(d.Files) == 0 {
+		return microerror.Maskf(invalidDescriptorError, "at least one file must be defined")
+	}
+
+	seenFlags := map[string]bool{
+		// Every plugin command has these.
+		"flavour":  true,
+		"language": true,
+	}
+	seenShorthands := map[string]bool{
+		// Shorthands of the flags above and of --help.
+		"f": true,
+		"l": true,
+		"h": true,
+	}
+	seenKeys := map[string]bool{}
+	for _, f := range d.Flags {
+		if !nameRegexp.MatchString(f.Name) {
+			return microerror.Maskf(invalidDescriptorError, "flag name must match %s, got %#q", nameRegexp, f.Name)
+		}
+		if seenFlags[f.Name] {
+			return microerror.Maskf(invalidDescriptorError, "flag %#q is defined twice or is reserved", f.Name)
+		}
+		seenFlags[f.Name] = true
+
+		if len(f.Shorthand) > 1 {
+			return microerror.Maskf(invalidDescriptorError, "flag %#q shorthand must be a single letter", f.Name)
+		}
+		if f.Shorthand != "" {
+			if seenShorthands[f.Shorthand] {
+				return microerror.Maskf(invalidDescriptorError, "flag %#q shorthand %#q is defined twice or is reserved", f.Name, f.Shorthand)
+			}
+			seenShorthands[f.Shorthand] = true
+		}
+
+		switch f.FlagType() {
+		case FlagTypeBool, FlagTypeString, FlagTypeStringSlice:
+		default:
+			return microerror.Maskf(invalidDescriptorError, "flag %#q type must be one of %s|%s|%s, got %#q", f.Name, FlagTypeBool, FlagTypeString, FlagTypeStringSlice, f.Type)
+		}
+
+		key := f.TemplateKey()
+		if !keyRegexp.MatchString(key) {
+			return microerror.Maskf(invalidDescriptorError, "flag %#q key must match %s, got %#q", f.Name, keyRegexp, key)
+		}
+		if reservedKeys[key] || seenKeys[key] {
+			return microerror.Maskf(invalidDescriptorError, "flag %#q key %#q is defined twice or is reserved", f.Name, key)
+		}
+		seenKeys[key] = true
+	}
+
+	for _, partial := range d.Partials {
+		if !isLocalPath(partial) {
+			return microerror.Maskf(invalidDescriptorError, "partial %#q must be a relative path within the plugin directory", partial)
+		}
+	}
+
+	for _, f := range d.Files {
+		if f.Template == "" || f.Path == "" {
+			return microerror.Maskf(invalidDescriptorError, "files must define template and path")
+		}
+		if !isLocalPath(f.Template) {
+			return microerror.Maskf(invalidDescriptorError, "template %#q must be a relative path within the plugin directory", f.Template)
+		}
+		if !isLocalPath(f.Path) {
+			return microerror.Maskf(invalidDescriptorError, "path %#q must be a relative path within the repository", f.Path)
+		}
+		if (f.Delims.Left == "") != (f.Delims.Right == "") {
+			return microerror.Maskf(invalidDescriptorError, "file %#q must define both left and right delims", f.Path)
+		}
+		if f.Permissions != "" {
+			_, err := parsePermissions(f.Permissions)
+			if err != nil {
+				return microerror.Maskf(invalidDescriptorError, "file %#q permissions must be octal, e.g. 0755, got %#q", f.Path, f.Permissions)
+			}
+		}
+		for _, flavour := range f.Flavours {
+			_, err := gen.NewFlavour(flavour)
+			if err != nil {
+				return microerror.Maskf(invalidDescriptorError, "file %#q: %s", f.Path, err)
+			}
+		}
+		for _, language := range f.Languages {
+			_, err := gen.NewLanguage(language)
+			if err != nil {
+				return microerror.Maskf(invalidDescriptorError, "file %#q: %s", f.Path, err)
+			}
+		}
+		if f.Validation != "" && !slices.Contains(input.AllValidations(), f.Validation) {
+			return microerror.Maskf(invalidDescriptorError, "file %#q validation must be one of %s, got %#q", f.Path, strings.Join(input.AllValidations(), ", "), f.Validation)
+		}
+	}
+
+	return nil
+}
+
+// isLocalPath reports whether p is a relative slash separated path that
+// does not escape its root.
+func isLocalPath(p string) bool {
+	return filepath.IsLocal(filepath.FromSlash(p)) && !strings.Contains(p, "\\") && path.Clean(p) != "."
+}
@@ -0,0 +1,30 @@
+package plugin
+
+import "github.com/giantswarm/microerror"
+
+var invalidConfigError = &microerror.Error{
+	Kind: "invalidConfigError",
+}
+
+// IsInvalidConfig asserts invalidConfigError.
+func IsInvalidConfig(err error) bool {
+	return microerror.Cause(err) == invalidConfigError
+}
+
+var invalidDescriptorError = &microerror.Error{
+	Kind: "invalidDescriptorError",
+}
+
+// IsInvalidDescriptor asserts invalidDescriptorError.
+func IsInvalidDescriptor(err error) bool {
+	return microerror.Cause(err) == invalidDescriptorError
+}
+
+var notFoundError = &microerror.Error{
+	Kind: "notFoundError",
+}
+
+// IsNotFound asserts notFoundError.
+func IsNotFound(err error) bool {
+	return microerror.Cause(err) == notFoundError
+}
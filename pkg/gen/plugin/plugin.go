@@ -0,0 +1,163 @@
+package plugin
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/giantswarm/microerror"
+
+	"github.com/giantswarm/devctl/v8/pkg/gen"
+	"github.com/giantswarm/devctl/v8/pkg/gen/input"
+	"github.com/giantswarm/devctl/v8/pkg/gen/internal"
+)
+
+// Plugin is a generator loaded from a directory holding a devctl-plugin.yaml
+// descriptor and the templates it references.
+type Plugin struct {
+	Descriptor Descriptor
+	// Dir is the directory the plugin was loaded from.
+	Dir string
+
+	templates map[string]string
+	partials  map[string]string
+}
+
+// Config is the per invocation configuration of a plugin generator.
+type Config struct {
+	Flavours gen.FlavourSlice
+	Language string
+	// Values holds the flag values keyed by their template data key. See
+	// Flag.TemplateKey.
+	Values map[string]interface{}
+}
+
+// Load loads the plugin in the given directory.
+func Load(dir string) (*Plugin, error) {
+	d, err := readDescriptor(dir)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	p := &Plugin{
+		Descriptor: d,
+		Dir:        dir,
+		templates:  map[string]string{},
+		partials:   map[string]string{},
+	}
+
+	for _, partial := range d.Partials {
+		b, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(partial)))
+		if err != nil {
+			return nil, microerror.Maskf(invalidDescriptorError, "plugin %#q: %s", d.Name, err)
+		}
+		p.partials[partial] = string(b)
+	}
+
+	for _, f := range d.Files {
+		if _, ok := p.templates[f.Template]; ok {
+			continue
+		}
+
+		b, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(f.Template)))
+		if err != nil {
+			return nil, microerror.Maskf(invalidDescriptorError, "plugin %#q: %s", d.Name, err)
+		}
+		p.templates[f.Template] = string(b)
+	}
+
+	return p, nil
+}
+
+// Inputs returns the inputs of all files whose flavour and language
+// conditions match the given config.
+func (p *Plugin) Inputs(config Config) ([]input.Input, error) {
+	var inputs []input.Input
+
+	for _, f := range p.Descriptor.Files {
+		if !matchesFlavours(f.Flavours, config.Flavours) || !matchesLanguage(f.Languages, config.Language) {
+			continue
+		}
+
+		var permissions fs.FileMode
+		if f.Permissions != "" {
+			var err error
+			permissions, err = parsePermissions(f.Permissions)
+			if err != nil {
+				return nil, microerror.Mask(err)
+			}
+		}
+
+		data := map[string]interface{}{}
+		for k, v := range config.Values {
+			data[k] = v
+		}
+		flavours := make([]string, len(config.Flavours))
+		for i, flavour := range config.Flavours {
+			flavours[i] = flavour.String()
+		}
+		data["Flavours"] = flavours
+		data["Language"] = config.Language
+		data["Header"] = ""
+		if f.HeaderComment != "" {
+			data["Header"] = internal.Header(f.HeaderComment, "")
+		}
+
+		inputs = append(inputs, input.Input{
+			Path:         filepath.FromSlash(f.Path),
+			Permissions:  permissions,
+			TemplateBody: p.templates[f.Template],
+			TemplateData: data,
+			Partials:     p.partials,
+			TemplateDelims: input.InputTemplateDelims{
+				Left:  f.Delims.Left,
+				Right: f.Delims.Right,
+			},
+			SkipRegenCheck: f.Regenerable,
+			Validation:     input.Validation(f.Validation),
+		})
+	}
+
+	return inputs, nil
+}
+
+func matchesFlavours(want []string, have gen.FlavourSlice) bool {
+	if len(want) == 0 {
+		return true
+	}
+
+	for _, w := range want {
+		if have.Contains(gen.Flavour(w)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesLanguage(want []string, have string) bool {
+	if len(want) == 0 {
+		return true
+	}
+
+	for _, w := range want {
+		if w == have {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parsePermissions(s string) (fs.FileMode, error) {
+	m, err := strconv.ParseUint(s, 8, 32)
+	if err != nil {
+		return 0, microerror.Mask(err)
+	}
+	if m == 0 || m > 0777 {
+		return 0, microerror.Maskf(invalidDescriptorError, "permissions must be within 0001-0777, got %#q", s)
+	}
+
+	return fs.FileMode(m), nil
+}
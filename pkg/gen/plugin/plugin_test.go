@@ -0,0 +1,220 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/giantswarm/devctl/v8/pkg/gen"
+	"github.com/giantswarm/devctl/v8/pkg/gen/input"
+	"github.com/giantswarm/devctl/v8/pkg/gen/internal"
+)
+
+func render(t *testing.T, in input.Input) string {
+	t.Helper()
+
+	var b bytes.Buffer
+	err := internal.Execute(context.Background(), &b, in)
+	if err != nil {
+		t.Fatalf("execute template for %s: %v", in.Path, err)
+	}
+
+	return b.String()
+}
+
+func TestLoadAndInputs(t *testing.T) {
+	p, err := Load("testdata/backstage")
+	if err != nil {
+		t.Fatalf("Load() returned unexpected error: %v", err)
+	}
+
+	if got := p.Descriptor.Flags[0].TemplateKey(); got != "Owner" {
+		t.Errorf("TemplateKey() = %q, want Owner", got)
+	}
+
+	tests := []struct {
+		name      string
+		config    Config
+		wantPaths []string
+	}{
+		{
+			name:      "no flavour or language",
+			config:    Config{},
+			wantPaths: []string{"catalog-info.yaml"},
+		},
+		{
+			name:      "matching flavour",
+			config:    Config{Flavours: gen.FlavourSlice{gen.FlavourGeneric, gen.FlavourCLI}},
+			wantPaths: []string{"catalog-info.yaml", ".github/workflows/zz_generated.techdocs.yaml"},
+		},
+		{
+			name:      "matching language",
+			config:    Config{Language: "go"},
+			wantPaths: []string{"catalog-info.yaml", "docs/go.txt"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.config.Values = map[string]interface{}{
+				"Owner":     "team-honeybadger",
+				"Lifecycle": "production",
+				"Tags":      []string{"go", "cli"},
+				"Techdocs":  true,
+			}
+
+			inputs, err := p.Inputs(tc.config)
+			if err != nil {
+				t.Fatalf("Inputs() returned unexpected error: %v", err)
+			}
+
+			var paths []string
+			for _, in := range inputs {
+				paths = append(paths, filepath.ToSlash(in.Path))
+				render(t, in)
+			}
+			if strings.Join(paths, ",") != strings.Join(tc.wantPaths, ",") {
+				t.Errorf("Inputs() paths = %v, want %v", paths, tc.wantPaths)
+			}
+		})
+	}
+}
+
+func TestInputsTemplateData(t *testing.T) {
+	p, err := Load("testdata/backstage")
+	if err != nil {
+		t.Fatalf("Load() returned unexpected error: %v", err)
+	}
+
+	inputs, err := p.Inputs(Config{
+		Flavours: gen.FlavourSlice{gen.FlavourApp},
+		Language: "go",
+		Values: map[string]interface{}{
+			"Owner":     "team-honeybadger",
+			"Lifecycle": "experimental",
+			"Tags":      []string{"go"},
+			"Techdocs":  false,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Inputs() returned unexpected error: %v", err)
+	}
+
+	catalog := render(t, inputs[0])
+	for _, want := range []string{"  labels:\n    owner: team-honeybadger\n", "lifecycle: experimental", "    - go"} {
+		if !strings.Contains(catalog, want) {
+			t.Errorf("catalog-info.yaml does not contain %q:\n%s", want, catalog)
+		}
+	}
+	if strings.Contains(catalog, "techdocs") {
+		t.Errorf("catalog-info.yaml contains techdocs although the flag is false:\n%s", catalog)
+	}
+	if inputs[0].SkipRegenCheck {
+		t.Errorf("catalog-info.yaml is not regenerable but SkipRegenCheck is set")
+	}
+
+	if !inputs[1].SkipRegenCheck {
+		t.Errorf("regenerable file has SkipRegenCheck unset")
+	}
+	techdocs := render(t, inputs[1])
+	for _, want := range []string{"# DO NOT EDIT. Generated with:", "# flavours: app"} {
+		if !strings.Contains(techdocs, want) {
+			t.Errorf("techdocs workflow does not contain %q:\n%s", want, techdocs)
+		}
+	}
+
+	if got := render(t, inputs[2]); got != "language: go\n" {
+		t.Errorf("custom delims rendered %q", got)
+	}
+}
+
+func TestLoadInvalidDescriptor(t *testing.T) {
+	tests := []struct {
+		name       string
+		descriptor string
+	}{
+		{"unknown field", "name: x\nfiles: [{template: t, path: p}]\nunknown: true\n"},
+		{"invalid name", "name: X\nfiles: [{template: t, path: p}]\n"},
+		{"no files", "name: x\n"},
+		{"escaping path", "name: x\nfiles: [{template: t, path: ../p}]\n"},
+		{"absolute template", "name: x\nfiles: [{template: /etc/passwd, path: p}]\n"},
+		{"reserved key", "name: x\nflags: [{name: header}]\nfiles: [{template: t, path: p}]\n"},
+		{"reserved flag", "name: x\nflags: [{name: flavour}]\nfiles: [{template: t, path: p}]\n"},
+		{"reserved shorthand", "name: x\nflags: [{name: a, shorthand: f}]\nfiles: [{template: t, path: p}]\n"},
+		{"duplicate shorthand", "name: x\nflags: [{name: a, shorthand: a}, {name: b, shorthand: a}]\nfiles: [{template: t, path: p}]\n"},
+		{"unknown flag type", "name: x\nflags: [{name: a, type: int}]\nfiles: [{template: t, path: p}]\n"},
+		{"unknown flavour", "name: x\nfiles: [{template: t, path: p, flavours: [nope]}]\n"},
+		{"invalid permissions", "name: x\nfiles: [{template: t, path: p, permissions: \"999\"}]\n"},
+		{"unknown validation", "name: x\nfiles: [{template: t, path: p, validation: toml}]\n"},
+		{"half delims", "name: x\nfiles: [{template: t, path: p, delims: {left: \"[[\"}}]\n"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			err := os.WriteFile(filepath.Join(dir, DescriptorFileName), []byte(tc.descriptor), 0600)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = os.WriteFile(filepath.Join(dir, "t"), []byte("x"), 0600)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = Load(dir)
+			if !IsInvalidDescriptor(err) {
+				t.Errorf("Load() error = %v, want invalid descriptor error", err)
+			}
+		})
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	plugins, errs := Discover([]string{"testdata", "testdata/backstage", "testdata/does-not-exist"})
+	if len(errs) != 0 {
+		t.Fatalf("Discover() returned unexpected errors: %v", errs)
+	}
+	if len(plugins) != 1 || plugins[0].Descriptor.Name != "backstage" {
+		t.Errorf("Discover() = %v, want the backstage plugin once", plugins)
+	}
+}
+
+func TestDiscoverSkipsInvalidPlugins(t *testing.T) {
+	dir := t.TempDir()
+	descriptors := map[string]string{
+		"ok":       "name: ok\nflags: [{name: owner, shorthand: o}]\nfiles: [{template: t, path: p}]\n",
+		"reserved": "name: reserved\nflags: [{name: format, shorthand: f}]\nfiles: [{template: t, path: p}]\n",
+		"twice":    "name: twice\nflags: [{name: a, shorthand: x}, {name: b, shorthand: x}]\nfiles: [{template: t, path: p}]\n",
+	}
+	for name, descriptor := range descriptors {
+		sub := filepath.Join(dir, name)
+		err := os.Mkdir(sub, 0750)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(filepath.Join(sub, DescriptorFileName), []byte(descriptor), 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(filepath.Join(sub, "t"), []byte("x"), 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	plugins, errs := Discover([]string{dir})
+	if len(errs) != 2 {
+		t.Errorf("Discover() returned %d errors, want 2: %v", len(errs), errs)
+	}
+	for _, err := range errs {
+		if !IsInvalidDescriptor(err) {
+			t.Errorf("Discover() error = %v, want invalid descriptor error", err)
+		}
+	}
+	if len(plugins) != 1 || plugins[0].Descriptor.Name != "ok" {
+		t.Errorf("Discover() = %v, want the ok plugin only", plugins)
+	}
+}
@@ -0,0 +1,166 @@
+package plugin
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/giantswarm/microerror"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+)
+
+// Discover loads the plugins found in the given directories. A directory is
+// either a plugin itself or holds plugins in its subdirectories. Directories
+// that do not exist are ignored. Plugins that fail to load are reported in
+// the returned errors without preventing the other plugins from loading.
+// When several plugins share a name the first one found wins.
+func Discover(dirs []string) ([]*Plugin, []error) {
+	var plugins []*Plugin
+	var errs []error
+
+	seen := map[string]bool{}
+	add := func(dir string) {
+		p, err := Load(dir)
+		if err != nil {
+			errs = append(errs, err)
+			return
+		}
+		if seen[p.Descriptor.Name] {
+			return
+		}
+		seen[p.Descriptor.Name] = true
+		plugins = append(plugins, p)
+	}
+
+	for _, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+
+		if _, err := os.Stat(filepath.Join(dir, DescriptorFileName)); err == nil {
+			add(dir)
+			continue
+		}
+
+		entries, err := os.ReadDir(dir)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			errs = append(errs, microerror.Mask(err))
+			continue
+		}
+
+		for _, e := range entries {
+			if !e.IsDir() {
+				continue
+			}
+			sub := filepath.Join(dir, e.Name())
+			if _, err := os.Stat(filepath.Join(sub, DescriptorFileName)); err != nil {
+				continue
+			}
+			add(sub)
+		}
+	}
+
+	sort.SliceStable(plugins, func(i, j int) bool {
+		return plugins[i].Descriptor.Name < plugins[j].Descriptor.Name
+	})
+
+	return plugins, errs
+}
+
+// InstallConfig configures Install.
+type InstallConfig struct {
+	// URL is the URL of the Git repository holding the plugin.
+	URL string
+	// Ref is the branch or tag to check out. Defaults to the remote HEAD.
+	Ref string
+	// Subdir is the directory of the plugin within the repository.
+	// Defaults to the repository root.
+	Subdir string
+	// Dir is the directory plugins are installed into. The plugin ends up
+	// in a subdirectory named after the plugin.
+	Dir string
+	// Token authenticates the clone when set, e.g. for private GitHub
+	// repositories.
+	Token string
+}
+
+// Install clones a plugin from a Git repository into config.Dir, replacing
+// an already installed plugin of the same name.
+func Install(ctx context.Context, config InstallConfig) (*Plugin, error) {
+	if config.URL == "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.URL must not be empty", config)
+	}
+	if config.Dir == "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Dir must not be empty", config)
+	}
+	if config.Subdir != "" && !isLocalPath(config.Subdir) {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Subdir must be a relative path within the repository", config)
+	}
+
+	err := os.MkdirAll(config.Dir, 0750)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	tmp, err := os.MkdirTemp(config.Dir, ".install-*")
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	defer func() { _ = os.RemoveAll(tmp) }()
+
+	cloneDir := filepath.Join(tmp, "repo")
+	opts := &git.CloneOptions{
+		URL:   config.URL,
+		Depth: 1,
+	}
+	if config.Ref != "" {
+		opts.ReferenceName = plumbing.ReferenceName(config.Ref)
+		if !opts.ReferenceName.IsBranch() && !opts.ReferenceName.IsTag() {
+			opts.ReferenceName = plumbing.NewBranchReferenceName(config.Ref)
+		}
+		opts.SingleBranch = true
+	}
+	if config.Token != "" {
+		opts.Auth = &http.BasicAuth{Username: "devctl", Password: config.Token}
+	}
+
+	_, err = git.PlainCloneContext(ctx, cloneDir, false, opts)
+	if config.Ref != "" && err != nil && opts.ReferenceName.IsBranch() {
+		// The ref may be a tag given without the refs/tags/ prefix.
+		_ = os.RemoveAll(cloneDir)
+		opts.ReferenceName = plumbing.NewTagReferenceName(config.Ref)
+		_, err = git.PlainCloneContext(ctx, cloneDir, false, opts)
+	}
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	pluginDir := filepath.Join(cloneDir, filepath.FromSlash(config.Subdir))
+
+	p, err := Load(pluginDir)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	target := filepath.Join(config.Dir, p.Descriptor.Name)
+	err = os.RemoveAll(target)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	err = os.Rename(pluginDir, target)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	p, err = Load(target)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return p, nil
+}
@@ -0,0 +1,3 @@
+{{- define "labels" -}}
+owner: {{ .Owner | kebabcase }}
+{{- end -}}
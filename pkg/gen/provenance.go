@@ -0,0 +1,212 @@
+package gen
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"regexp"
+
+	"github.com/giantswarm/microerror"
+)
+
+const (
+	templateURLPrefix = "https://github.com/giantswarm/devctl/blob/"
+
+	// contentHashKey labels the header line carrying the hash of the
+	// generated content. It is written right below the template URL lines
+	// of the header.
+	contentHashKey = "content-sha256:"
+
+	// headerScanLines bounds how far into a file, from either end, the
+	// provenance header is searched for. Most headers are rendered at the
+	// very top of generated files, possibly after a shebang or a YAML
+	// document marker. The gen llm ones close the file in an HTML comment.
+	headerScanLines = 20
+
+	// headerScanBytes bounds how much of either end of a file is read before
+	// deciding whether it carries a provenance header at all.
+	headerScanBytes = 4096
+)
+
+var (
+	// templateURLRegexp matches a template URL line, either behind a line
+	// comment as rendered by internal.Header or on its own line inside a
+	// block comment.
+	templateURLRegexp = regexp.MustCompile(`^(.*?)\s*https://github\.com/giantswarm/devctl/blob/([0-9a-f]{7,40})/(\S+)\s*$`)
+	contentHashRegexp = regexp.MustCompile(`^(?:.*?\s+)?` + regexp.QuoteMeta(contentHashKey) + `\s*([0-9a-f]{64})\s*$`)
+)
+
+// Provenance describes the devctl template a generated file was rendered
+// from, as recorded in the file's "DO NOT EDIT" header.
+type Provenance struct {
+	// Path is the path of the generated file.
+	Path string
+	// TemplatePath is the path of the template within the devctl
+	// repository, e.g. pkg/gen/input/dependabot/internal/file/dependabot.yml.template.
+	TemplatePath string
+	// Revision is the devctl commit SHA of the template the file was
+	// generated from.
+	Revision string
+	// Templates lists every template the header records, for files
+	// combining several templates, e.g. AGENTS.md. TemplatePath and
+	// Revision are those of the first.
+	Templates []TemplateRevision
+	// ContentHash is the content hash stamped into the header at
+	// generation time. It is empty for files generated before devctl
+	// started stamping content hashes.
+	ContentHash string
+	// Modified is true when the file content does not match ContentHash,
+	// i.e. the file was edited after it was generated. It is always false
+	// when ContentHash is empty.
+	Modified bool
+}
+
+// TemplateRevision is a template recorded in a provenance header.
+type TemplateRevision struct {
+	// TemplatePath is the path of the template within the devctl
+	// repository.
+	TemplatePath string
+	// Revision is the devctl commit SHA of the template.
+	Revision string
+}
+
+// ReadProvenance reads the provenance header of the file at the given path.
+// The returned bool is false when the file has no devctl provenance header.
+func ReadProvenance(path string) (Provenance, bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Provenance{}, false, microerror.Mask(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	head := make([]byte, headerScanBytes)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return Provenance{}, false, microerror.Mask(err)
+	}
+	head = head[:n]
+
+	if !bytes.Contains(head, []byte(templateURLPrefix)) {
+		info, err := f.Stat()
+		if err != nil {
+			return Provenance{}, false, microerror.Mask(err)
+		}
+		if info.Size() <= int64(n) {
+			return Provenance{}, false, nil
+		}
+
+		tail := make([]byte, min(headerScanBytes, info.Size()-int64(n)))
+		_, err = f.ReadAt(tail, info.Size()-int64(len(tail)))
+		if err != nil && err != io.EOF {
+			return Provenance{}, false, microerror.Mask(err)
+		}
+		if !bytes.Contains(tail, []byte(templateURLPrefix)) {
+			return Provenance{}, false, nil
+		}
+	}
+
+	rest, err := io.ReadAll(f)
+	if err != nil {
+		return Provenance{}, false, microerror.Mask(err)
+	}
+
+	p, ok := ParseProvenance(append(head, rest...))
+	p.Path = path
+
+	return p, ok, nil
+}
+
+// ParseProvenance parses the provenance header of generated content. The
+// returned bool is false when the content has no devctl provenance header.
+func ParseProvenance(content []byte) (Provenance, bool) {
+	lines := bytes.SplitAfter(content, []byte("\n"))
+
+	var p Provenance
+	var found bool
+	for _, i := range headerLines(lines) {
+		line := bytes.TrimRight(lines[i], "\r\n")
+
+		if m := templateURLRegexp.FindSubmatch(line); m != nil {
+			p.Templates = append(p.Templates, TemplateRevision{TemplatePath: string(m[3]), Revision: string(m[2])})
+			if !found {
+				p.Revision = string(m[2])
+				p.TemplatePath = string(m[3])
+				found = true
+			}
+			continue
+		}
+		if !found {
+			continue
+		}
+
+		m := contentHashRegexp.FindSubmatch(line)
+		if m != nil {
+			p.ContentHash = string(m[1])
+			withoutHash := append(bytes.Join(lines[:i], nil), bytes.Join(lines[i+1:], nil)...)
+			p.Modified = contentHash(withoutHash) != p.ContentHash
+			break
+		}
+	}
+
+	return p, found
+}
+
+// stampContentHash inserts a content hash line below the template URL lines
+// of the provenance header. Content without a provenance header is returned
+// unchanged. The hash covers the content as rendered, so removing the
+// stamped line again yields the bytes the hash was computed from.
+func stampContentHash(content []byte) []byte {
+	lines := bytes.SplitAfter(content, []byte("\n"))
+
+	for _, i := range headerLines(lines) {
+		m := templateURLRegexp.FindSubmatch(bytes.TrimRight(lines[i], "\r\n"))
+		if m == nil {
+			continue
+		}
+
+		// Headers of combined files list one template URL per line.
+		for i+1 < len(lines) && templateURLRegexp.Match(bytes.TrimRight(lines[i+1], "\r\n")) {
+			i++
+		}
+
+		// The template URL lines are followed by the rest of the
+		// header, so the last one always ends with a newline.
+		if !bytes.HasSuffix(lines[i], []byte("\n")) {
+			return content
+		}
+
+		hashLine := contentHashKey + " " + contentHash(content) + "\n"
+		if prefix := string(m[1]); prefix != "" {
+			hashLine = prefix + "    " + hashLine
+		}
+
+		var out bytes.Buffer
+		out.Write(bytes.Join(lines[:i+1], nil))
+		out.WriteString(hashLine)
+		out.Write(bytes.Join(lines[i+1:], nil))
+
+		return out.Bytes()
+	}
+
+	return content
+}
+
+// headerLines returns the indexes of the lines a provenance header is
+// searched in, the first and the last headerScanLines, in order.
+func headerLines(lines [][]byte) []int {
+	var indexes []int
+	for i := range lines {
+		if i < headerScanLines || i >= len(lines)-headerScanLines {
+			indexes = append(indexes, i)
+		}
+	}
+
+	return indexes
+}
+
+func contentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
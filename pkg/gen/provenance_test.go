@@ -0,0 +1,102 @@
+package gen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/giantswarm/devctl/v8/pkg/gen/internal"
+)
+
+const testTemplateURL = "https://github.com/giantswarm/devctl/blob/0ec3e49745962245bdd6d5c282d4272c40faec37/pkg/gen/input/makefile/internal/file/Makefile.gen.go.mk.template"
+
+func TestStampContentHash(t *testing.T) {
+	rendered := internal.Header("#", testTemplateURL) + "\n\nall:\n\techo ok\n"
+
+	stamped := string(stampContentHash([]byte(rendered)))
+
+	if !strings.Contains(stamped, "#    "+contentHashKey+" ") {
+		t.Fatalf("stamped content has no content hash line:\n%s", stamped)
+	}
+	if !strings.HasSuffix(stamped, "\n\nall:\n\techo ok\n") {
+		t.Errorf("stamped content body changed:\n%s", stamped)
+	}
+
+	p, ok := ParseProvenance([]byte(stamped))
+	if !ok {
+		t.Fatalf("ParseProvenance() found no header in:\n%s", stamped)
+	}
+	if p.Revision != "0ec3e49745962245bdd6d5c282d4272c40faec37" {
+		t.Errorf("Revision = %q", p.Revision)
+	}
+	if p.TemplatePath != "pkg/gen/input/makefile/internal/file/Makefile.gen.go.mk.template" {
+		t.Errorf("TemplatePath = %q", p.TemplatePath)
+	}
+	if p.ContentHash == "" {
+		t.Errorf("ContentHash is empty")
+	}
+	if p.Modified {
+		t.Errorf("freshly stamped content reported as modified")
+	}
+
+	edited := strings.Replace(stamped, "echo ok", "echo changed", 1)
+	p, _ = ParseProvenance([]byte(edited))
+	if !p.Modified {
+		t.Errorf("edited content not reported as modified")
+	}
+}
+
+func TestStampContentHashWithoutHeader(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"no header", "all:\n\techo ok\n"},
+		{"header without url", internal.Header("#", "") + "\nall:\n"},
+		{"foreign url", "# https://github.com/giantswarm/other/blob/abc1234/file\n"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := string(stampContentHash([]byte(tc.content))); got != tc.content {
+				t.Errorf("stampContentHash() changed content without provenance header:\n%s", got)
+			}
+			if _, ok := ParseProvenance([]byte(tc.content)); ok {
+				t.Errorf("ParseProvenance() reported a header")
+			}
+		})
+	}
+}
+
+func TestParseProvenanceLegacyHeader(t *testing.T) {
+	content := "#!/usr/bin/env bash\n" + internal.Header("#", testTemplateURL) + "\necho ok\n"
+
+	p, ok := ParseProvenance([]byte(content))
+	if !ok {
+		t.Fatalf("ParseProvenance() found no header")
+	}
+	if p.ContentHash != "" || p.Modified {
+		t.Errorf("legacy header without content hash reported ContentHash=%q Modified=%v", p.ContentHash, p.Modified)
+	}
+}
+
+func TestStampContentHashClosingComment(t *testing.T) {
+	otherURL := "https://github.com/giantswarm/devctl/blob/f7e9aa98a933cfd6e1a44da848a6b10a844b27ef/pkg/gen/input/llm/internal/file/go_rules.md.template"
+	body := strings.Repeat("Some rule.\n", 30)
+	rendered := body + "\n<!--\nDO NOT EDIT. Generated with devctl.\nThis file is maintained at:\n" + testTemplateURL + "\n" + otherURL + "\nManual changes will be overwritten.\n-->\n"
+
+	stamped := string(stampContentHash([]byte(rendered)))
+	if !strings.Contains(stamped, otherURL+"\n"+contentHashKey+" ") {
+		t.Fatalf("content hash line is not below the template URLs:\n%s", stamped)
+	}
+
+	p, ok := ParseProvenance([]byte(stamped))
+	if !ok {
+		t.Fatalf("ParseProvenance() found no header in:\n%s", stamped)
+	}
+	if len(p.Templates) != 2 || p.Templates[1].TemplatePath != "pkg/gen/input/llm/internal/file/go_rules.md.template" {
+		t.Errorf("Templates = %+v, want both templates", p.Templates)
+	}
+	if p.ContentHash == "" || p.Modified {
+		t.Errorf("ContentHash = %q, Modified = %v", p.ContentHash, p.Modified)
+	}
+}
@@ -0,0 +1,558 @@
+package gen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+	"github.com/titanous/json5"
+	"gopkg.in/yaml.v3"
+
+	"github.com/giantswarm/devctl/v8/pkg/gen/input"
+)
+
+var yamlErrorRegexp = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+// ValidationFor returns the check Validate runs for a file generated at the
+// given path when its input does not select one explicitly.
+func ValidationFor(path string) input.Validation {
+	slashed := filepath.ToSlash(path)
+	base := filepath.Base(path)
+	ext := filepath.Ext(path)
+	isYAML := ext == ".yaml" || ext == ".yml"
+
+	switch {
+	case isYAML && (strings.HasPrefix(slashed, ".github/workflows/") || strings.Contains(slashed, "/.github/workflows/")):
+		return input.ValidationGitHubWorkflow
+	case base == "dependabot.yml" || base == "dependabot.yaml":
+		return input.ValidationDependabot
+	case base == ".pre-commit-config.yaml":
+		return input.ValidationPrecommit
+	case isYAML && filepath.Base(filepath.Dir(path)) == ".circleci" && base != "custom.yml":
+		return input.ValidationCircleCI
+	case isYAML:
+		return input.ValidationYAML
+	case ext == ".json5" || ext == ".json":
+		return input.ValidationJSON5
+	}
+
+	return input.ValidationNone
+}
+
+// Validate checks the rendered content of the given input. Problems are
+// reported as a validationFailedError listing every problem found, each
+// prefixed with the file path and line.
+func Validate(file input.Input, content []byte) error {
+	validation := file.Validation
+	if validation == input.ValidationAuto {
+		validation = ValidationFor(file.Path)
+	}
+
+	v := &validator{path: file.Path}
+
+	switch validation {
+	case input.ValidationNone:
+		return nil
+	case input.ValidationJSON5:
+		v.json5(content)
+	case input.ValidationYAML:
+		v.yaml(content)
+	case input.ValidationGitHubWorkflow:
+		v.document(content, v.githubWorkflow)
+	case input.ValidationDependabot:
+		v.document(content, v.dependabot)
+	case input.ValidationCircleCI:
+		v.document(content, v.circleCI)
+	case input.ValidationPrecommit:
+		v.document(content, v.precommit)
+	default:
+		return microerror.Maskf(invalidConfigError, "unknown validation %#q for file %#q", validation, file.Path)
+	}
+
+	if len(v.problems) > 0 {
+		return microerror.Maskf(validationFailedError, "%s", strings.Join(v.problems, "\n"))
+	}
+
+	return nil
+}
+
+type validator struct {
+	path     string
+	problems []string
+}
+
+func (v *validator) addf(line int, format string, args ...interface{}) {
+	v.problems = append(v.problems, fmt.Sprintf("%s:%d: %s", v.path, line, fmt.Sprintf(format, args...)))
+}
+
+func (v *validator) json5(content []byte) {
+	var out interface{}
+	err := json5.Unmarshal(content, &out)
+
+	var syntaxErr *json5.SyntaxError
+	switch {
+	case err == nil:
+	case errors.As(err, &syntaxErr):
+		offset := int(syntaxErr.Offset)
+		if offset > len(content) {
+			offset = len(content)
+		}
+		v.addf(bytes.Count(content[:offset], []byte("\n"))+1, "invalid JSON5: %s", syntaxErr.Error())
+	default:
+		v.addf(1, "invalid JSON5: %s", err.Error())
+	}
+}
+
+// yaml parses all documents in content and returns them. Syntax errors are
+// recorded as problems.
+func (v *validator) yaml(content []byte) []*yaml.Node {
+	var docs []*yaml.Node
+
+	dec := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		var doc yaml.Node
+		err := dec.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			return docs
+		} else if err != nil {
+			if m := yamlErrorRegexp.FindStringSubmatch(err.Error()); m != nil {
+				line, _ := strconv.Atoi(m[1])
+				v.addf(line, "invalid YAML: %s", m[2])
+			} else {
+				v.addf(1, "invalid YAML: %s", strings.TrimPrefix(err.Error(), "yaml: "))
+			}
+			return nil
+		}
+
+		docs = append(docs, &doc)
+	}
+}
+
+// document parses content as a single YAML document with a mapping at the
+// root and passes the mapping to check.
+func (v *validator) document(content []byte, check func(root *yaml.Node)) {
+	docs := v.yaml(content)
+	if len(v.problems) > 0 {
+		return
+	}
+
+	if len(docs) != 1 || len(docs[0].Content) == 0 {
+		v.addf(1, "expected exactly one YAML document, got %d", len(docs))
+		return
+	}
+
+	root := resolve(docs[0].Content[0])
+	if root.Kind != yaml.MappingNode {
+		v.addf(root.Line, "expected a mapping at the document root")
+		return
+	}
+
+	check(root)
+}
+
+// mapping checks that n is a mapping and reports keys not in allowed. It
+// returns false when n is not a mapping.
+func (v *validator) mapping(n *yaml.Node, what string, allowed ...string) bool {
+	if n.Kind != yaml.MappingNode {
+		v.addf(n.Line, "%s must be a mapping", what)
+		return false
+	}
+
+	if len(allowed) == 0 {
+		return true
+	}
+
+	for _, p := range pairs(n) {
+		if !slices.Contains(allowed, p.key.Value) {
+			v.addf(p.key.Line, "unknown key %q in %s", p.key.Value, what)
+		}
+	}
+
+	return true
+}
+
+// required returns the value of key in mapping n and reports a problem if
+// the key is missing.
+func (v *validator) required(n *yaml.Node, key, what string) *yaml.Node {
+	val := lookup(n, key)
+	if val == nil {
+		v.addf(n.Line, "%s is missing required key %q", what, key)
+	}
+	return val
+}
+
+func (v *validator) githubWorkflow(root *yaml.Node) {
+	v.mapping(root, "workflow", "name", "run-name", "on", "permissions", "env", "defaults", "concurrency", "jobs")
+
+	on := v.required(root, "on", "workflow")
+	if on != nil && isEmpty(on) {
+		v.addf(on.Line, "workflow \"on\" must name at least one event")
+	}
+
+	jobs := v.required(root, "jobs", "workflow")
+	if jobs == nil || !v.mapping(jobs, "jobs") {
+		return
+	}
+	if len(jobs.Content) == 0 {
+		v.addf(jobs.Line, "workflow must define at least one job")
+	}
+
+	for _, job := range pairs(jobs) {
+		what := fmt.Sprintf("job %q", job.key.Value)
+		if !v.mapping(job.value, what,
+			"name", "permissions", "needs", "if", "runs-on", "environment", "concurrency", "outputs",
+			"env", "defaults", "steps", "timeout-minutes", "strategy", "continue-on-error", "container",
+			"services", "uses", "with", "secrets") {
+			continue
+		}
+
+		for _, need := range scalars(lookup(job.value, "needs")) {
+			if lookup(jobs, need.Value) == nil {
+				v.addf(need.Line, "%s needs unknown job %q", what, need.Value)
+			}
+		}
+
+		// Jobs either run steps on a runner or call a reusable workflow.
+		uses := lookup(job.value, "uses")
+		steps := lookup(job.value, "steps")
+		switch {
+		case uses != nil:
+			if steps != nil {
+				v.addf(steps.Line, "%s calls a reusable workflow and must not define steps", what)
+			}
+			continue
+		case lookup(job.value, "runs-on") == nil:
+			v.addf(job.value.Line, "%s is missing required key \"runs-on\"", what)
+		}
+
+		if steps == nil {
+			v.addf(job.value.Line, "%s is missing required key \"steps\"", what)
+			continue
+		}
+		if steps.Kind != yaml.SequenceNode || len(steps.Content) == 0 {
+			v.addf(steps.Line, "%s steps must be a non-empty sequence", what)
+			continue
+		}
+
+		for i, step := range steps.Content {
+			step = resolve(step)
+			stepWhat := fmt.Sprintf("%s step %d", what, i+1)
+			if !v.mapping(step, stepWhat,
+				"id", "if", "name", "uses", "run", "working-directory", "shell", "with", "env",
+				"continue-on-error", "timeout-minutes") {
+				continue
+			}
+			if (lookup(step, "uses") == nil) == (lookup(step, "run") == nil) {
+				v.addf(step.Line, "%s must define exactly one of \"uses\" and \"run\"", stepWhat)
+			}
+		}
+	}
+}
+
+var (
+	dependabotEcosystems = []string{
+		"bazel", "bun", "bundler", "cargo", "composer", "conda", "devcontainers", "docker",
+		"docker-compose", "dotnet-sdk", "elm", "github-actions", "gitsubmodule", "gomod",
+		"gradle", "helm", "julia", "maven", "mix", "npm", "nuget", "opentofu", "pip", "pre-commit",
+		"pub", "rust-toolchain", "swift", "terraform", "uv", "vcpkg",
+	}
+	dependabotIntervals = []string{"daily", "weekly", "monthly", "quarterly", "semiannually", "yearly", "cron"}
+)
+
+func (v *validator) dependabot(root *yaml.Node) {
+	v.mapping(root, "dependabot config", "version", "updates", "registries", "enable-beta-ecosystems", "multi-ecosystem-groups")
+
+	if version := v.required(root, "version", "dependabot config"); version != nil && version.Value != "2" {
+		v.addf(version.Line, "dependabot config version must be 2, got %q", version.Value)
+	}
+
+	updates := v.required(root, "updates", "dependabot config")
+	if updates == nil {
+		return
+	}
+	if updates.Kind != yaml.SequenceNode || len(updates.Content) == 0 {
+		v.addf(updates.Line, "updates must be a non-empty sequence")
+		return
+	}
+
+	for i, update := range updates.Content {
+		update = resolve(update)
+		what := fmt.Sprintf("update %d", i+1)
+		if !v.mapping(update, what,
+			"package-ecosystem", "directory", "directories", "schedule", "allow", "assignees",
+			"commit-message", "cooldown", "exclude-paths", "groups", "ignore",
+			"insecure-external-code-execution", "labels", "milestone", "multi-ecosystem-group",
+			"open-pull-requests-limit", "patterns", "pull-request-branch-name", "rebase-strategy",
+			"registries", "reviewers", "target-branch", "vendor", "versioning-strategy") {
+			continue
+		}
+
+		if ecosystem := v.required(update, "package-ecosystem", what); ecosystem != nil && !slices.Contains(dependabotEcosystems, ecosystem.Value) {
+			v.addf(ecosystem.Line, "%s has unknown package-ecosystem %q", what, ecosystem.Value)
+		}
+
+		for _, registry := range scalars(lookup(update, "registries")) {
+			if registry.Value != "*" && lookup(lookup(root, "registries"), registry.Value) == nil {
+				v.addf(registry.Line, "%s uses undefined registry %q", what, registry.Value)
+			}
+		}
+
+		directory := lookup(update, "directory")
+		directories := lookup(update, "directories")
+		if (directory == nil) == (directories == nil) {
+			v.addf(update.Line, "%s must define exactly one of \"directory\" and \"directories\"", what)
+		}
+
+		schedule := v.required(update, "schedule", what)
+		if schedule == nil || !v.mapping(schedule, what+" schedule") {
+			continue
+		}
+		interval := v.required(schedule, "interval", what+" schedule")
+		if interval == nil {
+			continue
+		}
+		if !slices.Contains(dependabotIntervals, interval.Value) {
+			v.addf(interval.Line, "%s has unknown schedule interval %q", what, interval.Value)
+		}
+		if interval.Value == "cron" {
+			v.required(schedule, "cronjob", what+" schedule")
+		}
+	}
+}
+
+func (v *validator) circleCI(root *yaml.Node) {
+	v.mapping(root, "CircleCI config", "version", "setup", "orbs", "executors", "commands", "jobs", "workflows", "parameters")
+
+	v.required(root, "version", "CircleCI config")
+
+	orbs := map[string]bool{}
+	if n := lookup(root, "orbs"); n != nil && v.mapping(n, "orbs") {
+		for _, p := range pairs(n) {
+			orbs[p.key.Value] = true
+		}
+	}
+
+	jobs := lookup(root, "jobs")
+	if jobs != nil && v.mapping(jobs, "jobs") {
+		for _, job := range pairs(jobs) {
+			what := fmt.Sprintf("job %q", job.key.Value)
+			if !v.mapping(job.value, what) {
+				continue
+			}
+			v.required(job.value, "steps", what)
+		}
+	}
+
+	defined := func(name string) bool {
+		if jobs != nil && lookup(jobs, name) != nil {
+			return true
+		}
+		orb, _, ok := strings.Cut(name, "/")
+		return ok && orbs[orb]
+	}
+
+	workflows := lookup(root, "workflows")
+	if workflows == nil || !v.mapping(workflows, "workflows") {
+		return
+	}
+
+	for _, workflow := range pairs(workflows) {
+		// CircleCI 2.0 configs carry a version key next to the workflows.
+		if workflow.key.Value == "version" {
+			continue
+		}
+
+		what := fmt.Sprintf("workflow %q", workflow.key.Value)
+		if !v.mapping(workflow.value, what, "jobs", "triggers", "when", "unless", "max_auto_reruns") {
+			continue
+		}
+
+		list := v.required(workflow.value, "jobs", what)
+		if list == nil {
+			continue
+		}
+		if list.Kind != yaml.SequenceNode || len(list.Content) == 0 {
+			v.addf(list.Line, "%s jobs must be a non-empty sequence", what)
+			continue
+		}
+
+		names := map[string]bool{}
+		var requires []*yaml.Node
+		for _, entry := range list.Content {
+			entry = resolve(entry)
+
+			var job, params *yaml.Node
+			switch {
+			case entry.Kind == yaml.ScalarNode:
+				job = entry
+			case entry.Kind == yaml.MappingNode && len(entry.Content) == 2:
+				job = entry.Content[0]
+				params = resolve(entry.Content[1])
+			default:
+				v.addf(entry.Line, "%s job entries must be a job name or a single-key mapping", what)
+				continue
+			}
+
+			name := job.Value
+			if params != nil && params.Kind == yaml.MappingNode {
+				if n := lookup(params, "name"); n != nil {
+					name = n.Value
+				}
+				if t := lookup(params, "type"); t != nil && t.Value == "approval" {
+					names[name] = true
+					continue
+				}
+				requires = append(requires, requiredJobs(lookup(params, "requires"))...)
+			}
+
+			if !defined(job.Value) {
+				v.addf(job.Line, "%s uses job %q which is neither defined in jobs nor provided by a declared orb", what, job.Value)
+			}
+			names[name] = true
+		}
+
+		// .circleci/workflows.yml is merged with the repo-owned
+		// .circleci/custom.yml at pipeline runtime, so generated jobs
+		// may require jobs that only custom.yml adds to the workflow.
+		if filepath.Base(v.path) == "workflows.yml" {
+			continue
+		}
+
+		for _, r := range requires {
+			if !names[r.Value] {
+				v.addf(r.Line, "%s requires job %q which is not part of the workflow", what, r.Value)
+			}
+		}
+	}
+}
+
+func (v *validator) precommit(root *yaml.Node) {
+	repos := v.required(root, "repos", "pre-commit config")
+	if repos == nil {
+		return
+	}
+	if repos.Kind != yaml.SequenceNode {
+		v.addf(repos.Line, "repos must be a sequence")
+		return
+	}
+
+	for i, repo := range repos.Content {
+		repo = resolve(repo)
+		what := fmt.Sprintf("repo %d", i+1)
+		if !v.mapping(repo, what, "repo", "rev", "hooks") {
+			continue
+		}
+
+		url := v.required(repo, "repo", what)
+		if url != nil && url.Value != "local" && url.Value != "meta" {
+			v.required(repo, "rev", what)
+		}
+
+		hooks := v.required(repo, "hooks", what)
+		if hooks == nil {
+			continue
+		}
+		if hooks.Kind != yaml.SequenceNode || len(hooks.Content) == 0 {
+			v.addf(hooks.Line, "%s hooks must be a non-empty sequence", what)
+			continue
+		}
+		for j, hook := range hooks.Content {
+			hook = resolve(hook)
+			hookWhat := fmt.Sprintf("%s hook %d", what, j+1)
+			if v.mapping(hook, hookWhat) {
+				v.required(hook, "id", hookWhat)
+			}
+		}
+	}
+}
+
+type pair struct {
+	key   *yaml.Node
+	value *yaml.Node
+}
+
+func pairs(n *yaml.Node) []pair {
+	var out []pair
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		out = append(out, pair{key: n.Content[i], value: resolve(n.Content[i+1])})
+	}
+	return out
+}
+
+func lookup(n *yaml.Node, key string) *yaml.Node {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for _, p := range pairs(n) {
+		if p.key.Value == key {
+			return p.value
+		}
+	}
+	return nil
+}
+
+func resolve(n *yaml.Node) *yaml.Node {
+	for n.Kind == yaml.AliasNode && n.Alias != nil {
+		n = n.Alias
+	}
+	return n
+}
+
+// scalars returns n itself when it is a scalar or the scalar items of n when
+// it is a sequence.
+func scalars(n *yaml.Node) []*yaml.Node {
+	if n == nil {
+		return nil
+	}
+	if n.Kind == yaml.ScalarNode {
+		return []*yaml.Node{n}
+	}
+
+	var out []*yaml.Node
+	for _, item := range n.Content {
+		item = resolve(item)
+		if item.Kind == yaml.ScalarNode {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// requiredJobs returns the job names in a CircleCI requires list. Entries
+// are either job names or mappings of job names to required statuses.
+func requiredJobs(n *yaml.Node) []*yaml.Node {
+	if n == nil || n.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	var out []*yaml.Node
+	for _, item := range n.Content {
+		item = resolve(item)
+		switch item.Kind {
+		case yaml.ScalarNode:
+			out = append(out, item)
+		case yaml.MappingNode:
+			for _, p := range pairs(item) {
+				out = append(out, p.key)
+			}
+		}
+	}
+	return out
+}
+
+func isEmpty(n *yaml.Node) bool {
+	switch n.Kind {
+	case yaml.ScalarNode:
+		return n.Value == "" || n.Tag == "!!null"
+	case yaml.MappingNode, yaml.SequenceNode:
+		return len(n.Content) == 0
+	}
+	return false
+}
@@ -0,0 +1,235 @@
+package gen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/giantswarm/devctl/v8/pkg/gen/input"
+)
+
+func TestValidationFor(t *testing.T) {
+	tests := []struct {
+		path string
+		want input.Validation
+	}{
+		{".github/workflows/zz_generated.gitleaks.yaml", input.ValidationGitHubWorkflow},
+		{"/repo/.github/workflows/ci.yml", input.ValidationGitHubWorkflow},
+		{".github/dependabot.yml", input.ValidationDependabot},
+		{".pre-commit-config.yaml", input.ValidationPrecommit},
+		{".circleci/config.yml", input.ValidationCircleCI},
+		{".circleci/workflows.yml", input.ValidationCircleCI},
+		{".circleci/custom.yml", input.ValidationYAML},
+		{".github/zizmor.base.yml", input.ValidationYAML},
+		{"renovate.json5", input.ValidationJSON5},
+		{"package.json", input.ValidationJSON5},
+		{"Makefile.gen.go.mk", input.ValidationNone},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			if got := ValidationFor(tc.path); got != tc.want {
+				t.Errorf("ValidationFor() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		validation input.Validation
+		content    string
+		// wantErrors are the problems expected in the error, in order. No
+		// entries means the content is valid.
+		wantErrors []string
+	}{
+		{
+			name:    "valid yaml",
+			path:    "values.yaml",
+			content: "a: 1\n---\nb: 2\n",
+		},
+		{
+			name:       "malformed yaml",
+			path:       "values.yaml",
+			content:    "a: 1\nb: 2\n c: 3\n",
+			wantErrors: []string{"values.yaml:3: invalid YAML:"},
+		},
+		{
+			name:       "malformed yaml ignored when validation is disabled",
+			path:       "values.yaml",
+			validation: input.ValidationNone,
+			content:    "a: {{ .Values.a }}\n",
+		},
+		{
+			name:    "valid json5",
+			path:    "renovate.json5",
+			content: "{\n  // comment\n  extends: ['config:base'],\n}\n",
+		},
+		{
+			name:       "malformed json5",
+			path:       "renovate.json5",
+			content:    "{\n  extends: ['config:base'],\n  labels: ['a' 'b'],\n}\n",
+			wantErrors: []string{"renovate.json5:3: invalid JSON5:"},
+		},
+		{
+			name: "valid workflow",
+			path: ".github/workflows/ci.yaml",
+			content: `name: CI
+on:
+  push: {}
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+    - uses: actions/checkout@v4
+    - run: make test
+  release:
+    needs: build
+    uses: giantswarm/github-workflows/.github/workflows/release.yaml@main
+`,
+		},
+		{
+			name: "invalid workflow",
+			path: ".github/workflows/ci.yaml",
+			content: `name: CI
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    needs: [lint]
+    step:
+    - uses: actions/checkout@v4
+  test:
+    runs-on: ubuntu-latest
+    steps:
+    - name: both
+      uses: actions/checkout@v4
+      run: make test
+`,
+			wantErrors: []string{
+				`.github/workflows/ci.yaml:1: workflow is missing required key "on"`,
+				`.github/workflows/ci.yaml:6: unknown key "step" in job "build"`,
+				`.github/workflows/ci.yaml:5: job "build" needs unknown job "lint"`,
+				`.github/workflows/ci.yaml:4: job "build" is missing required key "steps"`,
+				`.github/workflows/ci.yaml:11: job "test" step 1 must define exactly one of "uses" and "run"`,
+			},
+		},
+		{
+			name: "valid dependabot",
+			path: ".github/dependabot.yml",
+			content: `version: 2
+updates:
+- package-ecosystem: gomod
+  directory: "/"
+  schedule:
+    interval: weekly
+`,
+		},
+		{
+			name: "invalid dependabot",
+			path: ".github/dependabot.yml",
+			content: `version: 1
+updates:
+- package-ecosystem: go
+  directory: "/"
+  schedule:
+    interval: hourly
+- package-ecosystem: docker
+  registries: [dockerhub]
+  schedule:
+    interval: cron
+`,
+			wantErrors: []string{
+				`.github/dependabot.yml:1: dependabot config version must be 2, got "1"`,
+				`.github/dependabot.yml:3: update 1 has unknown package-ecosystem "go"`,
+				`.github/dependabot.yml:6: update 1 has unknown schedule interval "hourly"`,
+				`.github/dependabot.yml:8: update 2 uses undefined registry "dockerhub"`,
+				`.github/dependabot.yml:7: update 2 must define exactly one of "directory" and "directories"`,
+				`.github/dependabot.yml:10: update 2 schedule is missing required key "cronjob"`,
+			},
+		},
+		{
+			name: "valid circleci",
+			path: ".circleci/config.yml",
+			content: `version: 2.1
+orbs:
+  architect: giantswarm/architect@6.0.0
+jobs:
+  lint:
+    docker:
+    - image: cimg/base:stable
+    steps:
+    - checkout
+workflows:
+  build:
+    jobs:
+    - lint
+    - hold:
+        type: approval
+    - architect/go-build:
+        name: go-build
+        requires:
+        - lint
+        - hold
+`,
+		},
+		{
+			name: "invalid circleci",
+			path: ".circleci/config.yml",
+			content: `version: 2.1
+workflows:
+  build:
+    jobs:
+    - lint
+    - architect/go-build:
+        requires:
+        - test
+`,
+			wantErrors: []string{
+				`.circleci/config.yml:5: workflow "build" uses job "lint" which is neither defined in jobs nor provided by a declared orb`,
+				`.circleci/config.yml:6: workflow "build" uses job "architect/go-build" which is neither defined in jobs nor provided by a declared orb`,
+				`.circleci/config.yml:8: workflow "build" requires job "test" which is not part of the workflow`,
+			},
+		},
+		{
+			name: "invalid pre-commit config",
+			path: ".pre-commit-config.yaml",
+			content: `repos:
+- repo: https://github.com/pre-commit/pre-commit-hooks
+  hooks:
+  - name: trailing-whitespace
+`,
+			wantErrors: []string{
+				`.pre-commit-config.yaml:2: repo 1 is missing required key "rev"`,
+				`.pre-commit-config.yaml:4: repo 1 hook 1 is missing required key "id"`,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(input.Input{Path: tc.path, Validation: tc.validation}, []byte(tc.content))
+
+			if len(tc.wantErrors) == 0 {
+				if err != nil {
+					t.Fatalf("Validate() returned unexpected error: %v", err)
+				}
+				return
+			}
+
+			if !IsValidationFailed(err) {
+				t.Fatalf("Validate() error = %v, want validation failed error", err)
+			}
+
+			got := strings.Split(strings.TrimPrefix(err.Error(), "validation failed error: "), "\n")
+			if len(got) != len(tc.wantErrors) {
+				t.Fatalf("Validate() returned %d problems, want %d:\n%s", len(got), len(tc.wantErrors), err)
+			}
+			for i, want := range tc.wantErrors {
+				if !strings.HasPrefix(got[i], want) {
+					t.Errorf("problem %d = %q, want prefix %q", i, got[i], want)
+				}
+			}
+		})
+	}
+}
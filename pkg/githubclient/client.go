@@ -3,6 +3,7 @@ package githubclient
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"time"
@@ -20,6 +21,9 @@ type Config struct {
 	Logger      *logrus.Logger
 	AccessToken string
 	DryRun      bool
+	// Progress receives the git clone progress output. Defaults to
+	// os.Stdout.
+	Progress io.Writer
 }
 
 type Client struct {
@@ -27,6 +31,7 @@ type Client struct {
 	accessToken string
 	workDir     string
 	dryRun      bool
+	progress    io.Writer
 	ghClient    *github.Client
 }
 
@@ -37,6 +42,9 @@ func New(config Config) (*Client, error) {
 	if config.AccessToken == "" {
 		return nil, microerror.Maskf(invalidConfigError, "%T.AccessToken must not be empty", config)
 	}
+	if config.Progress == nil {
+		config.Progress = os.Stdout
+	}
 
 	var transport http.RoundTripper = &oauth2.Transport{
 		Source: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: config.AccessToken}),
@@ -56,6 +64,7 @@ func New(config Config) (*Client, error) {
 		dryRun:      config.DryRun,
 		logger:      config.Logger,
 		accessToken: config.AccessToken,
+		progress:    config.Progress,
 		ghClient:    ghClient,
 	}
 
@@ -66,7 +75,7 @@ func (c *Client) CloneRepository(ctx context.Context, owner, repo, workDir strin
 	c.workDir = workDir
 	_, err := git.PlainClone(workDir, false, &git.CloneOptions{
 		URL:      fmt.Sprintf("https://%s@github.com/%s/%s", c.accessToken, owner, repo),
-		Progress: os.Stdout,
+		Progress: c.progress,
 	})
 
 	return microerror.Mask(err)
@@ -105,6 +114,16 @@ func (c *Client) CreateBranch(ctx context.Context, newBranch string) error {
 }
 
 func (c *Client) CommitAndPush(ctx context.Context, owner, repo, branch, message string) error {
+	return c.commitAndPush(ctx, owner, repo, branch, message, false)
+}
+
+// CommitAndForcePush behaves like CommitAndPush but overwrites the remote
+// branch, so a branch pushed by a previous run can be replaced.
+func (c *Client) CommitAndForcePush(ctx context.Context, owner, repo, branch, message string) error {
+	return c.commitAndPush(ctx, owner, repo, branch, message, true)
+}
+
+func (c *Client) commitAndPush(ctx context.Context, owner, repo, branch, message string, force bool) error {
 	gitRepo, err := git.PlainOpen(c.workDir)
 	if err != nil {
 		return microerror.Mask(err)
@@ -132,6 +151,7 @@ func (c *Client) CommitAndPush(ctx context.Context, owner, repo, branch, message
 	err = gitRepo.Push(&git.PushOptions{
 		RemoteName: "origin",
 		RefSpecs:   []config.RefSpec{config.RefSpec(fmt.Sprintf("refs/heads/%s:refs/heads/%s", branch, branch))},
+		Force:      force,
 	})
 	if err != nil {
 		return microerror.Mask(err)
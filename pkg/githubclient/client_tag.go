@@ -0,0 +1,93 @@
+package githubclient
+
+import (
+	"context"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/giantswarm/microerror"
+	"github.com/google/go-github/v90/github"
+)
+
+// LatestTag returns the tag of the latest release of the repository. For
+// repositories without releases it returns the highest semver tag.
+func (c *Client) LatestTag(ctx context.Context, owner, repo string) (string, error) {
+	c.logger.Debugf("getting latest tag for owner %#q and repository %#q", owner, repo)
+
+	underlyingClient := c.GetUnderlyingClient(ctx)
+
+	release, _, err := underlyingClient.Repositories.GetLatestRelease(ctx, owner, repo)
+	if err == nil && release.GetTagName() != "" {
+		return release.GetTagName(), nil
+	} else if err != nil && !isGithub404(err) {
+		return "", microerror.Mask(err)
+	}
+
+	var latest string
+	var latestVersion *semver.Version
+	opt := &github.ListOptions{PerPage: 100}
+	for {
+		tags, resp, err := underlyingClient.Repositories.ListTags(ctx, owner, repo, opt)
+		if isGithub404(err) {
+			return "", microerror.Maskf(notFoundError, "repository %#q for owner %#q", repo, owner)
+		} else if err != nil {
+			return "", microerror.Mask(err)
+		}
+
+		for _, t := range tags {
+			v, err := semver.NewVersion(t.GetName())
+			if err != nil || v.Prerelease() != "" {
+				continue
+			}
+			if latestVersion == nil || v.GreaterThan(latestVersion) {
+				latest, latestVersion = t.GetName(), v
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	if latest == "" {
+		return "", microerror.Maskf(notFoundError, "semver tag in repository %#q for owner %#q", repo, owner)
+	}
+
+	return latest, nil
+}
+
+// ListReleases returns the published releases of the repository, newest
+// first. Drafts and prereleases are skipped.
+func (c *Client) ListReleases(ctx context.Context, owner, repo string) ([]Release, error) {
+	c.logger.Debugf("listing releases for owner %#q and repository %#q", owner, repo)
+
+	underlyingClient := c.GetUnderlyingClient(ctx)
+
+	var releases []Release
+	opt := &github.ListOptions{PerPage: 100}
+	for {
+		page, resp, err := underlyingClient.Repositories.ListReleases(ctx, owner, repo, opt)
+		if isGithub404(err) {
+			return nil, microerror.Maskf(notFoundError, "repository %#q for owner %#q", repo, owner)
+		} else if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		for _, r := range page {
+			if r.GetDraft() || r.GetPrerelease() {
+				continue
+			}
+			releases = append(releases, Release{
+				Tag:  r.GetTagName(),
+				Body: r.GetBody(),
+				URL:  r.GetHTMLURL(),
+			})
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	return releases, nil
+}
@@ -2,21 +2,24 @@ package githubclient
 
 import (
 	"bytes"
+	"encoding/json"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 )
 
 // dryRunTransport short-circuits non-safe HTTP methods with a synthetic
-// 200 OK and an empty JSON body. Safe methods pass through unchanged.
+// 200 OK and an empty JSON body. Safe methods and GraphQL queries pass
+// through unchanged, GraphQL mutations are short-circuited.
 type dryRunTransport struct {
 	inner  http.RoundTripper
 	logger *logrus.Logger
 }
 
 func (t *dryRunTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	if isSafeHTTPMethod(req.Method) {
+	if isSafeHTTPMethod(req.Method) || isGraphQLQuery(req) {
 		return t.inner.RoundTrip(req)
 	}
 
@@ -45,3 +48,29 @@ func isSafeHTTPMethod(method string) bool {
 	}
 	return false
 }
+
+// isGraphQLQuery reports whether req is a GraphQL request that only reads.
+// The body is restored for the request to be sent.
+func isGraphQLQuery(req *http.Request) bool {
+	if req.Method != http.MethodPost || !strings.HasSuffix(req.URL.Path, "/graphql") || req.Body == nil {
+		return false
+	}
+
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+
+	var payload struct {
+		Query string `json:"query"`
+	}
+	err = json.Unmarshal(body, &payload)
+	if err != nil {
+		return false
+	}
+	query := strings.TrimSpace(payload.Query)
+
+	return strings.HasPrefix(query, "query") || strings.HasPrefix(query, "{")
+}
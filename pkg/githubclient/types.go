@@ -70,6 +70,13 @@ func newRepositoryFile(ghContent *github.RepositoryContent) (RepositoryFile, err
 	return r, err
 }
 
+// Release is a published GitHub release.
+type Release struct {
+	Tag  string
+	Body string
+	URL  string
+}
+
 func toString(p *string) (string, error) {
 	if p == nil {
 		return "", microerror.Maskf(executionError, "value is nil")